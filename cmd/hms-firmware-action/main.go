@@ -60,7 +60,7 @@ const redfishFirmwareInventory = redfishPath + "/FirmwareInventory"
 const redfishSoftwareInventory = redfishPath + "/SoftwareInventory"
 
 const defaultSMSServer = "https://api-gw-service-nmn.local/apis/smd"
-const defaultNodeBlacklist = "ignore_ignore_ignore"
+const unsetNodeBlacklist = "ignore_ignore_ignore" //what deployments set NODE_BLACKLIST to when nothing was excluded
 
 const manufacturerCray = "cray"
 const manufacturerGigabyte = "gigabyte"
@@ -74,21 +74,25 @@ const (
 	dfltMaxHTTPBackoff = 8
 )
 const defaultS3Endpoint = "s3"
+const defaultEventListenAddress = ":28801"
+const defaultRFMaxPerHost = 4
+const defaultRFMaxPerChassis = 16
 const defaultTFTPEndpoint = "TFTP"
 
 var S3_ENDPOINT string
 var TFTP_ENDPOINT string
 
 var nodeBlacklistSt string
-var nodeBlacklist []string
 
 var FileCheckClient *retryablehttp.Client
 
 var Running = true
 var DSP storage.StorageProvider
+var ASP storage.ArchiveProvider
 var HSM hsm.HSMProvider
 
 var restSrv *http.Server = nil
+var eventSrv *http.Server = nil
 var waitGroup sync.WaitGroup
 var mainLogger *logrus.Logger
 
@@ -110,6 +114,8 @@ func main() {
 	var runControl bool = true
 	var err error
 	var DaysToKeepActions int
+	var rfMaxPerHost, rfMaxPerChassis, rfMaxRequests int
+	var quarantineThreshold int
 	srv := &http.Server{Addr: defaultPORT}
 
 	///////////////////////////////
@@ -117,7 +123,7 @@ func main() {
 	//////////////////////////////
 
 	flag.StringVar(&StateManagerServer, "sms_server", defaultSMSServer, "SMS Server")
-	flag.StringVar(&nodeBlacklistSt, "node_blacklist", defaultNodeBlacklist, "Node Black List")
+	flag.StringVar(&nodeBlacklistSt, "node_blacklist", "", "Deprecated, use exclusion policies; node roles that are moved into one")
 	flag.StringVar(&S3_ENDPOINT, "s3_endpoint", defaultS3Endpoint, "S3 Endpoint")
 	flag.StringVar(&TFTP_ENDPOINT, "tftp_endpoint", defaultTFTPEndpoint, "TFTP Endpoint")
 	flag.BoolVar(&runControl, "run_control", runControl, "run control loop; false runs API only")
@@ -126,6 +132,10 @@ func main() {
 	flag.StringVar(&VaultKeypath, "vault_keypath", "secret/hms-creds",
 		"Keypath for Vault credentials.")
 	flag.IntVar(&DaysToKeepActions, "days_to_keep_actions", 0, "Days to Keep Actions before deleting")
+	flag.IntVar(&rfMaxPerHost, "rf_max_per_host", defaultRFMaxPerHost, "Concurrent Redfish requests per BMC; 0 is no limit")
+	flag.IntVar(&rfMaxPerChassis, "rf_max_per_chassis", defaultRFMaxPerChassis, "Concurrent Redfish requests per chassis; 0 is no limit")
+	flag.IntVar(&rfMaxRequests, "rf_max_requests", 0, "Concurrent Redfish requests overall; 0 is no limit")
+	flag.IntVar(&quarantineThreshold, "quarantine_threshold", domain.DefaultQuarantineThreshold, "Consecutive failures before a device is quarantined; 0 never quarantines")
 
 	flag.Parse()
 
@@ -136,13 +146,11 @@ func main() {
 	}
 	mainLogger.Info("Service/Instance name: " + serviceName)
 
-	nodeBlacklist = append(nodeBlacklist, strings.Split(nodeBlacklistSt, ",")...)
-
 	mainLogger.Info("SMS Server: " + StateManagerServer)
-	mainLogger.Info("Node Black List: ", nodeBlacklist)
 	mainLogger.Info("HSM Lock Enabled: ", hsmlockEnabled)
 	mainLogger.Info("Vault Enabled: ", VaultEnabled)
 	mainLogger.Info("Days To Keep Actions: ", DaysToKeepActions)
+	mainLogger.Info("Quarantine Threshold: ", quarantineThreshold)
 	mainLogger.SetReportCaller(true)
 
 	///////////////////////////////
@@ -247,7 +255,33 @@ func main() {
 		DSP = tmpStorageImplementation
 		mainLogger.Info("Storage Provider: ETCD")
 	}
-	DSP.Init(logy)
+	err = DSP.Init(logy)
+	if err != nil {
+		//a persistence file that can't be read would otherwise be overwritten with an empty store
+		mainLogger.Fatal("could not initialize storage provider: ", err)
+	}
+
+	////ARCHIVE CONFIGURATION
+	envstr = os.Getenv("ARCHIVE")
+	if envstr == "FILE" {
+		ASP = &storage.FileArchive{
+			Logger: logy,
+		}
+		mainLogger.Info("Archive Provider: File")
+	} else if envstr == "S3" {
+		ASP = &storage.S3Archive{
+			Logger: logy,
+		}
+		mainLogger.Info("Archive Provider: S3")
+	} else {
+		mainLogger.Info("Archive Provider: None, expired actions and snapshots are deleted")
+	}
+	if ASP != nil {
+		err = ASP.Init(logy)
+		if err != nil {
+			mainLogger.Error("Could not initialize archive provider, expired actions and snapshots will not be removed: ", err)
+		}
+	}
 
 	//Hardware State Manager CONFIGURATION
 	tmpHSM := &hsm.HSMv0{} //TODO this can more to config section
@@ -264,6 +298,49 @@ func main() {
 	var domainGlobals domain.DOMAIN_GLOBALS
 	domainGlobals.NewGlobals(&BaseTRSTask, &TLOC_rf, &TLOC_svc, rfClient,
 		svcClient, rfClientLock, &Running, &DSP, &HSM, DaysToKeepActions)
+	if ASP != nil {
+		domainGlobals.ASP = &ASP
+	}
+
+	domainGlobals.S3Endpoint = S3_ENDPOINT
+	domainGlobals.TFTPEndpoint = TFTP_ENDPOINT
+	domainGlobals.QuarantineThreshold = quarantineThreshold
+
+	domainGlobals.RFLimiter = domain.NewHostLimiter(rfMaxPerHost, rfMaxPerChassis, rfMaxRequests)
+	mainLogger.Infof("Redfish request limits: %d per BMC, %d per chassis, %d overall (0 is no limit)",
+		rfMaxPerHost, rfMaxPerChassis, rfMaxRequests)
+
+	////HOOK CONFIGURATION
+	if hooksFile := os.Getenv("FAS_HOOKS_FILE"); hooksFile != "" {
+		hooks, err := domain.LoadHooks(hooksFile)
+		if err != nil {
+			//a site that drains nodes before an update would rather have no FAS than one that skips the drain
+			mainLogger.WithField("file", hooksFile).Fatal("could not load hooks: ", err)
+		}
+		domainGlobals.Hooks = hooks
+		mainLogger.Infof("Loaded %d hooks from %s", len(hooks), hooksFile)
+	}
+
+	////POWER CLIENT CONFIGURATION
+	//reboots needManualReboot images after the update; none leaves that to the admin
+	if powerClient := os.Getenv("FAS_POWER_CLIENT"); powerClient != "none" {
+		client, err := domain.NewPowerClient(powerClient, os.Getenv("FAS_PCS_URL"))
+		if err != nil {
+			mainLogger.Fatal(err)
+		}
+		domainGlobals.PowerClient = client
+		mainLogger.Infof("Power client: %T", client)
+	} else {
+		mainLogger.Info("Power client: None, images that need a manual reboot are not rebooted")
+	}
+
+	////REDFISH EVENT CONFIGURATION
+	domainGlobals.EventDestination = strings.TrimSuffix(os.Getenv("FAS_EVENT_DESTINATION"), "/")
+	if domainGlobals.EventDestination != "" {
+		mainLogger.Info("Redfish event destination: ", domainGlobals.EventDestination)
+	} else {
+		mainLogger.Info("Redfish event destination: None, updates are verified by polling only")
+	}
 
 	//Wait for vault PKI to respond for CA bundle.  Once this happens, re-do
 	//the globals.  This goroutine will run forever checking if the CA trust
@@ -342,6 +419,21 @@ func main() {
 	//////////////////////////////
 	domain.Init(&domainGlobals)
 
+	//node_blacklist is replaced by exclusion policies; its roles move into one so nothing excluded before gets updated
+	var blacklistRoles []string
+	for _, role := range strings.Split(nodeBlacklistSt, ",") {
+		if role = strings.TrimSpace(role); role != "" && role != unsetNodeBlacklist {
+			blacklistRoles = append(blacklistRoles, role)
+		}
+	}
+	if len(blacklistRoles) > 0 {
+		mainLogger.Warn("node_blacklist is deprecated; its roles are kept in exclusion policy ", domain.NodeBlacklistPolicyID.String(),
+			", manage them through /exclusionpolicies and remove the flag")
+		if err = domain.MigrateNodeBlacklist(blacklistRoles); err != nil {
+			mainLogger.Error("Could not migrate node_blacklist into an exclusion policy: ", err)
+		}
+	}
+
 	///////////////////////////////
 	//SIGNAL HANDLING -- //TODO does this need to move up ^ so it happens sooner?
 	//////////////////////////////
@@ -363,6 +455,11 @@ func main() {
 		}
 
 		ctx := context.Background()
+		if eventSrv != nil {
+			if err := eventSrv.Shutdown(ctx); err != nil {
+				mainLogger.Error("Unable to stop Redfish event listener: ", err)
+			}
+		}
 		if restSrv != nil {
 			if err := restSrv.Shutdown(ctx); err != nil {
 				logrus.Panic("ERROR: Unable to stop REST collection server!")
@@ -395,6 +492,13 @@ func main() {
 	} else {
 		mainLogger.Info("NOT starting control loop")
 	}
+	//Redfish event listener
+	if domainGlobals.EventDestination != "" {
+		if runControl {
+			go domain.RemoveStaleEventSubscriptions()
+		}
+		doEventListener()
+	}
 	//Rest Server
 	waitGroup.Add(1)
 	doRest("28800")
@@ -406,6 +510,9 @@ func main() {
 	waitGroup.Wait()
 	mainLogger.Info("HTTP server shutdown, waiting for idle connection to close...")
 	<-idleConnsClosed
+	if err := DSP.Close(); err != nil {
+		mainLogger.Error("Could not close storage provider: ", err)
+	}
 	mainLogger.Info("Done. Exiting.")
 }
 
@@ -430,3 +537,29 @@ func doRest(serverPort string) {
 	mainLogger.Info("REST collection server started on port " + serverPort)
 	restSrv = srv
 }
+
+func doEventListener() {
+	address := os.Getenv("FAS_EVENT_LISTEN_ADDRESS")
+	if address == "" {
+		address = defaultEventListenAddress
+	}
+	certFile := os.Getenv("FAS_EVENT_TLS_CERT")
+	keyFile := os.Getenv("FAS_EVENT_TLS_KEY")
+
+	srv := &http.Server{Addr: address, Handler: api.NewEventRouter()}
+
+	go func() {
+		var err error
+		if certFile != "" && keyFile != "" {
+			err = srv.ListenAndServeTLS(certFile, keyFile)
+		} else {
+			err = srv.ListenAndServe()
+		}
+		if err != nil && err != http.ErrServerClosed {
+			mainLogger.Error("Redfish event listener stopped: ", err)
+		}
+	}()
+
+	mainLogger.Info("Redfish event listener started on " + address)
+	eventSrv = srv
+}
@@ -63,24 +63,28 @@ const (
 var loopDelay = time.Duration(5) * time.Second
 
 type PayloadCray struct {
-	ImageURI         string   `json:"ImageURI"`
-	TransferProtocol string   `json:"TransferProtocol"`
-	Targets          []string `json:"Targets"`
+	ImageURI           string   `json:"ImageURI"`
+	TransferProtocol   string   `json:"TransferProtocol"`
+	Targets            []string `json:"Targets"`
+	OperationApplyTime string   `json:"@Redfish.OperationApplyTime,omitempty"`
 }
 
 type PayloadGigabyte struct {
-	ImageURI         string `json:"ImageURI"`
-	TransferProtocol string `json:"TransferProtocol"`
-	UpdateComponent  string `json:"UpdateComponent"`
+	ImageURI           string `json:"ImageURI"`
+	TransferProtocol   string `json:"TransferProtocol"`
+	UpdateComponent    string `json:"UpdateComponent"`
+	OperationApplyTime string `json:"@Redfish.OperationApplyTime,omitempty"`
 }
 
 type PayloadHpe struct {
-	ImageURI string `json:"ImageURI"`
+	ImageURI           string `json:"ImageURI"`
+	OperationApplyTime string `json:"@Redfish.OperationApplyTime,omitempty"`
 }
 
 type PayloadFoxconn struct {
-	ImageURI       string `json:"ImageURI"`
-	RestoreDefault bool
+	ImageURI           string `json:"ImageURI"`
+	RestoreDefault     bool
+	OperationApplyTime string `json:"@Redfish.OperationApplyTime,omitempty"`
 }
 
 func drainAndCloseBodyWithCtxCancel(resp *http.Response, ctxCancel context.CancelFunc) {
@@ -153,9 +157,19 @@ func controlLoop(domainGlobal *domain.DOMAIN_GLOBALS) {
 			//the action has signaled abort
 			if action.State.Is("abortSignaled") {
 				mainLogger.WithField("actionID", action.ActionID).Debug("CONTROL LOOP - @ABORTING")
-				ops, err := domain.GetAllOperationsFromAction(action.ActionID)
-				if err != nil {
-					mainLogger.Error(err)
+				//pre action hooks still running when the abort came in finish before the post action hooks undo them
+				if domain.ActionHooksPending(storage.HookPreAction, action.ActionID) {
+					if done, _ := domain.PollActionHooks(storage.HookPreAction, domain.ActionHookContext(storage.HookPreAction, action)); !done {
+						continue
+					}
+				}
+				var ops []storage.Operation
+				var err error
+				if !domain.ActionHooksPending(storage.HookPostAction, action.ActionID) { //otherwise the operations were aborted on an earlier pass
+					ops, err = domain.GetAllOperationsFromAction(action.ActionID)
+					if err != nil {
+						mainLogger.Error(err)
+					}
 				}
 				for _, op := range ops {
 					//I will send a true on the quit channel, but I dont close the channel. Eventually I do delete it
@@ -196,6 +210,17 @@ func controlLoop(domainGlobal *domain.DOMAIN_GLOBALS) {
 						domain.StoreOperation(op)
 					}
 				}
+				//the post action hooks undo what the pre action hooks did, e.g. resume drained nodes, so they run on an abort too
+				hookContext := domain.ActionHookContext(storage.HookPostAction, action)
+				hookContext.State = "aborted"
+				done, err := domain.PollActionHooks(storage.HookPostAction, hookContext)
+				if !done {
+					mainLogger.WithField("actionID", action.ActionID).Debug("waiting for the post action hooks")
+					continue
+				}
+				if err != nil {
+					action.Errors = append(action.Errors, err.Error())
+				}
 				action.State.Event(context.Background(), "abort")
 				action.EndTime.Scan(time.Now())
 				domain.StoreAction(action)
@@ -205,20 +230,41 @@ func controlLoop(domainGlobal *domain.DOMAIN_GLOBALS) {
 				}
 
 				//verify if the action is still blocked
-			} else if action.State.Is("running") {
+				//a paused action keeps its place, so actions behind it stay blocked; it just doesnt launch anything new
+			} else if action.State.Is("running") || action.State.Is("paused") {
 				if lastRunningAction == uuid.Nil {
 					lastRunningAction = action.ActionID
 				}
-				mainLogger.WithField("actionID", action.ActionID).Debug("CONTROL LOOP - @RUNNING")
+				paused := action.State.Is("paused")
+				if paused {
+					mainLogger.WithField("actionID", action.ActionID).Debug("CONTROL LOOP - @PAUSED")
+				} else {
+					mainLogger.WithField("actionID", action.ActionID).Debug("CONTROL LOOP - @RUNNING")
+				}
+
+				handleOperationSignals(action.ActionID, quitChannels, restart, domainGlobal)
+
+				if !paused {
+					domain.ActivateStagedOperations(action)
+				}
 
 				operations := domain.GetAllActiveOperationsFromAction(action.ActionID)
+				var launchable map[uuid.UUID]bool
+				if len(action.Parameters.LaunchPolicies) > 0 {
+					allOperations, _ := domain.GetAllOperationsFromAction(action.ActionID)
+					launchable = domain.LaunchableOperations(action.Parameters.LaunchPolicies, allOperations, "configured")
+				}
 				for opnum, operation := range operations {
+					if operation.Signal != "" {
+						continue //waiting for doLaunch/doVerify to act on the quit signal
+					}
 					ToImagePB := domain.GetImageStorage(operation.ToImageID)
 					if ToImagePB.IsError {
 						mainLogger.Error(ToImagePB.Error.Detail)
 						operation.Error = errors.New(ToImagePB.Error.Detail)
 						operation.State.Event(context.Background(), "fail")
 						operation.StateHelper = "could not find the image"
+						operation.FailureCode = storage.FailureImageNotFound
 						operation.EndTime.Scan(time.Now())
 						domain.StoreOperation(operation)
 						continue
@@ -232,6 +278,7 @@ func controlLoop(domainGlobal *domain.DOMAIN_GLOBALS) {
 
 					var ToImage storage.Image
 					ToImage = ToImagePB.Obj.(storage.Image)
+					retryPolicy := storage.ResolveRetryPolicy(action.Parameters.RetryPolicy, ToImage.RetryPolicy)
 
 					var quitChan chan bool
 					if _, ok := quitChannels[operation.OperationID]; !ok {
@@ -246,15 +293,19 @@ func controlLoop(domainGlobal *domain.DOMAIN_GLOBALS) {
 					now := time.Now()
 					hasTipped := now.After(tripper)
 					//Launch or relaunch things
-					if operation.State.Is("configured") {
+					if operation.State.Is("configured") && paused {
+						mainLogger.WithFields(logrus.Fields{"operationID": operation.OperationID}).Trace("action paused, not launching")
+					} else if operation.State.Is("configured") && launchable != nil && !launchable[operation.OperationID] {
+						mainLogger.WithFields(logrus.Fields{"operationID": operation.OperationID}).Trace("held back by a launch policy")
+					} else if operation.State.Is("configured") {
 						mainLogger.WithFields(logrus.Fields{"operationID": operation.OperationID}).Debug("starting doLaunch")
-						go doLaunch(operation, ToImage, action.Command, domainGlobal, quitChan)
+						go doLaunch(operation, ToImage, action.Command, retryPolicy, domainGlobal, quitChan)
 					} else if operation.State.Is("needsVerified") {
 						mainLogger.WithFields(logrus.Fields{"operationID": operation.OperationID}).Debug("starting doVerify")
 						go doVerify(operation, ToImage, FromImage, domainGlobal, quitChan)
 					} else if operation.State.Is("inProgress") && (hasTipped || restart) {
 						mainLogger.WithFields(logrus.Fields{"operationID": operation.OperationID}).Warn("restarting doLaunch, operation failed to refresh")
-						go doLaunch(operation, ToImage, action.Command, domainGlobal, quitChan)
+						go doLaunch(operation, ToImage, action.Command, retryPolicy, domainGlobal, quitChan)
 					} else if operation.State.Is("verifying") && (hasTipped || restart) {
 						mainLogger.WithFields(logrus.Fields{"operationID": operation.OperationID}).Warn("restarting doVerify, operation failed to refresh")
 						go doVerify(operation, ToImage, FromImage, domainGlobal, quitChan)
@@ -270,10 +321,27 @@ func controlLoop(domainGlobal *domain.DOMAIN_GLOBALS) {
 
 				//Check if the whole thing is done!
 				counts := domain.GetOperationSummaryFromAction(action.ActionID)
-				if counts.Total == counts.Aborted+counts.NoSolution+counts.NoOperation+counts.Succeeded+counts.Failed {
+				done := counts.Aborted + counts.NoSolution + counts.NoOperation + counts.Succeeded + counts.Failed
+				if action.Activation == nil {
+					done += counts.Staged //the images stay staged until the action is activated
+				}
+				//a paused action with nothing left to launch or verify finishes too, there is nothing to resume
+				if counts.Total == done {
+					hookContext := domain.ActionHookContext(storage.HookPostAction, action)
+					hookContext.State = "completed"
+					hooksDone, err := domain.PollActionHooks(storage.HookPostAction, hookContext)
+					if !hooksDone {
+						//the action keeps its place until they are done, so the actions behind it wait
+						mainLogger.WithField("actionID", action.ActionID).Debug("operations complete, waiting for the post action hooks")
+						continue
+					}
+					if err != nil {
+						action.Errors = append(action.Errors, err.Error())
+					}
 					mainLogger.WithField("actionID", action.ActionID).Debug("operations complete, finishing action")
 					action.State.Event(context.Background(), "finish")
 					action.EndTime.Scan(time.Now())
+					action.PauseReason = ""
 					for _, op := range totalOperations {
 						err := (*domainGlobal.HSM).ClearLock([]string{op.Xname})
 						if err != nil {
@@ -293,9 +361,22 @@ func controlLoop(domainGlobal *domain.DOMAIN_GLOBALS) {
 				mainLogger.WithField("actionID", action.ActionID).Debug("CONTROL LOOP - @CONFIGURED")
 
 				if lastRunningAction == uuid.Nil { //everthing else so far has been aborted or completed.
-					action.State.Event(context.Background(), "start")
-					mainLogger.WithFields(logrus.Fields{"actionID": action.ActionID}).Debug("action is starting")
-					lastRunningAction = action.ActionID
+					done, err := domain.PollActionHooks(storage.HookPreAction, domain.ActionHookContext(storage.HookPreAction, action))
+					if !done {
+						//it is as good as running, the actions behind it wait
+						mainLogger.WithField("actionID", action.ActionID).Debug("waiting for the pre action hooks")
+						lastRunningAction = action.ActionID
+						continue
+					}
+					if err != nil {
+						action.Errors = append(action.Errors, err.Error())
+						action.State.Event(context.Background(), "signalAbort")
+						mainLogger.WithFields(logrus.Fields{"actionID": action.ActionID, "err": err}).Warn("pre action hook failed, aborting action")
+					} else {
+						action.State.Event(context.Background(), "start")
+						mainLogger.WithFields(logrus.Fields{"actionID": action.ActionID}).Debug("action is starting")
+						lastRunningAction = action.ActionID
+					}
 
 				} else {
 					action.BlockedBy = append(action.BlockedBy, lastRunningAction)
@@ -331,6 +412,57 @@ func controlLoop(domainGlobal *domain.DOMAIN_GLOBALS) {
 	}
 }
 
+// handleOperationSignals -> acts on abort/skip requests for single operations of an action.  An operation that is in
+// flight gets a quit on its channel and doLaunch/doVerify clean up after themselves; everything else is aborted or
+// skipped right here, and its lock is released.
+func handleOperationSignals(actionID uuid.UUID, quitChannels map[uuid.UUID]chan bool, restart bool, domainGlobal *domain.DOMAIN_GLOBALS) {
+	ops, err := domain.GetAllOperationsFromAction(actionID)
+	if err != nil {
+		mainLogger.Error(err)
+		return
+	}
+	for _, op := range ops {
+		if op.Signal == "" || !op.State.Can("abort") {
+			continue
+		}
+
+		hasTipped := time.Now().After(op.RefreshTime.Time.Add(time.Duration(10) * time.Minute))
+		inFlight := (op.State.Is("inProgress") || op.State.Is("verifying")) && !hasTipped && !restart
+		if quitChannel, ok := quitChannels[op.OperationID]; ok && inFlight {
+			select {
+			// If quitChannel queue is full, continue on
+			case quitChannel <- true:
+				mainLogger.WithFields(logrus.Fields{"operationID": op.OperationID, "signal": op.Signal}).Debug("TRUE Sent to QUIT CHANNEL")
+			default:
+				mainLogger.WithFields(logrus.Fields{"operationID": op.OperationID}).Debug("MESSAGE NOT SENT")
+			}
+			continue
+		}
+
+		//only a launched operation holds the lock; a sibling on the same xname may hold it otherwise
+		holdsLock := op.State.Is("inProgress") || op.State.Is("needsVerified") || op.State.Is("verifying")
+		if op.Signal == storage.OperationSignalSkip && op.State.Can("skip") {
+			op.State.Event(context.Background(), "skip")
+			op.StateHelper = "skipped by request"
+			op.FailureCode = storage.FailureSkipped
+		} else {
+			op.State.Event(context.Background(), "abort")
+			op.StateHelper = "aborted by request"
+		}
+		op.EndTime.Scan(time.Now())
+		mainLogger.WithFields(logrus.Fields{"operationID": op.OperationID}).Debug(op.StateHelper)
+		if holdsLock {
+			err := (*domainGlobal.HSM).ClearLock([]string{op.Xname})
+			if err != nil {
+				mainLogger.WithFields(logrus.Fields{"operationID": op.OperationID, "err": err}).Error("failed to unlock")
+				op.Error = errors.New("Failed to unlock node")
+			}
+		}
+		domain.StoreOperation(op)
+		delete(quitChannels, op.OperationID)
+	}
+}
+
 // doLaunch -> will check the file exists, lock the xname, perform the update.
 // Parameters:
 //		operation -> WHAT to do
@@ -340,8 +472,15 @@ func controlLoop(domainGlobal *domain.DOMAIN_GLOBALS) {
 //		quit -> a channel that we listen on so we know when to quit
 // At each stage/transition it will re-store the operation back to persistent storage.  This may seem excessive, but it
 // is vitally important so we know what has been done.
-func doLaunch(operation storage.Operation, image storage.Image, command storage.Command, globals *domain.DOMAIN_GLOBALS, quit <-chan bool) {
+func doLaunch(operation storage.Operation, image storage.Image, command storage.Command, retryPolicy storage.RetryPolicy, globals *domain.DOMAIN_GLOBALS, quit <-chan bool) {
 	var err error
+	//the post operation hooks give the device back, so they only run once the pre operation hooks let it go
+	hooked := domain.HooksRan(operation.HookResults, storage.HookPreOperation)
+	defer func() {
+		if hooked {
+			runPostOperationHooks(&operation, image)
+		}
+	}()
 
 	//This COULD be a re-launch, in which case we need to restart
 	if operation.State.Can("start") {
@@ -351,7 +490,7 @@ func doLaunch(operation storage.Operation, image storage.Image, command storage.
 		}
 		operation.StateHelper = "preparing to launch"
 		operation.Error = nil
-		domain.StoreOperation(operation)
+		domain.StoreInFlightOperation(&operation)
 	} else if operation.State.Can("restart") {
 		err = operation.State.Event(context.Background(), "restart")
 		if err != nil {
@@ -359,12 +498,12 @@ func doLaunch(operation storage.Operation, image storage.Image, command storage.
 		}
 		operation.StateHelper = "preparing to re-launch"
 		operation.Error = nil
-		domain.StoreOperation(operation)
+		domain.StoreInFlightOperation(&operation)
 	} else {
 
 		operation.Error = errors.New("invalid state, leaving doLaunch")
 		mainLogger.WithField("operationID", operation.OperationID).Error(operation.Error)
-		domain.StoreOperation(operation)
+		domain.StoreInFlightOperation(&operation)
 		return
 	}
 
@@ -378,47 +517,6 @@ func doLaunch(operation storage.Operation, image storage.Image, command storage.
 	var pollingTime time.Time
 	pollingSpeed := time.Duration(image.PollingSpeedSeconds) * time.Second
 	pollingTime = time.Now().Add(pollingSpeed)
-	//check if it is blacklistsed Only update compute nodes
-	if strings.EqualFold(operation.HsmData.Type, "nodebmc") {
-		blacklisted := false
-
-		var role string
-		role = operation.HsmData.Role
-		//need to go to HSM and get component data for the n0 attached to this bmc.  Since its a nodeBMC it should
-		//always have something under it, but if it doesnt, well we just ignore the role then.  ONLY nodes have roles;
-		//so if it doesnt have a node, then it cannot have a role that we care about.
-		var emptyArray []string
-		xnames := []string{operation.HsmData.ID + "n0"}
-		components, _ := HSM.GetStateComponents(xnames, emptyArray, emptyArray, emptyArray)
-		//In this case we dont care about an error; because we are looking for 1 and ONLY 1 component;
-		//if n0 doesnt exist we will probably get an empty components list with a 400 error; but we dont care; the ONLY
-		//other plan would be to default blacklist if it fails these checks, but that doesnt seem right
-
-		if len(components.Components) == 1 {
-			role = components.Components[0].Role
-			mainLogger.Debug("using child n0 role for parent")
-		}
-
-		for _, v := range nodeBlacklist {
-			if strings.EqualFold(role, v) {
-				blacklisted = true
-			}
-		}
-		if blacklisted {
-
-			operation.State.Event(context.Background(), "nosol")
-			operation.StateHelper = "Can not update node, black listed: " + role
-			operation.Error = nil
-			operation.EndTime.Scan(time.Now())
-			err := (*globals.HSM).ClearLock([]string{operation.Xname})
-			if err != nil {
-				mainLogger.WithFields(logrus.Fields{"operationID": operation.OperationID, "err": err}).Error("failed to unlock")
-				operation.Error = errors.New("Failed to unlock node")
-			}
-			domain.StoreOperation(operation)
-			return
-		}
-	}
 	//TODO in the future we need to consider a ROLLBACK possibility.
 	//if its a dry run we want to check the file & powerState, but NOT lock the device
 	var isFile, isLock, isPowerState bool
@@ -432,6 +530,7 @@ func doLaunch(operation storage.Operation, image storage.Image, command storage.
 	}
 
 	var updateURL string
+	var nextAttemptTime time.Time //zero until a retryable send fails
 	for ; ; time.Sleep(time.Duration(1) * time.Second) {
 		select {
 		case <-quit: //signal stop
@@ -444,18 +543,20 @@ func doLaunch(operation storage.Operation, image storage.Image, command storage.
 				mainLogger.WithFields(logrus.Fields{"operationID": operation.OperationID, "err": err}).Error("failed to unlock")
 				operation.Error = errors.New("Failed to unlock node")
 			}
-			domain.StoreOperation(operation)
+			domain.StoreInFlightOperation(&operation)
 			return
 		case <-timeout: //expiration time
 			mainLogger.WithField("operationID", operation.OperationID).Debug("expiration time for  operation exceeded")
 			operation.State.Event(context.Background(), "fail")
 			operation.StateHelper = "time expired; could not complete update"
+			operation.FailureCode = launchTimeoutFailureCode(isFile, isLock, isPowerState)
 			err := (*globals.HSM).ClearLock([]string{operation.Xname})
 			if err != nil {
 				mainLogger.WithFields(logrus.Fields{"operationID": operation.OperationID, "err": err}).Error("failed to unlock")
 				operation.Error = errors.New("Failed to unlock node")
 			}
-			domain.StoreOperation(operation)
+			domain.StoreInFlightOperation(&operation)
+			domain.RecordQuarantineOutcome(operation)
 			return
 
 		default:
@@ -468,7 +569,7 @@ func doLaunch(operation storage.Operation, image storage.Image, command storage.
 				if len(image.TftpURL) > 0 {
 					checkURL = image.TftpURL
 				}
-				updateURL, err = fileCheck(checkURL)
+				updateURL, err = domain.FileCheck(checkURL)
 				if err != nil {
 					operation.Error = err
 					operation.StateHelper = "failed to find file, trying again soon"
@@ -478,7 +579,7 @@ func doLaunch(operation storage.Operation, image storage.Image, command storage.
 					operation.Error = nil
 				}
 				mainLogger.WithField("operationID", operation.OperationID).Debug(operation.StateHelper)
-				domain.StoreOperation(operation)
+				domain.StoreInFlightOperation(&operation)
 
 			} else if !isLock {
 				operation.StateHelper = "attempting to lock"
@@ -494,7 +595,7 @@ func doLaunch(operation storage.Operation, image storage.Image, command storage.
 					operation.StateHelper = "got lock"
 				}
 				mainLogger.WithField("operationID", operation.OperationID).Debug(operation.StateHelper)
-				domain.StoreOperation(operation)
+				domain.StoreInFlightOperation(&operation)
 
 			} else if !isPowerState {
 				if time.Now().After(pollingTime) {
@@ -518,13 +619,14 @@ func doLaunch(operation storage.Operation, image storage.Image, command storage.
 						//We assume Off or rebooting
 						operation.StateHelper = "reboot not satisfied, powerstate: " + powerState
 					}
-					domain.StoreOperation(operation)
+					domain.StoreInFlightOperation(&operation)
 				}
-			} else if isLock && isFile && isPowerState {
+			} else if isLock && isFile && isPowerState && time.Now().After(nextAttemptTime) {
 
 				if operation.FromImageID == uuid.Nil && !command.RestoreNotPossibleOverride {
 					operation.State.Event(context.Background(), "nosol")
 					operation.StateHelper = "cannot perform the update as the override was not enabled and there is no image to go back to."
+					operation.FailureCode = storage.FailureNoRestoreImage
 					operation.EndTime.Scan(time.Now())
 					operation.Error = nil
 					mainLogger.Debug(operation.StateHelper)
@@ -533,29 +635,50 @@ func doLaunch(operation storage.Operation, image storage.Image, command storage.
 						mainLogger.WithFields(logrus.Fields{"operationID": operation.OperationID, "err": err}).Error("failed to unlock")
 						operation.Error = errors.New("Failed to unlock node")
 					}
-					domain.StoreOperation(operation)
+					domain.StoreInFlightOperation(&operation)
 					return
 				}
 
-				//OK, now that we have verified the power, lock and file -> time to update.  You get ONE CHANCE to do this
+				//OK, now that we have verified the power, lock and file -> time to update.  You get ONE CHANCE to do this,
+				// unless the retry policy says the failure was transient and there are attempts left.
 				// If manufacturer is blank, then we will copy manufacturer from image record.
 				// We can do this because we made it this far and want to flash the image we selected.
 				if operation.HsmData.Manufacturer == "" {
 					mainLogger.Debug("Opearation Manufacturer is blank, setting to: " + strings.ToLower(image.Manufacturer))
 					operation.HsmData.Manufacturer = strings.ToLower(image.Manufacturer)
 					operation.Manufacturer = strings.ToLower(image.Manufacturer)
-					domain.StoreOperation(operation)
+					domain.StoreInFlightOperation(&operation)
+				}
+				if command.OverrideDryrun && !hooked {
+					if !runPreOperationHooks(&operation, image, command, globals) {
+						return
+					}
+					hooked = true
 				}
 				var passback model.Passback
 				passback = model.BuildErrorPassback(http.StatusTeapot, errors.New("by default, this has failed"))
 				if command.OverrideDryrun {
-					if strings.EqualFold(operation.HsmData.Manufacturer, manufacturerIntel) {
+					if command.Stages() && strings.EqualFold(operation.HsmData.Manufacturer, manufacturerIntel) {
+						operation.State.Event(context.Background(), "nosol")
+						operation.StateHelper = "cannot stage the image: the intel update action has no apply time"
+						operation.FailureCode = storage.FailureStagingNotSupported
+						operation.EndTime.Scan(time.Now())
+						operation.Error = nil
+						mainLogger.Debug(operation.StateHelper)
+						err := (*globals.HSM).ClearLock([]string{operation.Xname})
+						if err != nil {
+							mainLogger.WithFields(logrus.Fields{"operationID": operation.OperationID, "err": err}).Error("failed to unlock")
+							operation.Error = errors.New("Failed to unlock node")
+						}
+						domain.StoreInFlightOperation(&operation)
+						return
+					} else if strings.EqualFold(operation.HsmData.Manufacturer, manufacturerIntel) {
 						path := operation.HsmData.InventoryURI + "/" + operation.Target + "/Actions/Oem/Intel.Oem.Update" + operation.Target
 						file := "images/" + updateURL
 						operation.StateHelper = "sending intel payload"
 						operation.Error = nil
 						mainLogger.Debug(operation.StateHelper)
-						domain.StoreOperation(operation)
+						domain.StoreInFlightOperation(&operation)
 
 						passback = SendSecureRedfishFileMultipartUpload(globals, operation.HsmData.FQDN, path, "upload", file,
 							operation.HsmData.User, operation.HsmData.Password)
@@ -563,13 +686,16 @@ func doLaunch(operation storage.Operation, image storage.Image, command storage.
 						operation.StateHelper = "sending cray payload"
 						operation.Error = nil
 						mainLogger.Debug(operation.StateHelper)
-						domain.StoreOperation(operation)
+						domain.StoreInFlightOperation(&operation)
 
 						pc := PayloadCray{
 							ImageURI:         updateURL,
 							TransferProtocol: "HTTP",
 							Targets:          []string{operation.HsmData.InventoryURI + "/" + operation.Target},
 						}
+						if command.Stages() {
+							pc.OperationApplyTime = command.ApplyTime
+						}
 
 						pcm, _ := json.Marshal(pc)
 						pcs := string(pcm)
@@ -592,13 +718,16 @@ func doLaunch(operation storage.Operation, image storage.Image, command storage.
 								operation.StateHelper = "sending gigabyte payload"
 								operation.Error = nil
 								mainLogger.Debug(operation.StateHelper)
-								domain.StoreOperation(operation)
+								domain.StoreInFlightOperation(&operation)
 
 								pg := PayloadGigabyte{
 									ImageURI:         updateImageURI,
 									TransferProtocol: "HTTP",
 									UpdateComponent:  operation.Target,
 								}
+								if command.Stages() {
+									pg.OperationApplyTime = command.ApplyTime
+								}
 								pgm, _ := json.Marshal(pg)
 								pgs := string(pgm)
 								passback = SendSecureRedfish(globals, operation.HsmData.FQDN, operation.HsmData.UpdateURI,
@@ -606,7 +735,7 @@ func doLaunch(operation storage.Operation, image storage.Image, command storage.
 								if !(passback.IsError || passback.StatusCode >= 400) {
 									// Gigabyte provide update status from the UpdateService
 									operation.UpdateInfoLink = "/redfish/v1/UpdateService"
-									domain.StoreOperation(operation)
+									domain.StoreInFlightOperation(&operation)
 								}
 							} else {
 								mainLogger.Errorf("Could not replace hostname: %s", host)
@@ -618,11 +747,14 @@ func doLaunch(operation storage.Operation, image storage.Image, command storage.
 						operation.StateHelper = "sending hpe payload"
 						operation.Error = nil
 						mainLogger.Debug(operation.StateHelper)
-						domain.StoreOperation(operation)
+						domain.StoreInFlightOperation(&operation)
 
 						pc := PayloadHpe{
 							ImageURI: updateURL,
 						}
+						if command.Stages() {
+							pc.OperationApplyTime = command.ApplyTime
+						}
 
 						pcm, _ := json.Marshal(pc)
 						pcs := string(pcm)
@@ -641,12 +773,15 @@ func doLaunch(operation storage.Operation, image storage.Image, command storage.
 						operation.StateHelper = "Sending Foxconn payload"
 						operation.Error = nil
 						mainLogger.Debug(operation.StateHelper)
-						domain.StoreOperation(operation)
+						domain.StoreInFlightOperation(&operation)
 
 						pc := PayloadFoxconn{
 							ImageURI:       updateURL,
 							RestoreDefault: false,
 						}
+						if command.Stages() {
+							pc.OperationApplyTime = command.ApplyTime
+						}
 
 						pcm, _ := json.Marshal(pc)
 						pcs := string(pcm)
@@ -667,6 +802,7 @@ func doLaunch(operation storage.Operation, image storage.Image, command storage.
 					} else {
 						_ = operation.State.Event(context.Background(), "fail")
 						operation.Error = errors.New("unsupported manufacturer")
+						operation.FailureCode = storage.FailureUnsupportedDevice
 						mainLogger.Debug("Unspported Manufacturer - Can not send payload")
 						passback = model.BuildErrorPassback(http.StatusBadRequest, operation.Error)
 					}
@@ -676,14 +812,43 @@ func doLaunch(operation storage.Operation, image storage.Image, command storage.
 					_ = operation.EndTime.Scan(time.Now())
 					operation.Error = nil
 					mainLogger.Debug(operation.StateHelper)
-					domain.StoreOperation(operation)
+					domain.StoreInFlightOperation(&operation)
 					return
 				}
 
-				if passback.IsError || passback.StatusCode >= 400 { //if we HAVE an error; or if the status code is the error range 4XX, 5XX
+				failed := passback.IsError || passback.StatusCode >= 400
+				attempt := storage.OperationAttempt{
+					Attempt:    len(operation.Attempts) + 1,
+					Time:       time.Now(),
+					StatusCode: passback.StatusCode,
+				}
+				var bmcMessages []storage.BMCMessage
+				if failed {
+					attempt.Error = passback.Error.Detail
+					attempt.Retryable = retryPolicy.Retryable(passback.IsError, passback.StatusCode, passback.Error.Detail)
+					bmcMessages = domain.ResolveRedfishMessages(storage.BMCMessageSourceUpdate, domain.ParseRedfishMessages([]byte(passback.Error.Detail)))
+					operation.AddBMCMessages(bmcMessages...)
+				}
+				operation.Attempts = append(operation.Attempts, attempt)
+
+				if failed && attempt.Retryable && attempt.Attempt < retryPolicy.MaxAttempts {
+					backoff := retryPolicy.Backoff(attempt.Attempt)
+					nextAttemptTime = time.Now().Add(backoff)
+					operation.Error = errors.New(passback.Error.Detail)
+					operation.StateHelper = fmt.Sprintf("attempt %d of %d failed - status code: %d - retrying in %s",
+						attempt.Attempt, retryPolicy.MaxAttempts, passback.StatusCode, backoff)
+					mainLogger.WithFields(logrus.Fields{"operationID": operation.OperationID, "attempt": attempt.Attempt}).Warn(operation.StateHelper)
+					domain.StoreInFlightOperation(&operation)
+				} else if failed { //if we HAVE an error; or if the status code is the error range 4XX, 5XX
 					operation.Error = errors.New(passback.Error.Detail)
 					operation.State.Event(context.Background(), "fail")
 					operation.StateHelper = "failed to update target - status code: " + strconv.Itoa(passback.StatusCode) + " - See operation for any error message"
+					if summary := domain.SummarizeBMCMessages(bmcMessages); summary != "" {
+						operation.StateHelper = "failed to update target - status code: " + strconv.Itoa(passback.StatusCode) + " - " + summary
+					}
+					if operation.FailureCode == "" {
+						operation.FailureCode = payloadFailureCode(passback)
+					}
 					operation.EndTime.Scan(time.Now())
 
 					err := (*globals.HSM).ClearLock([]string{operation.Xname})
@@ -691,7 +856,19 @@ func doLaunch(operation storage.Operation, image storage.Image, command storage.
 						mainLogger.WithFields(logrus.Fields{"operationID": operation.OperationID, "err": err}).Error("failed to unlock")
 						operation.Error = errors.New("Failed to unlock node")
 					}
-					domain.StoreOperation(operation)
+					domain.StoreInFlightOperation(&operation)
+					domain.RecordQuarantineOutcome(operation)
+					return
+				} else if command.Stages() {
+					//the image waits on the device; the activation resets it in and verifies it
+					operation.State.Event(context.Background(), "stage")
+					operation.StateHelper = "image staged, waiting for activation"
+					err := (*globals.HSM).ClearLock([]string{operation.Xname})
+					if err != nil {
+						mainLogger.WithFields(logrus.Fields{"operationID": operation.OperationID, "err": err}).Error("failed to unlock")
+						operation.Error = errors.New("Failed to unlock node")
+					}
+					domain.StoreInFlightOperation(&operation)
 					return
 				} else {
 
@@ -699,7 +876,7 @@ func doLaunch(operation storage.Operation, image storage.Image, command storage.
 					if operation.State.Can("needsVerify") {
 						operation.State.Event(context.Background(), "needsVerify")
 						operation.StateHelper = "update complete, needs verification"
-						domain.StoreOperation(operation)
+						domain.StoreInFlightOperation(&operation)
 						return
 					}
 				}
@@ -708,6 +885,83 @@ func doLaunch(operation storage.Operation, image storage.Image, command storage.
 	}
 }
 
+// launchTimeoutFailureCode -> names the launch step that was still outstanding when the operation expired
+func launchTimeoutFailureCode(isFile, isLock, isPowerState bool) string {
+	if !isFile {
+		return storage.FailureFileUnreachable
+	} else if !isLock {
+		return storage.FailureLockFailed
+	} else if !isPowerState {
+		return storage.FailurePowerStateNotAllowed
+	}
+	return storage.FailureLaunchTimeout
+}
+
+// payloadFailureCode -> tells a BMC that answered the update payload with an error status apart from a request that
+// never got a response; SendSecureRedfish only builds an error passback for the latter.
+func payloadFailureCode(passback model.Passback) string {
+	if passback.IsError {
+		return storage.FailureBMCUnreachable
+	}
+	return storage.FailureBMCRejected
+}
+
+// runPreOperationHooks -> runs the pre operation hooks right before the payload is sent.  A failing hook ends the
+// operation; false means doLaunch has to return.
+func runPreOperationHooks(operation *storage.Operation, image storage.Image, command storage.Command, globals *domain.DOMAIN_GLOBALS) bool {
+	hookContext := domain.OperationHookContext(storage.HookPreOperation, *operation, command, image.FirmwareVersion)
+	results, err := domain.RunHooks(storage.HookPreOperation, hookContext)
+	if len(results) == 0 {
+		return true
+	}
+	operation.HookResults = append(operation.HookResults, results...)
+	if err == nil {
+		operation.StateHelper = "pre operation hooks succeeded"
+		domain.StoreInFlightOperation(operation)
+		return true
+	}
+
+	if domain.HookFailState(results[len(results)-1].Name) == "noSolution" {
+		operation.State.Event(context.Background(), "nosol")
+	} else {
+		operation.State.Event(context.Background(), "fail")
+	}
+	operation.StateHelper = err.Error()
+	operation.FailureCode = storage.FailureHookFailed
+	operation.Error = nil
+	operation.EndTime.Scan(time.Now())
+	mainLogger.WithField("operationID", operation.OperationID).Warn(operation.StateHelper)
+	err = (*globals.HSM).ClearLock([]string{operation.Xname})
+	if err != nil {
+		mainLogger.WithFields(logrus.Fields{"operationID": operation.OperationID, "err": err}).Error("failed to unlock")
+		operation.Error = errors.New("Failed to unlock node")
+	}
+	domain.StoreInFlightOperation(operation)
+	return false
+}
+
+// runPostOperationHooks -> runs the post operation hooks once an operation that was sent to its device is done
+func runPostOperationHooks(operation *storage.Operation, image storage.Image) {
+	if !(operation.State.Is("succeeded") || operation.State.Is("failed") || operation.State.Is("aborted") || operation.State.Is("noSolution")) ||
+		domain.HooksRan(operation.HookResults, storage.HookPostOperation) {
+		return
+	}
+	var command storage.Command
+	if action, err := domain.GetStoredAction(operation.ActionID); err == nil {
+		command = action.Command
+	}
+	hookContext := domain.OperationHookContext(storage.HookPostOperation, *operation, command, image.FirmwareVersion)
+	results, err := domain.RunHooks(storage.HookPostOperation, hookContext)
+	if len(results) == 0 {
+		return
+	}
+	operation.HookResults = append(operation.HookResults, results...)
+	if err != nil {
+		mainLogger.WithField("operationID", operation.OperationID).Warn(err)
+	}
+	domain.StoreInFlightOperation(operation)
+}
+
 // doVerify -> will handle the reboot and then verify the firmware version
 // Parameters:
 //		operation -> WHAT to do
@@ -721,6 +975,7 @@ func doLaunch(operation storage.Operation, image storage.Image, command storage.
 func doVerify(operation storage.Operation, ToImage storage.Image, FromImage storage.Image, globals *domain.DOMAIN_GLOBALS, quit <-chan bool) {
 	var err error
 	err = nil
+	defer runPostOperationHooks(&operation, ToImage)
 
 	//it is possible this is a re launch of doVerify
 	if operation.State.Can("verifying") {
@@ -730,7 +985,7 @@ func doVerify(operation storage.Operation, ToImage storage.Image, FromImage stor
 		}
 		operation.StateHelper = "verifying potential success"
 		operation.Error = nil
-		domain.StoreOperation(operation)
+		domain.StoreInFlightOperation(&operation)
 	} else if operation.State.Can("reverifying") {
 		err = operation.State.Event(context.Background(), "reverifying")
 		if err != nil {
@@ -738,7 +993,7 @@ func doVerify(operation storage.Operation, ToImage storage.Image, FromImage stor
 		}
 		operation.StateHelper = "preparing to re-attempt verifying"
 		operation.Error = nil
-		domain.StoreOperation(operation)
+		domain.StoreInFlightOperation(&operation)
 	} else {
 		operation.Error = errors.New("invalid state, leaving doVerify")
 		mainLogger.WithField("operationID", operation.OperationID).Error(operation.Error)
@@ -747,7 +1002,7 @@ func doVerify(operation storage.Operation, ToImage storage.Image, FromImage stor
 			mainLogger.WithFields(logrus.Fields{"operationID": operation.OperationID, "err": err}).Error("failed to unlock")
 			operation.Error = errors.New("Failed to unlock node")
 		}
-		domain.StoreOperation(operation)
+		domain.StoreInFlightOperation(&operation)
 		return
 	}
 
@@ -764,6 +1019,20 @@ func doVerify(operation storage.Operation, ToImage storage.Image, FromImage stor
 	}
 	pollingSpeed := time.Duration(ToImage.PollingSpeedSeconds) * time.Second
 	pollingTime = time.Now().Add(pollingSpeed)
+	powerPollingTime := pollingTime
+
+	// once the BMC has been seen pushing events, polling the version or task is only the fallback for events that never
+	// arrive; resets and power state polls keep their pace.  A subscription alone is no proof, the events may go to a
+	// replica that does not run the control loop and drops them
+	verifyPollingSpeed := pollingSpeed
+	var events <-chan model.RedfishEvent
+	subscription, err := domain.SubscribeToEvents(operation)
+	if err != nil {
+		mainLogger.WithFields(logrus.Fields{"operationID": operation.OperationID, "err": err}).Debug("no event subscription, polling only")
+	} else if subscription != nil {
+		defer domain.UnsubscribeFromEvents(subscription)
+		events = subscription.Events()
+	}
 
 	var manualRebootSatisfied bool
 	manualRebootSatisfied = !(ToImage.NeedManualReboot) // the reboot is satisfied if it DOESNT need a reboot
@@ -773,6 +1042,28 @@ func doVerify(operation storage.Operation, ToImage storage.Image, FromImage stor
 	} else {
 		automaticRebootSatisfied = true
 	}
+	resetType := ToImage.ForceResetType
+	waitBeforeReboot := time.Duration(ToImage.WaitTimeBeforeManualRebootSeconds) * time.Second
+	powerClient := globals.PowerClient
+	if powerClient == nil && ToImage.NeedManualReboot {
+		//no reboot orchestration; whoever runs the action reboots the node and the version shows up then
+		manualRebootSatisfied = true
+	}
+	//an activated staged image only takes effect with the reset FAS sends, and there is nothing to wait for first
+	if operation.ResetType != "" {
+		manualRebootSatisfied = false
+		automaticRebootSatisfied = true
+		resetType = operation.ResetType
+		waitBeforeReboot = 0
+		if powerClient == nil {
+			powerClient = &domain.RedfishPowerClient{}
+		}
+	}
+	//another operation of the activation reset the device already, only the version is left to check
+	if operation.ResetBy != uuid.Nil {
+		manualRebootSatisfied = true
+		automaticRebootSatisfied = true
+	}
 
 	defaultTimeToWait := time.Duration(2) * time.Minute
 
@@ -781,6 +1072,7 @@ func doVerify(operation storage.Operation, ToImage storage.Image, FromImage stor
 
 	var rebootStarted bool
 	var rebootTime time.Time
+	var nextResetTime time.Time //zero until a reset fails
 	for ; ; time.Sleep(time.Duration(1) * time.Second) {
 		select {
 		case <-quit: //signal stop
@@ -793,73 +1085,81 @@ func doVerify(operation storage.Operation, ToImage storage.Image, FromImage stor
 				mainLogger.WithFields(logrus.Fields{"operationID": operation.OperationID, "err": err}).Error("failed to unlock")
 				operation.Error = errors.New("Failed to unlock node")
 			}
-			domain.StoreOperation(operation)
+			domain.StoreInFlightOperation(&operation)
 			return
 		case <-timeout: //expiration time
 			mainLogger.WithField("operationID", operation.OperationID).Debug("expiration time for  operation exceeded")
 			operation.State.Event(context.Background(), "fail")
 			operation.StateHelper = "time expired; could not verify"
+			operation.FailureCode = storage.FailureVerifyTimeout
 			err := (*globals.HSM).ClearLock([]string{operation.Xname})
 			if err != nil {
 				mainLogger.WithFields(logrus.Fields{"operationID": operation.OperationID, "err": err}).Error("failed to unlock")
 				operation.Error = errors.New("Failed to unlock node")
 			}
-			domain.StoreOperation(operation)
+			domain.StoreInFlightOperation(&operation)
+			domain.RecordQuarantineOutcome(operation)
 			return
+		case event := <-events:
+			if verifyPollingSpeed == pollingSpeed {
+				verifyPollingSpeed *= domain.EventFallbackPollingFactor
+			}
+			if domain.IsUpdateCompletionEvent(event) && !verifySatisfied {
+				mainLogger.WithFields(logrus.Fields{"operationID": operation.OperationID, "messageId": event.MessageId}).Debug("update event received, checking now")
+				pollingTime = time.Now()
+			}
 		default:
 			if !automaticRebootSatisfied {
 				if time.Now().After(entryTime.Add(defaultTimeToWait)) {
 					automaticRebootSatisfied = true
 				}
 			} else if !manualRebootSatisfied {
-				if time.Now().After(entryTime.Add(time.Duration(ToImage.WaitTimeBeforeManualRebootSeconds)*time.Second)) && !rebootStarted {
+				if time.Now().After(entryTime.Add(waitBeforeReboot)) && !rebootStarted && time.Now().After(nextResetTime) {
 					//see https://cray.slack.com/archives/GJUBRT8US/p1588276620304200 for notes
-					path := operation.HsmData.ActionReset.Target //"/redfish/v1/Systems/Self/Actions/ComputerSystem.Reset"
 					// check LOCK
 					lckErr := (*globals.HSM).SetLock([]string{operation.Xname})
 					if lckErr != nil {
 						mainLogger.WithFields(logrus.Fields{"xname": operation.Xname, "operationID": operation.OperationID, "lockMessage": lckErr}).Warn("could not lock component, trying again soon.")
 						operation.Error = err
 						operation.StateHelper = "failed to lock for reset, trying again soon"
-						domain.StoreOperation(operation)
+						domain.StoreInFlightOperation(&operation)
+					} else if err := powerClient.Reset(operation, resetType); err != nil {
+						//the image is on the device but not running yet; try again until the operation expires
+						mainLogger.WithField("err", err).Errorf("error encountered rebooting xname: %s", operation.Xname)
+						nextResetTime = time.Now().Add(pollingSpeed)
+						operation.StateHelper = "reboot failed, trying again soon: " + err.Error()
+						domain.StoreInFlightOperation(&operation)
 					} else {
-						passback := SendSecureRedfish(globals, operation.HsmData.FQDN, path, "{\"ResetType\":\""+ToImage.ForceResetType+"\"}", operation.HsmData.User, operation.HsmData.Password, "POST")
-						//its possible we could get an error code, but we are really close to being done, should we ignore it? or FAIL the whole thing?
-
-						if passback.IsError {
-							mainLogger.WithField("err", passback.Error.Detail).Errorf("error encountered rebooting xname: %s", operation.Xname)
-						} else {
-							mainLogger.WithFields(logrus.Fields{"response": passback.Obj, "statusCode": passback.StatusCode}).Debugf("issued restart to xname: %s", operation.Xname)
-						}
+						mainLogger.WithFields(logrus.Fields{"resetType": resetType}).Debugf("issued restart to xname: %s", operation.Xname)
 						rebootStarted = true
 						rebootTime = time.Now()
 						operation.StateHelper = "reboot command issued"
-						domain.StoreOperation(operation)
+						domain.StoreInFlightOperation(&operation)
 					}
-				} else {
+				} else if !rebootStarted && time.Now().After(nextResetTime) {
 					operation.StateHelper = "waiting to reboot"
-					domain.StoreOperation(operation)
+					domain.StoreInFlightOperation(&operation)
 				}
 
 				if time.Now().After(rebootTime.Add(time.Duration(ToImage.WaitTimeAfterRebootSeconds)*time.Second)) && rebootStarted {
 					//Consider what happens if we get NO response, because its still rebooting?! That will probably be
 					//an error
-					if time.Now().After(pollingTime) {
-						pollingTime = time.Now().Add(pollingSpeed) // reset it
-						powerState, err := getPowerState(&operation.HsmData)
+					if time.Now().After(powerPollingTime) {
+						powerPollingTime = time.Now().Add(pollingSpeed) // reset it
+						powerState, err := powerClient.PowerState(operation)
 						if err != nil {
 							mainLogger.Error(err)
 							operation.Error = err
 							operation.StateHelper = "could not get power state"
-						} else if powerState == rf.POWER_STATE_ON {
-							operation.StateHelper = "reboot satisfied"
+						} else if strings.EqualFold(powerState, rf.POWER_STATE_ON) {
+							operation.StateHelper = "reboot satisfied, verifying version"
 							manualRebootSatisfied = true
 						} else {
 							//We assume Off or rebooting
 							operation.StateHelper = "reboot not satisfied, powerstate: " + powerState
 							manualRebootSatisfied = false
 						}
-						domain.StoreOperation(operation)
+						domain.StoreInFlightOperation(&operation)
 						//unfortuneately we cannot use the status/health of the FirmwareInventory/{endpoint} to determine health
 						// of a update.  According to the RF spec, only OK, warning, and critical are supported. Gigabyte doesnt even do this,
 						//and cray does 'updating'? but it auto reboots.  So best thing to do it make WHOMEVER creates an ToImage tell us timings.
@@ -867,7 +1167,7 @@ func doVerify(operation storage.Operation, ToImage storage.Image, FromImage stor
 				}
 			} else if !verifySatisfied && manualRebootSatisfied && automaticRebootSatisfied {
 				if time.Now().After(pollingTime) {
-					pollingTime = time.Now().Add(pollingSpeed) // reset it
+					pollingTime = time.Now().Add(verifyPollingSpeed) // reset it
 					// Check the update/task links first to see if we are done
 					// UpdateInfoLink is currently only available on Gigabyte
 					if operation.UpdateInfoLink != "" {
@@ -876,25 +1176,33 @@ func doVerify(operation storage.Operation, ToImage storage.Image, FromImage stor
 							mainLogger.WithFields(logrus.Fields{"operationID": operation.OperationID, "err": err}).Error("Update Info Check")
 						} else {
 							if updateInfo.UpdateTarget == operation.Target {
+								operation.RecordRedfishStatus(updateInfo.UpdateStatus, updateInfo.FlashPercentage)
 								if updateInfo.UpdateStatus == "Preparing" || updateInfo.UpdateStatus == "VerifyingFirmware" || updateInfo.UpdateStatus == "Downloading" {
 									operation.StateHelper = "Firmware Update Information Returned " + updateInfo.UpdateStatus
-									domain.StoreOperation(operation)
+									domain.StoreInFlightOperation(&operation)
 								} else if updateInfo.UpdateStatus == "Flashing" {
 									operation.StateHelper = "Firmware Update Information Returned " + updateInfo.UpdateStatus + " " + updateInfo.FlashPercentage
-									domain.StoreOperation(operation)
+									domain.StoreInFlightOperation(&operation)
 								} else if updateInfo.UpdateStatus == "" {
 									operation.StateHelper = "Firmware Update Information Unavailable"
-									domain.StoreOperation(operation)
+									domain.StoreInFlightOperation(&operation)
+								} else if updateInfo.UpdateStatus == "Completed" && rebootStarted {
+									//the node was rebooted for this image, so only the running version counts
+									operation.StateHelper = "Firmware Update Information Returned " + updateInfo.UpdateStatus + ", verifying version after reboot"
+									domain.StoreInFlightOperation(&operation)
 								} else if updateInfo.UpdateStatus == "Completed" {
 									operation.State.Event(context.Background(), "success")
 									operation.StateHelper = "Firmware Update Information Returned " + updateInfo.UpdateStatus + " " + updateInfo.FlashPercentage + " -- Reboot of node may be required"
-									domain.StoreOperation(operation)
+									domain.StoreInFlightOperation(&operation)
+									domain.RecordQuarantineOutcome(operation)
 									return
 								} else {
 									operation.State.Event(context.Background(), "fail")
 									operation.StateHelper = "Firmware Update Information Returned " + updateInfo.UpdateStatus + " " + updateInfo.FlashPercentage + " -- See " + operation.UpdateInfoLink
+									operation.FailureCode = storage.FailureBMCTaskFailed
 									operation.Error = errors.New("See " + operation.UpdateInfoLink)
-									domain.StoreOperation(operation)
+									domain.StoreInFlightOperation(&operation)
+									domain.RecordQuarantineOutcome(operation)
 									return
 								}
 							} else {
@@ -908,19 +1216,35 @@ func doVerify(operation storage.Operation, ToImage storage.Image, FromImage stor
 						if err != nil {
 							mainLogger.WithFields(logrus.Fields{"operationID": operation.OperationID, "err": err}).Error("Task Status Check")
 						} else {
+							percent := ""
+							if taskStatus.PercentComplete > 0 {
+								percent = strconv.Itoa(taskStatus.PercentComplete) + "%"
+							}
+							operation.RecordRedfishStatus(taskStatus.TaskState+" "+taskStatus.TaskStatus, percent)
 							if taskStatus.TaskState == "Running" {
 								operation.StateHelper = "Firmware Task Returned Running"
-								domain.StoreOperation(operation)
+								domain.StoreInFlightOperation(&operation)
+							} else if taskStatus.TaskState == "Completed" && taskStatus.TaskStatus == "OK" && rebootStarted {
+								operation.StateHelper = "Firmware Task Returned " + taskStatus.TaskState + ", verifying version after reboot"
+								domain.StoreInFlightOperation(&operation)
 							} else if taskStatus.TaskState == "Completed" && taskStatus.TaskStatus == "OK" {
 								operation.State.Event(context.Background(), "success")
 								operation.StateHelper = "Firmware Task Returned " + taskStatus.TaskState + " with Status " + taskStatus.TaskStatus + " -- Reboot of node may be required"
-								domain.StoreOperation(operation)
+								domain.StoreInFlightOperation(&operation)
+								domain.RecordQuarantineOutcome(operation)
 								return
 							} else {
 								operation.State.Event(context.Background(), "fail")
 								operation.StateHelper = "Firmware Task Returned " + taskStatus.TaskState + " with Status " + taskStatus.TaskStatus + " -- See " + operation.TaskLink
+								bmcMessages := domain.ResolveRedfishMessages(storage.BMCMessageSourceTask, taskStatus.Messages)
+								operation.AddBMCMessages(bmcMessages...)
+								if summary := domain.SummarizeBMCMessages(bmcMessages); summary != "" {
+									operation.StateHelper = "Firmware Task Returned " + taskStatus.TaskState + " with Status " + taskStatus.TaskStatus + " -- " + summary
+								}
+								operation.FailureCode = storage.FailureBMCTaskFailed
 								operation.Error = errors.New("See " + operation.TaskLink)
-								domain.StoreOperation(operation)
+								domain.StoreInFlightOperation(&operation)
+								domain.RecordQuarantineOutcome(operation)
 								return
 							}
 						}
@@ -931,12 +1255,12 @@ func doVerify(operation storage.Operation, ToImage storage.Image, FromImage stor
 						continue
 					} else {
 						var stat VerifyStatus
-						if ToImage.FirmwareVersion == firmwareVersion {
+						if domain.FirmwareVersionMatchesImage(operation.Manufacturer, operation.Target, firmwareVersion, ToImage) {
 							//THEN the version matches!
 							stat = UpdateSuccess
 							operation.StateHelper = "Update Successful to version: " + ToImage.FirmwareVersion
 							//ITS on the old version still!!! FAIL After Timeout
-						} else if operation.FromFirmwareVersion == firmwareVersion {
+						} else if domain.FirmwareVersionsEqual(operation.Manufacturer, operation.Target, operation.FromFirmwareVersion, firmwareVersion) {
 							stat = FailNoChange
 							//operation.StateHelper = "no change detected in firmware version"
 						} else {
@@ -956,7 +1280,8 @@ func doVerify(operation storage.Operation, ToImage storage.Image, FromImage stor
 								mainLogger.WithFields(logrus.Fields{"operationID": operation.OperationID, "err": err}).Error("failed to unlock")
 								operation.Error = errors.New("Failed to unlock node")
 							}
-							domain.StoreOperation(operation)
+							domain.StoreInFlightOperation(&operation)
+							domain.RecordQuarantineOutcome(operation)
 							return
 						}
 						// We dont just quit on a FailNoChange... b/c we give it time to rectify
@@ -965,6 +1290,7 @@ func doVerify(operation storage.Operation, ToImage storage.Image, FromImage stor
 							verifySatisfied = true
 							//SET FAIL
 							operation.State.Event(context.Background(), "fail")
+							operation.FailureCode = storage.FailureUnexpectedVersion
 							operation.Error = nil
 							operation.EndTime.Scan(time.Now())
 							err := (*globals.HSM).ClearLock([]string{operation.Xname})
@@ -972,11 +1298,12 @@ func doVerify(operation storage.Operation, ToImage storage.Image, FromImage stor
 								mainLogger.WithFields(logrus.Fields{"operationID": operation.OperationID, "err": err}).Error("failed to unlock")
 								operation.Error = errors.New("Failed to unlock node")
 							}
-							domain.StoreOperation(operation)
+							domain.StoreInFlightOperation(&operation)
+							domain.RecordQuarantineOutcome(operation)
 							return
 						}
 					}
-					domain.StoreOperation(operation) // Update RefreshTime
+					domain.StoreInFlightOperation(&operation) // Update RefreshTime
 				}
 			}
 		}
@@ -1024,59 +1351,6 @@ func downloadFileToLocal(fileUrl string) (localFile string, err error) {
 	return localFile, err
 }
 
-func fileCheck(fileLocation string) (returnLocation string, err error) {
-	returnLocation = fileLocation
-	URL, err := url.Parse(fileLocation)
-	if err != nil {
-		return returnLocation, err
-	}
-
-	if strings.ToLower(URL.Scheme) == "s3" {
-
-		bucket := URL.Host //this helps us capture the bucket name // fw-update in s3://fw-update/f1.1123.24.xz.iso
-
-		s3endpoint, err := url.Parse(S3_ENDPOINT)
-		if err != nil {
-			return returnLocation, err
-		}
-
-		URL.Host = s3endpoint.Host //ex: http://rgw.local:8080
-		URL.Scheme = s3endpoint.Scheme
-		URL.Path = bucket + URL.Path
-
-		returnLocation = URL.String()
-	} else if strings.ToLower(URL.Scheme) == "tftp" {
-		bucket := URL.Host //this helps us capture the bucket name // fw-update in s3://fw-update/f1.1123.24.xz.iso
-		tftpEndpoint, err := url.Parse(TFTP_ENDPOINT)
-		if err != nil {
-			return returnLocation, err
-		}
-		URL.Host = tftpEndpoint.Host
-		URL.Scheme = tftpEndpoint.Scheme
-		URL.Path = bucket + URL.Path
-
-		returnLocation = URL.String()
-		// Cannot check for file with tftp, so just return
-		return returnLocation, err
-	}
-	//else the scheme is http
-
-	// Comment this next section out for testing without S3 buckets
-	// DO NOT RELEASE with this section commented out!
-	mainLogger.WithFields(logrus.Fields{"URL": returnLocation}).Debug("GETTING HEAD of FILE")
-	response, err := http.Head(returnLocation)
-	if err != nil {
-		mainLogger.Error(err)
-		return returnLocation, err
-	}
-	if response.StatusCode != http.StatusOK {
-		err = errors.New("unexpected status code; could not find file via HEAD")
-		return returnLocation, err
-	}
-
-	return returnLocation, nil
-}
-
 func SendSecureRedfish(globals *domain.DOMAIN_GLOBALS, server string, path string, bodyStr string, authUser string,
 	authPass string, method string, timeout_override ...int) (pb model.Passback) {
 
@@ -1105,6 +1379,14 @@ func SendSecureRedfish(globals *domain.DOMAIN_GLOBALS, server string, path strin
 
 	mainLogger.WithFields(logrus.Fields{"URL": tmpURL.String(), "body": bodyStr}).Debug("SENDING COMMAND")
 
+	release, err := globals.RFLimiter.Acquire(reqContext, server)
+	if err != nil {
+		reqCtxCancel()
+		mainLogger.Error(err)
+		pb = model.BuildErrorPassback(http.StatusServiceUnavailable, err)
+		return
+	}
+	defer release()
 	globals.RFClientLock.RLock()	// TODO: Do we really need locks?
 	resp, err := globals.RFHttpClient.Do(req)
 	globals.RFClientLock.RUnlock()
@@ -1155,6 +1437,15 @@ func SendSecureRedfishFileUpload(globals *domain.DOMAIN_GLOBALS, server string,
 	//	req = req.WithContext(reqContext)
 
 	req.Header.Add("Content-Type", "application/octet-stream")
+	limitContext, limitCtxCancel := context.WithTimeout(context.Background(), domain.LimiterWaitTimeout)
+	release, err := globals.RFLimiter.Acquire(limitContext, server)
+	limitCtxCancel()
+	if err != nil {
+		mainLogger.Error(err)
+		pb = model.BuildErrorPassback(http.StatusServiceUnavailable, err)
+		return
+	}
+	defer release()
 	globals.RFClientLock.RLock()	// TODO: Do we really need locks?
 	resp, err := globals.RFHttpClient.Do(req)
 	globals.RFClientLock.RUnlock()
@@ -1220,6 +1511,14 @@ func SendSecureRedfishFileMultipartUpload(globals *domain.DOMAIN_GLOBALS, server
 
 	req.Header.Add("Content-Type", writer.FormDataContentType())
 
+	release, err := globals.RFLimiter.Acquire(reqContext, server)
+	if err != nil {
+		reqCtxCancel()
+		mainLogger.Error(err)
+		pb = model.BuildErrorPassback(http.StatusServiceUnavailable, err)
+		return
+	}
+	defer release()
 	globals.RFClientLock.RLock()	// TODO: Do we really need locks?
 	resp, err := globals.RFHttpClient.Do(req)
 	globals.RFClientLock.RUnlock()
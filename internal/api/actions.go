@@ -85,6 +85,39 @@ func CreateAction(w http.ResponseWriter, req *http.Request) {
 	return
 }
 
+// PreflightAction - checks the devices an action with these parameters would update, without creating it
+func PreflightAction(w http.ResponseWriter, req *http.Request) {
+
+	defer base.DrainAndCloseRequestBody(req)
+
+	var pb model.Passback
+	var parameters storage.ActionParameters
+	if req.Body == nil {
+		err := errors.New("empty body not allowed")
+		pb = model.BuildErrorPassback(http.StatusBadRequest, err)
+		logrus.WithFields(logrus.Fields{"ERROR": err, "HttpStatusCode": pb.StatusCode}).Error("empty body")
+		WriteHeaders(w, pb)
+		return
+	}
+	body, err := ioutil.ReadAll(req.Body)
+	if err != nil {
+		pb = model.BuildErrorPassback(http.StatusInternalServerError, err)
+		logrus.WithFields(logrus.Fields{"ERROR": err, "HttpStatusCode": pb.StatusCode}).Error("Error detected retrieving body")
+		WriteHeaders(w, pb)
+		return
+	}
+	err = json.Unmarshal(body, &parameters)
+	if err != nil {
+		pb = model.BuildErrorPassback(http.StatusBadRequest, err)
+		logrus.WithFields(logrus.Fields{"ERROR": err, "HttpStatusCode": pb.StatusCode}).Error("Unparseable json")
+		WriteHeaders(w, pb)
+		return
+	}
+
+	pb = domain.PreflightAction(parameters)
+	WriteHeaders(w, pb)
+}
+
 // GetAction - returns all actions, or action by actionID
 func GetAction(w http.ResponseWriter, req *http.Request) {
 
@@ -175,6 +208,142 @@ func AbortActionID(w http.ResponseWriter, req *http.Request) {
 	return
 }
 
+// PauseActionID - stop launching new operations of a running action
+func PauseActionID(w http.ResponseWriter, req *http.Request) {
+
+	defer base.DrainAndCloseRequestBody(req)
+
+	pb := GetUUIDFromVars("actionID", req)
+	if pb.IsError {
+		WriteHeaders(w, pb)
+		return
+	}
+	actionID := pb.Obj.(uuid.UUID)
+
+	var parameters presentation.PauseActionParameters
+	if req.Body != nil {
+		body, err := ioutil.ReadAll(req.Body)
+		if err != nil {
+			pb = model.BuildErrorPassback(http.StatusInternalServerError, err)
+			logrus.WithFields(logrus.Fields{"ERROR": err, "HttpStatusCode": pb.StatusCode}).Error("Error detected retrieving body")
+			WriteHeaders(w, pb)
+			return
+		}
+		//the reason is optional
+		if len(body) > 0 {
+			err = json.Unmarshal(body, &parameters)
+			if err != nil {
+				pb = model.BuildErrorPassback(http.StatusBadRequest, err)
+				logrus.WithFields(logrus.Fields{"ERROR": err, "HttpStatusCode": pb.StatusCode}).Error("Unparseable json")
+				WriteHeaders(w, pb)
+				return
+			}
+		}
+	}
+
+	pb = domain.PauseActionID(actionID, parameters.Reason)
+	WriteHeaders(w, pb)
+	return
+}
+
+// ResumeActionID - let a paused action launch operations again
+func ResumeActionID(w http.ResponseWriter, req *http.Request) {
+
+	defer base.DrainAndCloseRequestBody(req)
+
+	pb := GetUUIDFromVars("actionID", req)
+	if pb.IsError {
+		WriteHeaders(w, pb)
+		return
+	}
+	actionID := pb.Obj.(uuid.UUID)
+
+	pb = domain.ResumeActionID(actionID)
+	WriteHeaders(w, pb)
+	return
+}
+
+// ActivateActionID - reset in the images a staging action left on its devices
+func ActivateActionID(w http.ResponseWriter, req *http.Request) {
+
+	defer base.DrainAndCloseRequestBody(req)
+
+	pb := GetUUIDFromVars("actionID", req)
+	if pb.IsError {
+		WriteHeaders(w, pb)
+		return
+	}
+	actionID := pb.Obj.(uuid.UUID)
+
+	var parameters presentation.ActivateActionParameters
+	if req.Body != nil {
+		body, err := ioutil.ReadAll(req.Body)
+		if err != nil {
+			pb = model.BuildErrorPassback(http.StatusInternalServerError, err)
+			logrus.WithFields(logrus.Fields{"ERROR": err, "HttpStatusCode": pb.StatusCode}).Error("Error detected retrieving body")
+			WriteHeaders(w, pb)
+			return
+		}
+		//a batch of one with the image reset type is the default
+		if len(body) > 0 {
+			err = json.Unmarshal(body, &parameters)
+			if err != nil {
+				pb = model.BuildErrorPassback(http.StatusBadRequest, err)
+				logrus.WithFields(logrus.Fields{"ERROR": err, "HttpStatusCode": pb.StatusCode}).Error("Unparseable json")
+				WriteHeaders(w, pb)
+				return
+			}
+		}
+	}
+
+	pb = domain.ActivateActionID(actionID, parameters)
+	WriteHeaders(w, pb)
+	return
+}
+
+// RetryActionID - create a new action for the operations of a finished action that did not succeed
+func RetryActionID(w http.ResponseWriter, req *http.Request) {
+
+	defer base.DrainAndCloseRequestBody(req)
+
+	pb := GetUUIDFromVars("actionID", req)
+	if pb.IsError {
+		WriteHeaders(w, pb)
+		return
+	}
+	actionID := pb.Obj.(uuid.UUID)
+
+	var parameters presentation.RetryActionParameters
+	if req.Body != nil {
+		body, err := ioutil.ReadAll(req.Body)
+		if err != nil {
+			pb = model.BuildErrorPassback(http.StatusInternalServerError, err)
+			logrus.WithFields(logrus.Fields{"ERROR": err, "HttpStatusCode": pb.StatusCode}).Error("Error detected retrieving body")
+			WriteHeaders(w, pb)
+			return
+		}
+		//an empty body retries every state
+		if len(body) > 0 {
+			err = json.Unmarshal(body, &parameters)
+			if err != nil {
+				pb = model.BuildErrorPassback(http.StatusBadRequest, err)
+				logrus.WithFields(logrus.Fields{"ERROR": err, "HttpStatusCode": pb.StatusCode}).Error("Unparseable json")
+				WriteHeaders(w, pb)
+				return
+			}
+		}
+	}
+
+	pb = domain.RetryActionID(actionID, parameters.States)
+	if pb.IsError == false && pb.StatusCode < 400 {
+		location := "../../actions/" + (pb.Obj.(presentation.CreateActionPayload).ActionID.String())
+		WriteHeadersWithLocation(w, pb, location)
+	} else {
+		WriteHeaders(w, pb)
+	}
+	return
+}
+
 // GetActionOperationID - get an operation by action/operation ids
 func GetActionOperationID(w http.ResponseWriter, req *http.Request) {
 
@@ -220,3 +389,54 @@ func GetOperationID(w http.ResponseWriter, req *http.Request) {
 	WriteHeaders(w, pb)
 	return
 }
+
+// GetOperationHistory - everything that happened to an operation
+func GetOperationHistory(w http.ResponseWriter, req *http.Request) {
+
+	defer base.DrainAndCloseRequestBody(req)
+
+	pb := GetUUIDFromVars("operationID", req)
+	if pb.IsError {
+		WriteHeaders(w, pb)
+		return
+	}
+	operationID := pb.Obj.(uuid.UUID)
+
+	pb = domain.GetOperationHistory(operationID)
+	WriteHeaders(w, pb)
+	return
+}
+
+// AbortOperationID - stop a single operation without aborting its action
+func AbortOperationID(w http.ResponseWriter, req *http.Request) {
+
+	defer base.DrainAndCloseRequestBody(req)
+
+	pb := GetUUIDFromVars("operationID", req)
+	if pb.IsError {
+		WriteHeaders(w, pb)
+		return
+	}
+	operationID := pb.Obj.(uuid.UUID)
+
+	pb = domain.AbortOperationID(operationID)
+	WriteHeaders(w, pb)
+	return
+}
+
+// SkipOperationID - do not launch a single operation of an action
+func SkipOperationID(w http.ResponseWriter, req *http.Request) {
+
+	defer base.DrainAndCloseRequestBody(req)
+
+	pb := GetUUIDFromVars("operationID", req)
+	if pb.IsError {
+		WriteHeaders(w, pb)
+		return
+	}
+	operationID := pb.Obj.(uuid.UUID)
+
+	pb = domain.SkipOperationID(operationID)
+	WriteHeaders(w, pb)
+	return
+}
@@ -25,6 +25,7 @@
 package api
 
 import (
+	"context"
 	"encoding/json"
 	"io/ioutil"
 	"net/http"
@@ -163,6 +164,41 @@ func (suite *Update_TS) Test_POST_Actions_GoodXnamesWithCompositeTargets_HappyPa
 	logrus.Trace(usp)
 }
 
+func (suite *Update_TS) Test_POST_Preflight() {
+	r, _ := http.NewRequest("POST", "/actions/preflight", nil)
+	w := httptest.NewRecorder()
+	NewRouter().ServeHTTP(w, r)
+	resp := w.Result()
+	defer base.DrainAndCloseResponseBody(resp)
+	suite.Equal(http.StatusBadRequest, resp.StatusCode)
+
+	apj, _ := json.Marshal(storage.ActionParameters{
+		StateComponentFilter: storage.StateComponentFilter{Xnames: []string{"badXname"}},
+	})
+	r, _ = http.NewRequest("POST", "/actions/preflight", strings.NewReader(string(apj)))
+	w = httptest.NewRecorder()
+	NewRouter().ServeHTTP(w, r)
+	suite.Equal(http.StatusBadRequest, w.Result().StatusCode)
+
+	before, _ := domain.GetStoredActions()
+	apj, _ = json.Marshal(GetDefaultActionParameters())
+	r, _ = http.NewRequest("POST", "/actions/preflight", strings.NewReader(string(apj)))
+	w = httptest.NewRecorder()
+	NewRouter().ServeHTTP(w, r)
+	resp = w.Result()
+	defer base.DrainAndCloseResponseBody(resp)
+	suite.Equal(http.StatusOK, resp.StatusCode)
+
+	body, _ := ioutil.ReadAll(resp.Body)
+	report := presentation.PreflightReport{}
+	suite.NoError(json.Unmarshal(body, &report))
+	suite.Equal(len(report.Devices), report.Counts.Total)
+
+	//a preflight never creates an action
+	after, _ := domain.GetStoredActions()
+	suite.Equal(len(before), len(after))
+}
+
 func (suite *Update_TS) Test_DELETE_Action_NoID() {
 	r, _ := http.NewRequest("DELETE", "/actions/", nil)
 	w := httptest.NewRecorder()
@@ -229,6 +265,138 @@ func (suite *Update_TS) Test_KILL_Action_NotFound() {
 	suite.Equal(http.StatusNotFound, resp.StatusCode)
 }
 
+func (suite *Update_TS) Test_PAUSE_RESUME_Action() {
+	action := storage.HelperGetStockAction()
+	action.State.SetState("running")
+	err := DSP.StoreAction(action)
+	suite.True(err == nil)
+
+	r, _ := http.NewRequest("PUT", "/actions/"+action.ActionID.String()+"/pause", strings.NewReader(`{"reason":"testing"}`))
+	w := httptest.NewRecorder()
+	NewRouter().ServeHTTP(w, r)
+	suite.Equal(http.StatusAccepted, w.Result().StatusCode)
+
+	r, _ = http.NewRequest("PUT", "/actions/"+action.ActionID.String()+"/resume", nil)
+	w = httptest.NewRecorder()
+	NewRouter().ServeHTTP(w, r)
+	suite.Equal(http.StatusAccepted, w.Result().StatusCode)
+
+	r, _ = http.NewRequest("PUT", "/actions/"+uuid.New().String()+"/pause", nil)
+	w = httptest.NewRecorder()
+	NewRouter().ServeHTTP(w, r)
+	suite.Equal(http.StatusNotFound, w.Result().StatusCode)
+}
+
+func (suite *Update_TS) Test_ACTIVATE_Action() {
+	action := storage.HelperGetStockAction()
+	action.Command.ApplyTime = storage.ApplyTimeOnReset
+	action.State.SetState("completed")
+	operation := storage.HelperGetStockOperation()
+	operation.ActionID = action.ActionID
+	operation.State.SetState("staged")
+	action.OperationIDs = []uuid.UUID{operation.OperationID}
+	suite.True(DSP.StoreOperation(operation) == nil)
+	suite.True(DSP.StoreAction(action) == nil)
+
+	r, _ := http.NewRequest("POST", "/actions/"+action.ActionID.String()+"/activate", strings.NewReader(`{"batchSize":`))
+	w := httptest.NewRecorder()
+	NewRouter().ServeHTTP(w, r)
+	suite.Equal(http.StatusBadRequest, w.Result().StatusCode)
+
+	r, _ = http.NewRequest("POST", "/actions/"+action.ActionID.String()+"/activate", strings.NewReader(`{"batchSize":4}`))
+	w = httptest.NewRecorder()
+	NewRouter().ServeHTTP(w, r)
+	suite.Equal(http.StatusAccepted, w.Result().StatusCode)
+
+	r, _ = http.NewRequest("POST", "/actions/"+action.ActionID.String()+"/activate", nil)
+	w = httptest.NewRecorder()
+	NewRouter().ServeHTTP(w, r)
+	suite.Equal(http.StatusConflict, w.Result().StatusCode)
+
+	r, _ = http.NewRequest("POST", "/actions/"+uuid.New().String()+"/activate", nil)
+	w = httptest.NewRecorder()
+	NewRouter().ServeHTTP(w, r)
+	suite.Equal(http.StatusNotFound, w.Result().StatusCode)
+}
+
+func (suite *Update_TS) Test_ABORT_SKIP_Operation() {
+	operation := storage.HelperGetStockOperation()
+	operation.State.SetState("configured")
+	err := DSP.StoreOperation(operation)
+	suite.True(err == nil)
+
+	r, _ := http.NewRequest("PUT", "/operations/"+operation.OperationID.String()+"/skip", nil)
+	w := httptest.NewRecorder()
+	NewRouter().ServeHTTP(w, r)
+	suite.Equal(http.StatusAccepted, w.Result().StatusCode)
+
+	r, _ = http.NewRequest("PUT", "/operations/"+operation.OperationID.String()+"/abort", nil)
+	w = httptest.NewRecorder()
+	NewRouter().ServeHTTP(w, r)
+	suite.Equal(http.StatusAccepted, w.Result().StatusCode)
+
+	r, _ = http.NewRequest("PUT", "/operations/"+uuid.New().String()+"/abort", nil)
+	w = httptest.NewRecorder()
+	NewRouter().ServeHTTP(w, r)
+	suite.Equal(http.StatusNotFound, w.Result().StatusCode)
+}
+
+func (suite *Update_TS) Test_GET_Operation_History() {
+	operation := storage.HelperGetStockOperation()
+	operation.State.SetState("configured")
+	suite.True(DSP.StoreOperation(operation) == nil)
+	_ = operation.State.Event(context.Background(), "start")
+	operation.StateHelper = "preparing to launch"
+	suite.True(domain.StoreOperation(operation) == nil)
+
+	r, _ := http.NewRequest("GET", "/operations/"+operation.OperationID.String()+"/history", nil)
+	w := httptest.NewRecorder()
+	NewRouter().ServeHTTP(w, r)
+	suite.Equal(http.StatusOK, w.Result().StatusCode)
+	var history presentation.OperationHistory
+	suite.True(json.NewDecoder(w.Result().Body).Decode(&history) == nil)
+	suite.Equal(operation.OperationID, history.OperationID)
+	suite.Equal("inProgress", history.State)
+	suite.Equal(1, len(history.History))
+	suite.Equal("start", history.History[0].Event)
+	suite.Equal("preparing to launch", history.History[0].StateHelper)
+
+	r, _ = http.NewRequest("GET", "/operations/"+uuid.New().String()+"/history", nil)
+	w = httptest.NewRecorder()
+	NewRouter().ServeHTTP(w, r)
+	suite.Equal(http.StatusNotFound, w.Result().StatusCode)
+
+	r, _ = http.NewRequest("GET", "/operations/notauuid/history", nil)
+	w = httptest.NewRecorder()
+	NewRouter().ServeHTTP(w, r)
+	suite.Equal(http.StatusBadRequest, w.Result().StatusCode)
+}
+
+func (suite *Update_TS) Test_RETRY_Action_NotFinished() {
+	action := storage.HelperGetStockAction()
+	err := DSP.StoreAction(action)
+	suite.True(err == nil)
+
+	r, _ := http.NewRequest("POST", "/actions/"+action.ActionID.String()+"/retry", nil)
+	w := httptest.NewRecorder()
+	NewRouter().ServeHTTP(w, r)
+	resp := w.Result()
+	suite.Equal(http.StatusConflict, resp.StatusCode)
+}
+
+func (suite *Update_TS) Test_RETRY_Action_BadState() {
+	action := storage.HelperGetStockAction()
+	action.State.SetState("completed")
+	err := DSP.StoreAction(action)
+	suite.True(err == nil)
+
+	r, _ := http.NewRequest("POST", "/actions/"+action.ActionID.String()+"/retry", strings.NewReader(`{"states":["succeeded"]}`))
+	w := httptest.NewRecorder()
+	NewRouter().ServeHTTP(w, r)
+	resp := w.Result()
+	suite.Equal(http.StatusBadRequest, resp.StatusCode)
+}
+
 func (suite *Update_TS) Test_GET_actions_ALL_After_UpdateAll() {
 	parameters := storage.ActionParameters{
 		Command: storage.Command{
@@ -0,0 +1,100 @@
+/*
+ * MIT License
+ *
+ * (C) Copyright [2026] Hewlett Packard Enterprise Development LP
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a
+ * copy of this software and associated documentation files (the "Software"),
+ * to deal in the Software without restriction, including without limitation
+ * the rights to use, copy, modify, merge, publish, distribute, sublicense,
+ * and/or sell copies of the Software, and to permit persons to whom the
+ * Software is furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included
+ * in all copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL
+ * THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+ * OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+ * ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+ * OTHER DEALINGS IN THE SOFTWARE.
+ */
+
+package api
+
+import (
+	"net/http"
+	"time"
+
+	base "github.com/Cray-HPE/hms-base/v2"
+	"github.com/Cray-HPE/hms-firmware-action/internal/domain"
+	"github.com/Cray-HPE/hms-firmware-action/internal/model"
+	"github.com/Cray-HPE/hms-firmware-action/internal/storage"
+	"github.com/google/uuid"
+)
+
+// GetArchivedActions - search the archive of expired actions
+func GetArchivedActions(w http.ResponseWriter, req *http.Request) {
+
+	defer base.DrainAndCloseRequestBody(req)
+
+	var err error
+	var filter storage.ArchiveFilter
+	query := req.URL.Query()
+	filter.Xname = query.Get("xname")
+	filter.Target = query.Get("target")
+	filter.Description = query.Get("description")
+	if val := query.Get("endedAfter"); val != "" {
+		filter.EndedAfter, err = time.Parse(time.RFC3339, val)
+	}
+	if val := query.Get("endedBefore"); val != "" && err == nil {
+		filter.EndedBefore, err = time.Parse(time.RFC3339, val)
+	}
+	if err != nil {
+		pb := model.BuildErrorPassback(http.StatusBadRequest, err)
+		WriteHeaders(w, pb)
+		return
+	}
+
+	pb := domain.GetArchivedActions(filter)
+	WriteHeaders(w, pb)
+	return
+}
+
+// GetArchivedAction - get an archived action with detailed operations
+func GetArchivedAction(w http.ResponseWriter, req *http.Request) {
+
+	defer base.DrainAndCloseRequestBody(req)
+
+	pb := GetUUIDFromVars("actionID", req)
+	if pb.IsError {
+		WriteHeaders(w, pb)
+		return
+	}
+	pb = domain.GetArchivedAction(pb.Obj.(uuid.UUID))
+	WriteHeaders(w, pb)
+	return
+}
+
+// RestoreArchivedAction - copy an archived action back into the live store
+func RestoreArchivedAction(w http.ResponseWriter, req *http.Request) {
+
+	defer base.DrainAndCloseRequestBody(req)
+
+	pb := GetUUIDFromVars("actionID", req)
+	if pb.IsError {
+		WriteHeaders(w, pb)
+		return
+	}
+	actionID := pb.Obj.(uuid.UUID)
+	pb = domain.RestoreArchivedAction(actionID)
+	if pb.IsError == false {
+		location := "../actions/" + actionID.String()
+		WriteHeadersWithLocation(w, pb, location)
+		return
+	}
+	WriteHeaders(w, pb)
+	return
+}
@@ -0,0 +1,64 @@
+/*
+ * MIT License
+ *
+ * (C) Copyright [2026] Hewlett Packard Enterprise Development LP
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a
+ * copy of this software and associated documentation files (the "Software"),
+ * to deal in the Software without restriction, including without limitation
+ * the rights to use, copy, modify, merge, publish, distribute, sublicense,
+ * and/or sell copies of the Software, and to permit persons to whom the
+ * Software is furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included
+ * in all copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL
+ * THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+ * OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+ * ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+ * OTHER DEALINGS IN THE SOFTWARE.
+ */
+
+package api
+
+import (
+	"net/http"
+	"time"
+
+	base "github.com/Cray-HPE/hms-base/v2"
+	"github.com/Cray-HPE/hms-firmware-action/internal/domain"
+	"github.com/sirupsen/logrus"
+)
+
+// GetBackup - stream a backup of every image, snapshot, action and operation
+func GetBackup(w http.ResponseWriter, req *http.Request) {
+
+	defer base.DrainAndCloseRequestBody(req)
+
+	fileName := "fas-backup-" + time.Now().UTC().Format("20060102T150405Z") + ".jsonl"
+	w.Header().Add("Content-Type", "application/x-ndjson")
+	w.Header().Add("Content-Disposition", "attachment; filename=\""+fileName+"\"")
+	w.WriteHeader(http.StatusOK)
+	err := domain.WriteBackup(w)
+	if err != nil {
+		logrus.WithField("ERROR", err).Error("Backup stream ended early")
+	}
+	return
+}
+
+// RestoreBackup - import a backup; conflict is one of skip, overwrite or fail (default)
+func RestoreBackup(w http.ResponseWriter, req *http.Request) {
+
+	defer base.DrainAndCloseRequestBody(req)
+
+	mode := req.URL.Query().Get("conflict")
+	if mode == "" {
+		mode = domain.ConflictModeFail
+	}
+	pb := domain.RestoreBackup(req.Body, mode)
+	WriteHeaders(w, pb)
+	return
+}
@@ -0,0 +1,72 @@
+/*
+ * MIT License
+ *
+ * (C) Copyright [2026] Hewlett Packard Enterprise Development LP
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a
+ * copy of this software and associated documentation files (the "Software"),
+ * to deal in the Software without restriction, including without limitation
+ * the rights to use, copy, modify, merge, publish, distribute, sublicense,
+ * and/or sell copies of the Software, and to permit persons to whom the
+ * Software is furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included
+ * in all copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL
+ * THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+ * OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+ * ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+ * OTHER DEALINGS IN THE SOFTWARE.
+ */
+
+package api
+
+import (
+	"bytes"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	base "github.com/Cray-HPE/hms-base/v2"
+	"github.com/stretchr/testify/suite"
+)
+
+type Backup_TS struct {
+	suite.Suite
+}
+
+func (suite *Backup_TS) Test_GET_Backup_RestoreSkip() {
+	r, _ := http.NewRequest("GET", "/backup", nil)
+	w := httptest.NewRecorder()
+	NewRouter().ServeHTTP(w, r)
+	resp := w.Result()
+	defer base.DrainAndCloseResponseBody(resp)
+	suite.Equal(http.StatusOK, resp.StatusCode)
+	suite.Equal("application/x-ndjson", resp.Header.Get("Content-Type"))
+	body, _ := ioutil.ReadAll(resp.Body)
+
+	r, _ = http.NewRequest("POST", "/backup/restore?conflict=skip", bytes.NewReader(body))
+	w = httptest.NewRecorder()
+	NewRouter().ServeHTTP(w, r)
+	resp2 := w.Result()
+	defer base.DrainAndCloseResponseBody(resp2)
+	suite.Equal(http.StatusOK, resp2.StatusCode)
+}
+
+func (suite *Backup_TS) Test_POST_BackupRestore_BadMode() {
+	r, _ := http.NewRequest("POST", "/backup/restore?conflict=merge", bytes.NewReader([]byte{}))
+	w := httptest.NewRecorder()
+	NewRouter().ServeHTTP(w, r)
+	resp := w.Result()
+	defer base.DrainAndCloseResponseBody(resp)
+	suite.Equal(http.StatusBadRequest, resp.StatusCode)
+}
+
+func Test_API_Backup(t *testing.T) {
+	ConfigureSystemForUnitTesting()
+	suite.Run(t, new(Backup_TS))
+}
@@ -0,0 +1,165 @@
+/*
+ * MIT License
+ *
+ * (C) Copyright [2026] Hewlett Packard Enterprise Development LP
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a
+ * copy of this software and associated documentation files (the "Software"),
+ * to deal in the Software without restriction, including without limitation
+ * the rights to use, copy, modify, merge, publish, distribute, sublicense,
+ * and/or sell copies of the Software, and to permit persons to whom the
+ * Software is furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included
+ * in all copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL
+ * THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+ * OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+ * ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+ * OTHER DEALINGS IN THE SOFTWARE.
+ */
+
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"net/http"
+
+	base "github.com/Cray-HPE/hms-base/v2"
+	"github.com/Cray-HPE/hms-firmware-action/internal/domain"
+	"github.com/Cray-HPE/hms-firmware-action/internal/model"
+	"github.com/Cray-HPE/hms-firmware-action/internal/presentation"
+	"github.com/Cray-HPE/hms-firmware-action/internal/storage"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+)
+
+// CreateCompatibilityRule - will create a compatibility rule
+func CreateCompatibilityRule(w http.ResponseWriter, req *http.Request) {
+
+	defer base.DrainAndCloseRequestBody(req)
+
+	var pb model.Passback
+	var rule presentation.RawCompatibilityRule
+
+	if req.Body != nil {
+		body, err := ioutil.ReadAll(req.Body)
+		logrus.WithFields(logrus.Fields{"body": string(body)}).Trace("Printing request body -- CreateCompatibilityRule")
+
+		if err != nil {
+			pb := model.BuildErrorPassback(http.StatusInternalServerError, err)
+			logrus.WithFields(logrus.Fields{"ERROR": err, "HttpStatusCode": pb.StatusCode}).Error("Error detected retrieving body")
+			WriteHeaders(w, pb)
+			return
+		}
+
+		err = json.Unmarshal(body, &rule)
+		if err != nil {
+			pb = model.BuildErrorPassback(http.StatusBadRequest, err)
+			logrus.WithFields(logrus.Fields{"ERROR": err, "HttpStatusCode": pb.StatusCode}).Error("Unparseable json")
+			WriteHeaders(w, pb)
+			return
+		}
+
+		pb = domain.CreateCompatibilityRule(rule)
+		if pb.IsError == false {
+			ruleID := pb.Obj.(storage.CompatibilityRuleID)
+			location := "../compatibilityrules/" + ruleID.RuleID.String()
+			WriteHeadersWithLocation(w, pb, location)
+		} else {
+			WriteHeaders(w, pb)
+		}
+		return
+	}
+	err := errors.New("body cannot be empty")
+	pb = model.BuildErrorPassback(http.StatusBadRequest, err)
+	logrus.WithFields(logrus.Fields{"ERROR": err, "HttpStatusCode": pb.StatusCode}).Error("empty body")
+	WriteHeaders(w, pb)
+}
+
+// GetCompatibilityRules - will return all compatibility rules
+func GetCompatibilityRules(w http.ResponseWriter, req *http.Request) {
+
+	defer base.DrainAndCloseRequestBody(req)
+
+	pb := domain.GetCompatibilityRules()
+	WriteHeaders(w, pb)
+}
+
+// GetCompatibilityRule - will return a compatibility rule
+func GetCompatibilityRule(w http.ResponseWriter, req *http.Request) {
+
+	defer base.DrainAndCloseRequestBody(req)
+
+	pb := GetUUIDFromVars("ruleID", req)
+	if pb.IsError {
+		WriteHeaders(w, pb)
+		return
+	}
+	ruleID := pb.Obj.(uuid.UUID)
+	pb = domain.GetCompatibilityRule(ruleID)
+	WriteHeaders(w, pb)
+}
+
+// DeleteCompatibilityRule - will delete a compatibility rule
+func DeleteCompatibilityRule(w http.ResponseWriter, req *http.Request) {
+
+	defer base.DrainAndCloseRequestBody(req)
+
+	pb := GetUUIDFromVars("ruleID", req)
+	if pb.IsError {
+		WriteHeaders(w, pb)
+		return
+	}
+	ruleID := pb.Obj.(uuid.UUID)
+	pb = domain.DeleteCompatibilityRule(ruleID)
+	WriteHeaders(w, pb)
+}
+
+// UpdateCompatibilityRule - will create or replace a compatibility rule
+func UpdateCompatibilityRule(w http.ResponseWriter, req *http.Request) {
+
+	defer base.DrainAndCloseRequestBody(req)
+
+	var rule presentation.RawCompatibilityRule
+
+	pb := GetUUIDFromVars("ruleID", req)
+	if pb.IsError {
+		WriteHeaders(w, pb)
+		return
+	}
+	ruleID := pb.Obj.(uuid.UUID)
+
+	if req.Body == nil {
+		err := errors.New("body cannot be empty")
+		pb = model.BuildErrorPassback(http.StatusBadRequest, err)
+		logrus.WithFields(logrus.Fields{"ERROR": err, "HttpStatusCode": pb.StatusCode}).Error("empty body")
+		WriteHeaders(w, pb)
+		return
+	}
+
+	body, err := ioutil.ReadAll(req.Body)
+	logrus.WithFields(logrus.Fields{"body": string(body)}).Trace("Printing request body")
+	if err != nil {
+		pb := model.BuildErrorPassback(http.StatusInternalServerError, err)
+		logrus.WithFields(logrus.Fields{"ERROR": err, "HttpStatusCode": pb.StatusCode}).Error("Error detected retrieving body")
+		WriteHeaders(w, pb)
+		return
+	}
+
+	err = json.Unmarshal(body, &rule)
+	if err != nil {
+		pb = model.BuildErrorPassback(http.StatusBadRequest, err)
+		logrus.WithFields(logrus.Fields{"ERROR": err, "HttpStatusCode": pb.StatusCode}).Error("Unparseable json")
+		WriteHeaders(w, pb)
+		return
+	}
+
+	pb = domain.UpdateCompatibilityRule(rule, ruleID)
+	WriteHeaders(w, pb)
+}
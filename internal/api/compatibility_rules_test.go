@@ -0,0 +1,130 @@
+/*
+ * MIT License
+ *
+ * (C) Copyright [2026] Hewlett Packard Enterprise Development LP
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a
+ * copy of this software and associated documentation files (the "Software"),
+ * to deal in the Software without restriction, including without limitation
+ * the rights to use, copy, modify, merge, publish, distribute, sublicense,
+ * and/or sell copies of the Software, and to permit persons to whom the
+ * Software is furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included
+ * in all copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL
+ * THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+ * OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+ * ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+ * OTHER DEALINGS IN THE SOFTWARE.
+ */
+
+package api
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	base "github.com/Cray-HPE/hms-base/v2"
+	"github.com/Cray-HPE/hms-firmware-action/internal/presentation"
+	"github.com/Cray-HPE/hms-firmware-action/internal/storage"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/suite"
+)
+
+type Compatibility_Rules_TS struct {
+	suite.Suite
+}
+
+// TEST: Test_CompatibilityRules_HappyPath
+// POST, GET, PUT and DELETE /compatibilityrules
+func (suite *Compatibility_Rules_TS) Test_CompatibilityRules_HappyPath() {
+	raw := presentation.RawCompatibilityRule{
+		Description: "one BMC version per chassis",
+		Type:        storage.CompatibilityRuleUniform,
+		Target:      "BMC",
+	}
+	apj, _ := json.Marshal(raw)
+	r, _ := http.NewRequest("POST", "/compatibilityrules", strings.NewReader(string(apj)))
+	w := httptest.NewRecorder()
+	NewRouter().ServeHTTP(w, r)
+	resp := w.Result()
+	defer base.DrainAndCloseResponseBody(resp)
+	suite.Equal(http.StatusOK, resp.StatusCode)
+	body, _ := ioutil.ReadAll(resp.Body)
+	ruleID := storage.CompatibilityRuleID{}
+	_ = json.Unmarshal(body, &ruleID)
+	suite.Equal("../compatibilityrules/"+ruleID.RuleID.String(), resp.Header.Get("Location"))
+
+	r, _ = http.NewRequest("GET", "/compatibilityrules", nil)
+	w = httptest.NewRecorder()
+	NewRouter().ServeHTTP(w, r)
+	resp = w.Result()
+	suite.Equal(http.StatusOK, resp.StatusCode)
+	body, _ = ioutil.ReadAll(resp.Body)
+	rules := presentation.CompatibilityRules{}
+	_ = json.Unmarshal(body, &rules)
+	found := false
+	for _, rule := range rules.CompatibilityRules {
+		if rule.RuleID == ruleID.RuleID {
+			found = true
+			suite.Equal("Chassis", rule.Scope)
+		}
+	}
+	suite.True(found)
+
+	raw.Scope = "Cabinet"
+	apj, _ = json.Marshal(raw)
+	r, _ = http.NewRequest("PUT", "/compatibilityrules/"+ruleID.RuleID.String(), strings.NewReader(string(apj)))
+	w = httptest.NewRecorder()
+	NewRouter().ServeHTTP(w, r)
+	suite.Equal(http.StatusOK, w.Result().StatusCode)
+
+	r, _ = http.NewRequest("GET", "/compatibilityrules/"+ruleID.RuleID.String(), nil)
+	w = httptest.NewRecorder()
+	NewRouter().ServeHTTP(w, r)
+	resp = w.Result()
+	suite.Equal(http.StatusOK, resp.StatusCode)
+	body, _ = ioutil.ReadAll(resp.Body)
+	rule := presentation.CompatibilityRuleMarshaled{}
+	_ = json.Unmarshal(body, &rule)
+	suite.Equal("Cabinet", rule.Scope)
+
+	r, _ = http.NewRequest("DELETE", "/compatibilityrules/"+ruleID.RuleID.String(), nil)
+	w = httptest.NewRecorder()
+	NewRouter().ServeHTTP(w, r)
+	suite.Equal(http.StatusNoContent, w.Result().StatusCode)
+}
+
+// TEST: Test_CompatibilityRules_Errors
+// Returns 400 on bad input and 404 on unknown rules
+func (suite *Compatibility_Rules_TS) Test_CompatibilityRules_Errors() {
+	r, _ := http.NewRequest("POST", "/compatibilityrules", strings.NewReader(`{"type":"sometimes","target":"BMC"}`))
+	w := httptest.NewRecorder()
+	NewRouter().ServeHTTP(w, r)
+	suite.Equal(http.StatusBadRequest, w.Result().StatusCode)
+
+	r, _ = http.NewRequest("GET", "/compatibilityrules/"+uuid.New().String(), nil)
+	w = httptest.NewRecorder()
+	NewRouter().ServeHTTP(w, r)
+	suite.Equal(http.StatusNotFound, w.Result().StatusCode)
+
+	r, _ = http.NewRequest("DELETE", "/compatibilityrules/foo", nil)
+	w = httptest.NewRecorder()
+	NewRouter().ServeHTTP(w, r)
+	suite.Equal(http.StatusBadRequest, w.Result().StatusCode)
+}
+
+func Test_API_Compatibility_Rules(t *testing.T) {
+	//This setups the production routs and handler
+	CreateRouterAndHandler()
+	ConfigureSystemForUnitTesting()
+	suite.Run(t, new(Compatibility_Rules_TS))
+}
@@ -0,0 +1,165 @@
+/*
+ * MIT License
+ *
+ * (C) Copyright [2026] Hewlett Packard Enterprise Development LP
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a
+ * copy of this software and associated documentation files (the "Software"),
+ * to deal in the Software without restriction, including without limitation
+ * the rights to use, copy, modify, merge, publish, distribute, sublicense,
+ * and/or sell copies of the Software, and to permit persons to whom the
+ * Software is furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included
+ * in all copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL
+ * THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+ * OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+ * ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+ * OTHER DEALINGS IN THE SOFTWARE.
+ */
+
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"net/http"
+
+	base "github.com/Cray-HPE/hms-base/v2"
+	"github.com/Cray-HPE/hms-firmware-action/internal/domain"
+	"github.com/Cray-HPE/hms-firmware-action/internal/model"
+	"github.com/Cray-HPE/hms-firmware-action/internal/presentation"
+	"github.com/Cray-HPE/hms-firmware-action/internal/storage"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+)
+
+// CreateExclusionPolicy - will create an exclusion policy
+func CreateExclusionPolicy(w http.ResponseWriter, req *http.Request) {
+
+	defer base.DrainAndCloseRequestBody(req)
+
+	var pb model.Passback
+	var policy presentation.RawExclusionPolicy
+
+	if req.Body != nil {
+		body, err := ioutil.ReadAll(req.Body)
+		logrus.WithFields(logrus.Fields{"body": string(body)}).Trace("Printing request body -- CreateExclusionPolicy")
+
+		if err != nil {
+			pb := model.BuildErrorPassback(http.StatusInternalServerError, err)
+			logrus.WithFields(logrus.Fields{"ERROR": err, "HttpStatusCode": pb.StatusCode}).Error("Error detected retrieving body")
+			WriteHeaders(w, pb)
+			return
+		}
+
+		err = json.Unmarshal(body, &policy)
+		if err != nil {
+			pb = model.BuildErrorPassback(http.StatusBadRequest, err)
+			logrus.WithFields(logrus.Fields{"ERROR": err, "HttpStatusCode": pb.StatusCode}).Error("Unparseable json")
+			WriteHeaders(w, pb)
+			return
+		}
+
+		pb = domain.CreateExclusionPolicy(policy)
+		if pb.IsError == false {
+			policyID := pb.Obj.(storage.ExclusionPolicyID)
+			location := "../exclusionpolicies/" + policyID.PolicyID.String()
+			WriteHeadersWithLocation(w, pb, location)
+		} else {
+			WriteHeaders(w, pb)
+		}
+		return
+	}
+	err := errors.New("body cannot be empty")
+	pb = model.BuildErrorPassback(http.StatusBadRequest, err)
+	logrus.WithFields(logrus.Fields{"ERROR": err, "HttpStatusCode": pb.StatusCode}).Error("empty body")
+	WriteHeaders(w, pb)
+}
+
+// GetExclusionPolicies - will return all exclusion policies
+func GetExclusionPolicies(w http.ResponseWriter, req *http.Request) {
+
+	defer base.DrainAndCloseRequestBody(req)
+
+	pb := domain.GetExclusionPolicies()
+	WriteHeaders(w, pb)
+}
+
+// GetExclusionPolicy - will return an exclusion policy
+func GetExclusionPolicy(w http.ResponseWriter, req *http.Request) {
+
+	defer base.DrainAndCloseRequestBody(req)
+
+	pb := GetUUIDFromVars("policyID", req)
+	if pb.IsError {
+		WriteHeaders(w, pb)
+		return
+	}
+	policyID := pb.Obj.(uuid.UUID)
+	pb = domain.GetExclusionPolicy(policyID)
+	WriteHeaders(w, pb)
+}
+
+// DeleteExclusionPolicy - will delete an exclusion policy
+func DeleteExclusionPolicy(w http.ResponseWriter, req *http.Request) {
+
+	defer base.DrainAndCloseRequestBody(req)
+
+	pb := GetUUIDFromVars("policyID", req)
+	if pb.IsError {
+		WriteHeaders(w, pb)
+		return
+	}
+	policyID := pb.Obj.(uuid.UUID)
+	pb = domain.DeleteExclusionPolicy(policyID)
+	WriteHeaders(w, pb)
+}
+
+// UpdateExclusionPolicy - will create or replace an exclusion policy
+func UpdateExclusionPolicy(w http.ResponseWriter, req *http.Request) {
+
+	defer base.DrainAndCloseRequestBody(req)
+
+	var policy presentation.RawExclusionPolicy
+
+	pb := GetUUIDFromVars("policyID", req)
+	if pb.IsError {
+		WriteHeaders(w, pb)
+		return
+	}
+	policyID := pb.Obj.(uuid.UUID)
+
+	if req.Body == nil {
+		err := errors.New("body cannot be empty")
+		pb = model.BuildErrorPassback(http.StatusBadRequest, err)
+		logrus.WithFields(logrus.Fields{"ERROR": err, "HttpStatusCode": pb.StatusCode}).Error("empty body")
+		WriteHeaders(w, pb)
+		return
+	}
+
+	body, err := ioutil.ReadAll(req.Body)
+	logrus.WithFields(logrus.Fields{"body": string(body)}).Trace("Printing request body")
+	if err != nil {
+		pb := model.BuildErrorPassback(http.StatusInternalServerError, err)
+		logrus.WithFields(logrus.Fields{"ERROR": err, "HttpStatusCode": pb.StatusCode}).Error("Error detected retrieving body")
+		WriteHeaders(w, pb)
+		return
+	}
+
+	err = json.Unmarshal(body, &policy)
+	if err != nil {
+		pb = model.BuildErrorPassback(http.StatusBadRequest, err)
+		logrus.WithFields(logrus.Fields{"ERROR": err, "HttpStatusCode": pb.StatusCode}).Error("Unparseable json")
+		WriteHeaders(w, pb)
+		return
+	}
+
+	pb = domain.UpdateExclusionPolicy(policy, policyID)
+	WriteHeaders(w, pb)
+}
@@ -0,0 +1,129 @@
+/*
+ * MIT License
+ *
+ * (C) Copyright [2026] Hewlett Packard Enterprise Development LP
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a
+ * copy of this software and associated documentation files (the "Software"),
+ * to deal in the Software without restriction, including without limitation
+ * the rights to use, copy, modify, merge, publish, distribute, sublicense,
+ * and/or sell copies of the Software, and to permit persons to whom the
+ * Software is furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included
+ * in all copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL
+ * THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+ * OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+ * ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+ * OTHER DEALINGS IN THE SOFTWARE.
+ */
+
+package api
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	base "github.com/Cray-HPE/hms-base/v2"
+	"github.com/Cray-HPE/hms-firmware-action/internal/presentation"
+	"github.com/Cray-HPE/hms-firmware-action/internal/storage"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/suite"
+)
+
+type Exclusion_Policies_TS struct {
+	suite.Suite
+}
+
+// TEST: Test_ExclusionPolicies_HappyPath
+// POST, GET, PUT and DELETE /exclusionpolicies
+func (suite *Exclusion_Policies_TS) Test_ExclusionPolicies_HappyPath() {
+	raw := presentation.RawExclusionPolicy{
+		Description: "management nodes are updated by hand",
+		Roles:       []string{"Management"},
+	}
+	apj, _ := json.Marshal(raw)
+	r, _ := http.NewRequest("POST", "/exclusionpolicies", strings.NewReader(string(apj)))
+	w := httptest.NewRecorder()
+	NewRouter().ServeHTTP(w, r)
+	resp := w.Result()
+	defer base.DrainAndCloseResponseBody(resp)
+	suite.Equal(http.StatusOK, resp.StatusCode)
+	body, _ := ioutil.ReadAll(resp.Body)
+	policyID := storage.ExclusionPolicyID{}
+	_ = json.Unmarshal(body, &policyID)
+	suite.Equal("../exclusionpolicies/"+policyID.PolicyID.String(), resp.Header.Get("Location"))
+
+	r, _ = http.NewRequest("GET", "/exclusionpolicies", nil)
+	w = httptest.NewRecorder()
+	NewRouter().ServeHTTP(w, r)
+	resp = w.Result()
+	suite.Equal(http.StatusOK, resp.StatusCode)
+	body, _ = ioutil.ReadAll(resp.Body)
+	policies := presentation.ExclusionPolicies{}
+	_ = json.Unmarshal(body, &policies)
+	found := false
+	for _, policy := range policies.ExclusionPolicies {
+		if policy.PolicyID == policyID.PolicyID {
+			found = true
+			suite.Equal([]string{"Management"}, policy.Roles)
+		}
+	}
+	suite.True(found)
+
+	raw.Xnames = []string{"x3000c0s*b0"}
+	apj, _ = json.Marshal(raw)
+	r, _ = http.NewRequest("PUT", "/exclusionpolicies/"+policyID.PolicyID.String(), strings.NewReader(string(apj)))
+	w = httptest.NewRecorder()
+	NewRouter().ServeHTTP(w, r)
+	suite.Equal(http.StatusOK, w.Result().StatusCode)
+
+	r, _ = http.NewRequest("GET", "/exclusionpolicies/"+policyID.PolicyID.String(), nil)
+	w = httptest.NewRecorder()
+	NewRouter().ServeHTTP(w, r)
+	resp = w.Result()
+	suite.Equal(http.StatusOK, resp.StatusCode)
+	body, _ = ioutil.ReadAll(resp.Body)
+	policy := presentation.ExclusionPolicyMarshaled{}
+	_ = json.Unmarshal(body, &policy)
+	suite.Equal([]string{"x3000c0s*b0"}, policy.Xnames)
+
+	r, _ = http.NewRequest("DELETE", "/exclusionpolicies/"+policyID.PolicyID.String(), nil)
+	w = httptest.NewRecorder()
+	NewRouter().ServeHTTP(w, r)
+	suite.Equal(http.StatusNoContent, w.Result().StatusCode)
+}
+
+// TEST: Test_ExclusionPolicies_Errors
+// Returns 400 on bad input and 404 on unknown policies
+func (suite *Exclusion_Policies_TS) Test_ExclusionPolicies_Errors() {
+	r, _ := http.NewRequest("POST", "/exclusionpolicies", strings.NewReader(`{"description":"everything"}`))
+	w := httptest.NewRecorder()
+	NewRouter().ServeHTTP(w, r)
+	suite.Equal(http.StatusBadRequest, w.Result().StatusCode)
+
+	r, _ = http.NewRequest("GET", "/exclusionpolicies/"+uuid.New().String(), nil)
+	w = httptest.NewRecorder()
+	NewRouter().ServeHTTP(w, r)
+	suite.Equal(http.StatusNotFound, w.Result().StatusCode)
+
+	r, _ = http.NewRequest("DELETE", "/exclusionpolicies/foo", nil)
+	w = httptest.NewRecorder()
+	NewRouter().ServeHTTP(w, r)
+	suite.Equal(http.StatusBadRequest, w.Result().StatusCode)
+}
+
+func Test_API_Exclusion_Policies(t *testing.T) {
+	//This setups the production routs and handler
+	CreateRouterAndHandler()
+	ConfigureSystemForUnitTesting()
+	suite.Run(t, new(Exclusion_Policies_TS))
+}
@@ -0,0 +1,66 @@
+/*
+ * MIT License
+ *
+ * (C) Copyright [2026] Hewlett Packard Enterprise Development LP
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a
+ * copy of this software and associated documentation files (the "Software"),
+ * to deal in the Software without restriction, including without limitation
+ * the rights to use, copy, modify, merge, publish, distribute, sublicense,
+ * and/or sell copies of the Software, and to permit persons to whom the
+ * Software is furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included
+ * in all copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL
+ * THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+ * OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+ * ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+ * OTHER DEALINGS IN THE SOFTWARE.
+ */
+
+package api
+
+import (
+	"net/http"
+	"strconv"
+
+	base "github.com/Cray-HPE/hms-base/v2"
+	"github.com/Cray-HPE/hms-firmware-action/internal/domain"
+	"github.com/Cray-HPE/hms-firmware-action/internal/model"
+	"github.com/gorilla/mux"
+)
+
+// GetQuarantine - list the failure streaks of devices; ?quarantined=true lists only the quarantined ones
+func GetQuarantine(w http.ResponseWriter, req *http.Request) {
+
+	defer base.DrainAndCloseRequestBody(req)
+
+	quarantinedOnly := false
+	if val := req.URL.Query().Get("quarantined"); val != "" {
+		var err error
+		quarantinedOnly, err = strconv.ParseBool(val)
+		if err != nil {
+			pb := model.BuildErrorPassback(http.StatusBadRequest, err)
+			WriteHeaders(w, pb)
+			return
+		}
+	}
+	pb := domain.GetQuarantine(quarantinedOnly)
+	WriteHeaders(w, pb)
+	return
+}
+
+// ClearQuarantine - release a device; ?target= limits it to one target of the xname
+func ClearQuarantine(w http.ResponseWriter, req *http.Request) {
+
+	defer base.DrainAndCloseRequestBody(req)
+
+	params := mux.Vars(req)
+	pb := domain.ClearQuarantine(params["xname"], req.URL.Query().Get("target"))
+	WriteHeaders(w, pb)
+	return
+}
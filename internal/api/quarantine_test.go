@@ -0,0 +1,106 @@
+/*
+ * MIT License
+ *
+ * (C) Copyright [2026] Hewlett Packard Enterprise Development LP
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a
+ * copy of this software and associated documentation files (the "Software"),
+ * to deal in the Software without restriction, including without limitation
+ * the rights to use, copy, modify, merge, publish, distribute, sublicense,
+ * and/or sell copies of the Software, and to permit persons to whom the
+ * Software is furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included
+ * in all copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL
+ * THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+ * OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+ * ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+ * OTHER DEALINGS IN THE SOFTWARE.
+ */
+
+package api
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	base "github.com/Cray-HPE/hms-base/v2"
+	"github.com/Cray-HPE/hms-firmware-action/internal/presentation"
+	"github.com/Cray-HPE/hms-firmware-action/internal/storage"
+	"github.com/stretchr/testify/suite"
+)
+
+type Quarantine_TS struct {
+	suite.Suite
+}
+
+// TEST: Test_Quarantine_HappyPath
+// GET /quarantine and DELETE /quarantine/{xname}
+func (suite *Quarantine_TS) Test_Quarantine_HappyPath() {
+	suite.Nil(DSP.StoreQuarantineEntry(storage.QuarantineEntry{Xname: "x0c0s5b0", Target: "BMC",
+		ConsecutiveFailures: 3, LastFailureCode: storage.FailureBMCTaskFailed, Quarantined: true}))
+	suite.Nil(DSP.StoreQuarantineEntry(storage.QuarantineEntry{Xname: "x0c0s5b0", Target: "BIOS",
+		ConsecutiveFailures: 1, LastFailureCode: storage.FailureVerifyTimeout}))
+
+	r, _ := http.NewRequest("GET", "/quarantine?quarantined=true", nil)
+	w := httptest.NewRecorder()
+	NewRouter().ServeHTTP(w, r)
+	resp := w.Result()
+	defer base.DrainAndCloseResponseBody(resp)
+	suite.Equal(http.StatusOK, resp.StatusCode)
+	body, _ := ioutil.ReadAll(resp.Body)
+	list := presentation.QuarantineList{}
+	_ = json.Unmarshal(body, &list)
+	found := 0
+	for _, entry := range list.Entries {
+		suite.True(entry.Quarantined)
+		if entry.Xname == "x0c0s5b0" {
+			found++
+			suite.Equal("BMC", entry.Target)
+		}
+	}
+	suite.Equal(1, found)
+
+	r, _ = http.NewRequest("DELETE", "/quarantine/x0c0s5b0?target=BMC", nil)
+	w = httptest.NewRecorder()
+	NewRouter().ServeHTTP(w, r)
+	suite.Equal(http.StatusNoContent, w.Result().StatusCode)
+
+	r, _ = http.NewRequest("DELETE", "/quarantine/x0c0s5b0", nil)
+	w = httptest.NewRecorder()
+	NewRouter().ServeHTTP(w, r)
+	suite.Equal(http.StatusNoContent, w.Result().StatusCode)
+}
+
+// TEST: Test_Quarantine_Errors
+// Returns 400 on bad input and 404 when nothing is quarantined
+func (suite *Quarantine_TS) Test_Quarantine_Errors() {
+	r, _ := http.NewRequest("GET", "/quarantine?quarantined=maybe", nil)
+	w := httptest.NewRecorder()
+	NewRouter().ServeHTTP(w, r)
+	suite.Equal(http.StatusBadRequest, w.Result().StatusCode)
+
+	r, _ = http.NewRequest("DELETE", "/quarantine/foo", nil)
+	w = httptest.NewRecorder()
+	NewRouter().ServeHTTP(w, r)
+	suite.Equal(http.StatusBadRequest, w.Result().StatusCode)
+
+	r, _ = http.NewRequest("DELETE", "/quarantine/x0c0s6b0", nil)
+	w = httptest.NewRecorder()
+	NewRouter().ServeHTTP(w, r)
+	suite.Equal(http.StatusNotFound, w.Result().StatusCode)
+}
+
+func Test_API_Quarantine(t *testing.T) {
+	//This setups the production routs and handler
+	CreateRouterAndHandler()
+	ConfigureSystemForUnitTesting()
+	suite.Run(t, new(Quarantine_TS))
+}
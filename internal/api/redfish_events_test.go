@@ -0,0 +1,70 @@
+/*
+ * MIT License
+ *
+ * (C) Copyright [2026] Hewlett Packard Enterprise Development LP
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a
+ * copy of this software and associated documentation files (the "Software"),
+ * to deal in the Software without restriction, including without limitation
+ * the rights to use, copy, modify, merge, publish, distribute, sublicense,
+ * and/or sell copies of the Software, and to permit persons to whom the
+ * Software is furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included
+ * in all copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL
+ * THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+ * OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+ * ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+ * OTHER DEALINGS IN THE SOFTWARE.
+ */
+
+package api
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/suite"
+)
+
+type Redfish_Events_TS struct {
+	suite.Suite
+}
+
+// TEST: Test_ReceiveRedfishEvent
+// POST /events/{operationID} on the event listener
+func (suite *Redfish_Events_TS) Test_ReceiveRedfishEvent() {
+	payload := `{"Context":"x","Events":[{"EventType":"Alert","MessageId":"Update.1.0.UpdateSuccessful"}]}`
+	r, _ := http.NewRequest("POST", "/events/"+uuid.New().String(), strings.NewReader(payload))
+	w := httptest.NewRecorder()
+	NewEventRouter().ServeHTTP(w, r)
+	suite.Equal(http.StatusNoContent, w.Result().StatusCode)
+
+	r, _ = http.NewRequest("POST", "/events/"+uuid.New().String(), strings.NewReader("{"))
+	w = httptest.NewRecorder()
+	NewEventRouter().ServeHTTP(w, r)
+	suite.Equal(http.StatusBadRequest, w.Result().StatusCode)
+
+	r, _ = http.NewRequest("POST", "/events/notauuid", strings.NewReader(payload))
+	w = httptest.NewRecorder()
+	NewEventRouter().ServeHTTP(w, r)
+	suite.Equal(http.StatusBadRequest, w.Result().StatusCode)
+
+	// only the event listener takes events
+	r, _ = http.NewRequest("POST", "/events/"+uuid.New().String(), strings.NewReader(payload))
+	w = httptest.NewRecorder()
+	NewRouter().ServeHTTP(w, r)
+	suite.NotEqual(http.StatusNoContent, w.Result().StatusCode)
+}
+
+func Test_API_Redfish_Events(t *testing.T) {
+	ConfigureSystemForUnitTesting()
+	suite.Run(t, new(Redfish_Events_TS))
+}
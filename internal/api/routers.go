@@ -65,6 +65,15 @@ func Logger(inner http.Handler, name string) http.Handler {
 
 // NewRouter - create a new mux Router; and initializes it with the routes
 func NewRouter() *mux.Router {
+	return newRouterFor(routes)
+}
+
+// NewEventRouter - create a new mux Router for the Redfish event listener, which BMCs rather than users talk to
+func NewEventRouter() *mux.Router {
+	return newRouterFor(eventRoutes)
+}
+
+func newRouterFor(routes Routes) *mux.Router {
 	router := mux.NewRouter().StrictSlash(true)
 	for _, route := range routes {
 		var handler http.Handler = route.HandlerFunc
@@ -103,6 +112,13 @@ var routes = Routes{
 		"/actions",
 		CreateAction,
 	},
+	// POST actions/preflight
+	Route{
+		"PreflightAction",
+		strings.ToUpper("post"),
+		"/actions/preflight",
+		PreflightAction,
+	},
 	// GET actions/{actionID}
 	Route{
 		"GetActionID",
@@ -124,6 +140,34 @@ var routes = Routes{
 		"/actions/{actionID}/instance",
 		AbortActionID,
 	},
+	// PUT actions/{actionID}/pause
+	Route{
+		"PauseActionID",
+		strings.ToUpper("put"),
+		"/actions/{actionID}/pause",
+		PauseActionID,
+	},
+	// PUT actions/{actionID}/resume
+	Route{
+		"ResumeActionID",
+		strings.ToUpper("put"),
+		"/actions/{actionID}/resume",
+		ResumeActionID,
+	},
+	// POST actions/{actionID}/activate
+	Route{
+		"ActivateActionID",
+		strings.ToUpper("post"),
+		"/actions/{actionID}/activate",
+		ActivateActionID,
+	},
+	// POST actions/{actionID}/retry
+	Route{
+		"RetryActionID",
+		strings.ToUpper("post"),
+		"/actions/{actionID}/retry",
+		RetryActionID,
+	},
 	// GET actions/{actionID}/operations/{operationsID}
 	Route{
 		"GetActionOperationID",
@@ -138,6 +182,27 @@ var routes = Routes{
 		"/operations/{operationID}",
 		GetOperationID,
 	},
+	// GET operations/{operationsID}/history
+	Route{
+		"GetOperationHistory",
+		strings.ToUpper("get"),
+		"/operations/{operationID}/history",
+		GetOperationHistory,
+	},
+	// PUT operations/{operationsID}/abort
+	Route{
+		"AbortOperationID",
+		strings.ToUpper("put"),
+		"/operations/{operationID}/abort",
+		AbortOperationID,
+	},
+	// PUT operations/{operationsID}/skip
+	Route{
+		"SkipOperationID",
+		strings.ToUpper("put"),
+		"/operations/{operationID}/skip",
+		SkipOperationID,
+	},
 	// GET actions/{actionID}/operations
 	Route{
 		"GetActionIDOperations",
@@ -202,6 +267,66 @@ var routes = Routes{
 		"/images/{imageID}",
 		DeleteImage,
 	},
+	Route{
+		"GetCompatibilityRules",
+		strings.ToUpper("get"),
+		"/compatibilityrules",
+		GetCompatibilityRules,
+	},
+	Route{
+		"UpdateCompatibilityRule",
+		strings.ToUpper("put"),
+		"/compatibilityrules/{ruleID}",
+		UpdateCompatibilityRule,
+	},
+	Route{
+		"CreateCompatibilityRule",
+		strings.ToUpper("post"),
+		"/compatibilityrules",
+		CreateCompatibilityRule,
+	},
+	Route{
+		"GetCompatibilityRule",
+		strings.ToUpper("get"),
+		"/compatibilityrules/{ruleID}",
+		GetCompatibilityRule,
+	},
+	Route{
+		"DeleteCompatibilityRule",
+		strings.ToUpper("delete"),
+		"/compatibilityrules/{ruleID}",
+		DeleteCompatibilityRule,
+	},
+	Route{
+		"GetExclusionPolicies",
+		strings.ToUpper("get"),
+		"/exclusionpolicies",
+		GetExclusionPolicies,
+	},
+	Route{
+		"UpdateExclusionPolicy",
+		strings.ToUpper("put"),
+		"/exclusionpolicies/{policyID}",
+		UpdateExclusionPolicy,
+	},
+	Route{
+		"CreateExclusionPolicy",
+		strings.ToUpper("post"),
+		"/exclusionpolicies",
+		CreateExclusionPolicy,
+	},
+	Route{
+		"GetExclusionPolicy",
+		strings.ToUpper("get"),
+		"/exclusionpolicies/{policyID}",
+		GetExclusionPolicy,
+	},
+	Route{
+		"DeleteExclusionPolicy",
+		strings.ToUpper("delete"),
+		"/exclusionpolicies/{policyID}",
+		DeleteExclusionPolicy,
+	},
 	Route{
 		"GetSnapshots",
 		strings.ToUpper("get"),
@@ -232,6 +357,38 @@ var routes = Routes{
 		"/snapshots/{name}",
 		DeleteSnapshot,
 	},
+	// BACKUP
+	Route{
+		"GetBackup",
+		strings.ToUpper("get"),
+		"/backup",
+		GetBackup,
+	},
+	Route{
+		"RestoreBackup",
+		strings.ToUpper("post"),
+		"/backup/restore",
+		RestoreBackup,
+	},
+	// ARCHIVE
+	Route{
+		"GetArchivedActions",
+		strings.ToUpper("get"),
+		"/archive/actions",
+		GetArchivedActions,
+	},
+	Route{
+		"GetArchivedAction",
+		strings.ToUpper("get"),
+		"/archive/actions/{actionID}",
+		GetArchivedAction,
+	},
+	Route{
+		"RestoreArchivedAction",
+		strings.ToUpper("post"),
+		"/archive/actions/{actionID}/restore",
+		RestoreArchivedAction,
+	},
 	Route{
 		"LoaderStatus",
 		strings.ToUpper("get"),
@@ -244,6 +401,18 @@ var routes = Routes{
 		"/loader/{loaderID}",
 		LoaderStatusID,
 	},
+	Route{
+		"GetQuarantine",
+		strings.ToUpper("get"),
+		"/quarantine",
+		GetQuarantine,
+	},
+	Route{
+		"ClearQuarantine",
+		strings.ToUpper("delete"),
+		"/quarantine/{xname}",
+		ClearQuarantine,
+	},
 	Route{
 		"LoaderDelete",
 		strings.ToUpper("delete"),
@@ -263,3 +432,12 @@ var routes = Routes{
 		LoaderLoadNexus,
 	},
 }
+
+var eventRoutes = Routes{
+	Route{
+		"ReceiveRedfishEvent",
+		strings.ToUpper("post"),
+		"/events/{operationID}",
+		ReceiveRedfishEvent,
+	},
+}
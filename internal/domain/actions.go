@@ -50,11 +50,18 @@ func TriggerFirmwareUpdate(params storage.ActionParameters) (pb model.Passback)
 	}
 
 	action := storage.NewAction(params)
+	pb = launchAction(action)
+	return pb
+}
+
+// launchAction - stores a new action and fires off the generation of its operations
+func launchAction(action *storage.Action) (pb model.Passback) {
+	params := action.Parameters
 
 	// GenerateOperations may find out that an action param is invalid! Like an xname doesnt exist.
 	// I am planning on allowing the action to be created, but to just no act on bad data, this is best effort!
 
-	err = StoreAction(*action)
+	err := StoreAction(*action)
 	if err == nil {
 		actionID := storage.ActionID{ActionID: action.ActionID}
 
@@ -109,8 +116,29 @@ func DeleteStoredAction(actionID uuid.UUID) (err error) {
 	return
 }
 
+// StoreInFlightOperation -> stores the copy of the operation doLaunch/doVerify work on.  An abort or skip requested
+// through the API in the meantime is kept in that copy rather than wiped out, so the control loop still acts on it.
+func StoreInFlightOperation(operation *storage.Operation) (err error) {
+	if operation.Signal == "" {
+		if stored, err := GetStoredOperation(operation.OperationID); err == nil {
+			operation.Signal = stored.Signal
+		}
+	}
+	return StoreOperation(*operation)
+}
+
 func StoreOperation(operation storage.Operation) (err error) {
+	// the history only ever grows from what is stored
+	var last *storage.OperationEvent
+	history, _ := (*GLOB.DSP).GetOperationHistory(operation.OperationID)
+	if n := len(history); n > 0 {
+		last = &history[n-1]
+	}
+	events := operation.PendingHistory(last)
 	err = (*GLOB.DSP).StoreOperation(operation)
+	if err == nil && len(events) > 0 {
+		err = (*GLOB.DSP).AppendOperationHistory(operation.OperationID, events)
+	}
 	return
 }
 
@@ -167,7 +195,8 @@ func CheckBlockage(allOperations *[]storage.Operation) {
 	for operationID, _ := range *allOperations {
 		var tmpOpState OpState
 		operation := (*allOperations)[operationID]
-		if operation.State.Is("failed") || operation.State.Is("aborted") || operation.State.Is("noOperation") || operation.State.Is("noSolution") || operation.State.Is("succeeded") {
+		//a staged image does not count as applied, so whatever depends on it cannot run until a later action
+		if operation.State.Is("failed") || operation.State.Is("aborted") || operation.State.Is("noOperation") || operation.State.Is("noSolution") || operation.State.Is("succeeded") || operation.State.Is("staged") {
 			tmpOpState = OpState{
 				Op:    &operation,
 				State: "completed",
@@ -188,6 +217,28 @@ func CheckBlockage(allOperations *[]storage.Operation) {
 
 	for opID, op := range ops {
 		if op.State == "blocked" {
+			//a prerequisite that did not succeed means this one can never be applied
+			var failed *storage.Operation
+			for _, dependency := range op.Op.DependsOn {
+				if dependencyOp, ok := ops[dependency]; ok && dependencyOp.State == "completed" && !dependencyOp.Op.State.Is("succeeded") {
+					failed = dependencyOp.Op
+					break
+				}
+			}
+			if failed != nil {
+				op.State = "completed"
+				op.Op.State.Event(context.Background(), "nosol")
+				op.Op.EndTime.Scan(time.Now())
+				if failed.Xname == op.Op.Xname && failed.Target == op.Op.Target {
+					op.Op.StateHelper = "upgrade path not completed: an earlier step " + failed.State.Current()
+				} else {
+					op.Op.StateHelper = "prerequisite not met: " + failed.Target + " " + failed.State.Current()
+				}
+				op.Op.FailureCode = storage.FailurePrerequisiteNotMet
+				StoreOperation(*op.Op)
+				ops[opID] = op
+				continue
+			}
 			var stillBlocked bool = false
 			for _, blocker := range op.Op.BlockedBy {
 				blockerOp := ops[blocker]
@@ -264,6 +315,7 @@ func GetAllActions() (pb model.Passback) {
 				break
 			}
 			summary.OperationCounts = operationCounts
+			summary.FailureCodes = presentation.ToFailureCodeCountsFromOperations(operations)
 			summaries.Actions = append(summaries.Actions, summary)
 		}
 
@@ -297,6 +349,7 @@ func GetActionStatus(actionID uuid.UUID) (pb model.Passback) {
 			return
 		}
 		summary.OperationCounts = operationCounts
+		summary.FailureCodes = presentation.ToFailureCodeCountsFromOperations(operations)
 		pb = model.BuildSuccessPassback(http.StatusOK, summary)
 	} else {
 		pb = model.BuildErrorPassback(http.StatusNotFound, err)
@@ -323,6 +376,7 @@ func GetAction(id uuid.UUID) (pb model.Passback) {
 		if err != nil {
 			logrus.WithField("error", err).Error("Could not convert from action to action summary")
 		} else {
+			actionMarshal.RetryChain = GetRetryChain(action)
 			operations, err := GetStoredOperations(action.ActionID)
 			if err != nil {
 				logrus.WithFields(logrus.Fields{"ERROR": err, "actionID": action.ActionID.String()}).Error("Could not get operations from action")
@@ -344,39 +398,50 @@ func GetAction(id uuid.UUID) (pb model.Passback) {
 // GetActionDetail - gets an action and formats it for presentation
 func GetActionDetail(id uuid.UUID) (pb model.Passback) {
 	action, err := GetStoredAction(id)
-	actionOperationsDetail := presentation.ActionOperationsDetail{}
-
-	if err == nil {
-		actionOperationsDetail, err = presentation.ToActionOperationsDetailFromAction(action)
-		if err != nil {
-			logrus.WithField("error", err).Error("Could not convert from action to action summary")
-		} else {
-			operations, err := GetStoredOperations(action.ActionID)
-			if err != nil {
-				logrus.WithFields(logrus.Fields{"ERROR": err, "actionID": action.ActionID.String()}).Error("Could not get operations from action")
-			} else {
-				var operationsPI []presentation.OperationPlusImages
-				for _, o := range operations {
-					var opi presentation.OperationPlusImages
-					opi.Operation = o
-					opi.FromImage, _ = GetStoredImage(o.FromImageID)
-					opi.ToImage, _ = GetStoredImage(o.ToImageID)
-					operationsPI = append(operationsPI, opi)
-				}
-				operationDetail, err := presentation.ToOperationDetailFromOperations(operationsPI)
-				if err != nil {
-					logrus.WithFields(logrus.Fields{"ERROR": err, "actionID": action.ActionID.String()}).Error("Could not build operation data")
-				}
-				actionOperationsDetail.OperationDetails = operationDetail
-				pb = model.BuildSuccessPassback(http.StatusOK, actionOperationsDetail)
-				return pb
-			}
-		}
+	if err != nil {
+		pb = model.BuildErrorPassback(http.StatusNotFound, err)
+		return pb
 	}
-	pb = model.BuildErrorPassback(http.StatusNotFound, err)
+	operations, err := GetStoredOperations(action.ActionID)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{"ERROR": err, "actionID": action.ActionID.String()}).Error("Could not get operations from action")
+		pb = model.BuildErrorPassback(http.StatusNotFound, err)
+		return pb
+	}
+	actionOperationsDetail, err := buildActionOperationsDetail(action, operations)
+	if err != nil {
+		pb = model.BuildErrorPassback(http.StatusNotFound, err)
+		return pb
+	}
+	actionOperationsDetail.RetryChain = GetRetryChain(action)
+	pb = model.BuildSuccessPassback(http.StatusOK, actionOperationsDetail)
 	return pb
 }
 
+// buildActionOperationsDetail - shared by live and archived actions
+func buildActionOperationsDetail(action storage.Action, operations []storage.Operation) (actionOperationsDetail presentation.ActionOperationsDetail, err error) {
+	actionOperationsDetail, err = presentation.ToActionOperationsDetailFromAction(action)
+	if err != nil {
+		logrus.WithField("error", err).Error("Could not convert from action to action summary")
+		return
+	}
+	var operationsPI []presentation.OperationPlusImages
+	for _, o := range operations {
+		var opi presentation.OperationPlusImages
+		opi.Operation = o
+		opi.FromImage, _ = GetStoredImage(o.FromImageID)
+		opi.ToImage, _ = GetStoredImage(o.ToImageID)
+		operationsPI = append(operationsPI, opi)
+	}
+	operationDetail, err := presentation.ToOperationDetailFromOperations(operationsPI)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{"ERROR": err, "actionID": action.ActionID.String()}).Error("Could not build operation data")
+	}
+	actionOperationsDetail.OperationDetails = operationDetail
+	actionOperationsDetail.PhaseDurations = SummarizePhaseDurations(operations, time.Now())
+	return actionOperationsDetail, nil
+}
+
 func GetActionState(id uuid.UUID) (action storage.Action) {
 	action, _ = GetStoredAction(id)
 	return
@@ -494,6 +559,235 @@ func AbortActionID(actionID uuid.UUID) (pb model.Passback) {
 	return pb
 }
 
+// PauseActionID - stop launching new operations of a running action; operations already launched are left to finish
+func PauseActionID(actionID uuid.UUID, reason string) (pb model.Passback) {
+	action, err := GetStoredAction(actionID)
+	if err != nil {
+		logrus.Error(err)
+		pb = model.BuildErrorPassback(http.StatusNotFound, err)
+		return pb
+	}
+
+	if action.State.Is("paused") {
+		logrus.Trace("already paused")
+		pb = model.BuildSuccessPassback(http.StatusOK, nil)
+		return pb
+	}
+	if action.State.Can("pause") == false {
+		err = errors.New("action is " + action.State.Current() + "; only running actions can be paused")
+		pb = model.BuildErrorPassback(http.StatusConflict, err)
+		return pb
+	}
+	err = action.State.Event(context.Background(), "pause")
+	if err != nil {
+		pb = model.BuildErrorPassback(http.StatusInternalServerError, err)
+		return pb
+	}
+	action.PauseReason = reason
+	err = StoreAction(action)
+	if err != nil {
+		pb = model.BuildErrorPassback(http.StatusInternalServerError, err)
+	} else {
+		pb = model.BuildSuccessPassback(http.StatusAccepted, nil)
+	}
+	return pb
+}
+
+// ResumeActionID - let a paused action launch operations again
+func ResumeActionID(actionID uuid.UUID) (pb model.Passback) {
+	action, err := GetStoredAction(actionID)
+	if err != nil {
+		logrus.Error(err)
+		pb = model.BuildErrorPassback(http.StatusNotFound, err)
+		return pb
+	}
+
+	if action.State.Is("running") {
+		logrus.Trace("already running")
+		pb = model.BuildSuccessPassback(http.StatusOK, nil)
+		return pb
+	}
+	if action.State.Can("resume") == false {
+		err = errors.New("action is " + action.State.Current() + "; only paused actions can be resumed")
+		pb = model.BuildErrorPassback(http.StatusConflict, err)
+		return pb
+	}
+	err = action.State.Event(context.Background(), "resume")
+	if err != nil {
+		pb = model.BuildErrorPassback(http.StatusInternalServerError, err)
+		return pb
+	}
+	action.PauseReason = ""
+	err = StoreAction(action)
+	if err != nil {
+		pb = model.BuildErrorPassback(http.StatusInternalServerError, err)
+	} else {
+		pb = model.BuildSuccessPassback(http.StatusAccepted, nil)
+	}
+	return pb
+}
+
+// AbortOperationID - ask the control loop to stop a single operation; the rest of the action carries on
+func AbortOperationID(operationID uuid.UUID) (pb model.Passback) {
+	return signalOperation(operationID, storage.OperationSignalAbort)
+}
+
+// SkipOperationID - ask the control loop to leave an operation that has not been launched yet alone
+func SkipOperationID(operationID uuid.UUID) (pb model.Passback) {
+	return signalOperation(operationID, storage.OperationSignalSkip)
+}
+
+func signalOperation(operationID uuid.UUID, signal string) (pb model.Passback) {
+	operation, err := GetStoredOperation(operationID)
+	if err != nil {
+		logrus.Error(err)
+		pb = model.BuildErrorPassback(http.StatusNotFound, err)
+		return pb
+	}
+
+	//if it cannot abort; it must be finished
+	if operation.State.Can("abort") == false {
+		logrus.Trace("already complete")
+		pb = model.BuildSuccessPassback(http.StatusOK, nil)
+		return pb
+	}
+	if signal == storage.OperationSignalSkip && operation.State.Can("skip") == false {
+		err = errors.New("operation is " + operation.State.Current() + "; it has already been launched and can only be aborted")
+		pb = model.BuildErrorPassback(http.StatusConflict, err)
+		return pb
+	}
+
+	operation.Signal = signal
+	err = StoreOperation(operation)
+	if err != nil {
+		pb = model.BuildErrorPassback(http.StatusInternalServerError, err)
+	} else {
+		pb = model.BuildSuccessPassback(http.StatusAccepted, nil)
+	}
+	return pb
+}
+
+// RetryableOperationStates - the operation states a retry action may be built from
+var RetryableOperationStates = []string{"failed", "aborted", "noSolution"}
+
+// RetryActionID - creates a new action from the parameters of a finished one, restricted to the xname/targets of its
+// operations that ended in one of the selected states.  The new action points at its parent and the parent lists it.
+func RetryActionID(actionID uuid.UUID, states []string) (pb model.Passback) {
+	if len(states) == 0 {
+		states = RetryableOperationStates
+	}
+	for _, state := range states {
+		if _, found := model.Find(RetryableOperationStates, state); !found {
+			err := errors.New("invalid state '" + state + "'; must be one of failed, aborted, noSolution")
+			pb = model.BuildErrorPassback(http.StatusBadRequest, err)
+			return
+		}
+	}
+
+	parent, err := GetStoredAction(actionID)
+	if err != nil {
+		pb = model.BuildErrorPassback(http.StatusNotFound, err)
+		return
+	}
+	if !parent.State.Is("completed") && !parent.State.Is("aborted") {
+		err = errors.New("action is " + parent.State.Current() + "; only completed or aborted actions can be retried")
+		pb = model.BuildErrorPassback(http.StatusConflict, err)
+		return
+	}
+
+	operations, err := GetStoredOperations(actionID)
+	if err != nil {
+		pb = model.BuildErrorPassback(http.StatusInternalServerError, err)
+		return
+	}
+
+	params := parent.Parameters
+	params.StateComponentFilter = storage.StateComponentFilter{}
+	params.TargetFilter = storage.TargetFilter{}
+	params.XnameTargetFilter = storage.XnameTargetFilter{}
+	for _, op := range operations {
+		if op.State == nil {
+			continue
+		}
+		if _, found := model.Find(states, op.State.Current()); !found {
+			continue
+		}
+		if params.XnameTargetFilter.Contains(op.Xname, op.Target) {
+			continue
+		}
+		params.XnameTargetFilter.XnameTargets = append(params.XnameTargetFilter.XnameTargets,
+			storage.XnameTarget{Xname: op.Xname, Target: op.Target})
+		if _, found := model.Find(params.StateComponentFilter.Xnames, op.Xname); !found {
+			params.StateComponentFilter.Xnames = append(params.StateComponentFilter.Xnames, op.Xname)
+		}
+		if _, found := model.Find(params.TargetFilter.Targets, op.Target); !found {
+			params.TargetFilter.Targets = append(params.TargetFilter.Targets, op.Target)
+		}
+	}
+	if params.XnameTargetFilter.Empty() {
+		err = errors.New("no operations to retry in the selected states")
+		pb = model.BuildErrorPassback(http.StatusBadRequest, err)
+		return
+	}
+
+	err = ValidateActionParameters(&params)
+	if err != nil {
+		pb = model.BuildErrorPassback(http.StatusBadRequest, err)
+		return
+	}
+
+	action := storage.NewAction(params)
+	action.ParentActionID = parent.ActionID
+	pb = launchAction(action)
+	if pb.IsError || pb.StatusCode >= 400 {
+		return
+	}
+
+	parent.RetryActionIDs = append(parent.RetryActionIDs, action.ActionID)
+	err = StoreAction(parent)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{"ERROR": err, "actionID": parent.ActionID.String()}).Error("Could not link retry action to parent")
+	}
+	return pb
+}
+
+// GetRetryChain - every action in the retry tree the given action belongs to, starting from the original action.
+// Children are listed in the order they were created.
+func GetRetryChain(action storage.Action) (chain []uuid.UUID) {
+	if action.ParentActionID == uuid.Nil && len(action.RetryActionIDs) == 0 {
+		return
+	}
+	root := action
+	seen := map[uuid.UUID]bool{root.ActionID: true}
+	for root.ParentActionID != uuid.Nil && !seen[root.ParentActionID] {
+		parent, err := GetStoredAction(root.ParentActionID)
+		if err != nil {
+			break
+		}
+		seen[parent.ActionID] = true
+		root = parent
+	}
+
+	chain = []uuid.UUID{root.ActionID}
+	queue := root.RetryActionIDs
+	visited := map[uuid.UUID]bool{root.ActionID: true}
+	for len(queue) > 0 {
+		id := queue[0]
+		queue = queue[1:]
+		if visited[id] {
+			continue
+		}
+		visited[id] = true
+		chain = append(chain, id)
+		child, err := GetStoredAction(id)
+		if err != nil {
+			continue
+		}
+		queue = append(queue, child.RetryActionIDs...)
+	}
+	return
+}
+
 func DeleteExpiredActions(daysToKeep int) {
 	if daysToKeep <= 0 {
 		return
@@ -507,7 +801,21 @@ func DeleteExpiredActions(daysToKeep int) {
 
 	for _, action := range actions {
 		if action.EndTime.Valid {
-			if action.State.Is("completed") && action.EndTime.Time.Before(time.Now().AddDate(0, 0, -daysToKeep)) {
+			// a restored action gets a fresh retention period
+			keepFrom := action.EndTime.Time
+			if action.RestoredTime.Valid && action.RestoredTime.Time.After(keepFrom) {
+				keepFrom = action.RestoredTime.Time
+			}
+			if action.State.Is("completed") && keepFrom.Before(time.Now().AddDate(0, 0, -daysToKeep)) {
+				// staged images that were never activated still need the action to activate them
+				if hasUnactivatedStagedOperations(action) {
+					logrus.WithField("actionID", action.ActionID.String()).Info("Keeping expired action, it has staged operations that are not activated")
+					continue
+				}
+				if err := ArchiveAction(action); err != nil {
+					logrus.WithFields(logrus.Fields{"ERROR": err, "actionID": action.ActionID.String()}).Error("Could not archive action, not deleting")
+					continue
+				}
 				logrus.Info("Deleting Action: ", action.ActionID)
 				pb := DeleteAction(action.ActionID)
 				if pb.IsError {
@@ -519,6 +827,24 @@ func DeleteExpiredActions(daysToKeep int) {
 	return
 }
 
+// hasUnactivatedStagedOperations -> true if the action was never activated and still has staged operations
+func hasUnactivatedStagedOperations(action storage.Action) bool {
+	if action.Activation != nil {
+		return false
+	}
+	operations, err := GetStoredOperations(action.ActionID)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{"ERROR": err, "actionID": action.ActionID.String()}).Error("Could not get operations, keeping action")
+		return true
+	}
+	for _, operation := range operations {
+		if operation.State.Is("staged") {
+			return true
+		}
+	}
+	return false
+}
+
 //// AbortOperation - halt a running operation
 //func AbortOperation(operationID uuid.UUID) (err error) {
 //	operation, err := (*GLOB.DSP).GetOperation(operationID)
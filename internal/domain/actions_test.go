@@ -76,6 +76,179 @@ func (suite *Actions_TS) Test_TriggerFirmwareUpdate() {
 	suite.True(err == nil)
 }
 
+func (suite *Actions_TS) Test_PauseResumeActionID() {
+	action := storage.HelperGetStockAction()
+	action.State.SetState("running")
+	suite.True(StoreAction(action) == nil)
+
+	pb := ResumeActionID(action.ActionID)
+	suite.False(pb.IsError)
+	suite.Equal(http.StatusOK, pb.StatusCode)
+
+	pb = PauseActionID(action.ActionID, "waiting on a maintenance window")
+	suite.False(pb.IsError)
+	suite.Equal(http.StatusAccepted, pb.StatusCode)
+	aRet, err := GetStoredAction(action.ActionID)
+	suite.True(err == nil)
+	suite.True(aRet.State.Is("paused"))
+	suite.Equal("waiting on a maintenance window", aRet.PauseReason)
+
+	pb = GetAction(action.ActionID)
+	suite.False(pb.IsError)
+	suite.Equal("paused", pb.Obj.(presentation.ActionMarshaled).State)
+	suite.Equal("waiting on a maintenance window", pb.Obj.(presentation.ActionMarshaled).PauseReason)
+
+	pb = PauseActionID(action.ActionID, "")
+	suite.Equal(http.StatusOK, pb.StatusCode)
+
+	pb = ResumeActionID(action.ActionID)
+	suite.False(pb.IsError)
+	suite.Equal(http.StatusAccepted, pb.StatusCode)
+	aRet, err = GetStoredAction(action.ActionID)
+	suite.True(err == nil)
+	suite.True(aRet.State.Is("running"))
+	suite.Equal("", aRet.PauseReason)
+
+	aRet.State.SetState("completed")
+	suite.True(StoreAction(aRet) == nil)
+	pb = PauseActionID(action.ActionID, "")
+	suite.True(pb.IsError)
+	suite.Equal(http.StatusConflict, pb.StatusCode)
+	pb = ResumeActionID(action.ActionID)
+	suite.True(pb.IsError)
+	suite.Equal(http.StatusConflict, pb.StatusCode)
+
+	pb = PauseActionID(uuid.New(), "")
+	suite.Equal(http.StatusNotFound, pb.StatusCode)
+
+	suite.False(DeleteAction(action.ActionID).IsError)
+}
+
+func (suite *Actions_TS) Test_AbortSkipOperationID() {
+	operation := storage.HelperGetStockOperation()
+	operation.State.SetState("configured")
+	suite.True(StoreOperation(operation) == nil)
+
+	pb := SkipOperationID(operation.OperationID)
+	suite.False(pb.IsError)
+	suite.Equal(http.StatusAccepted, pb.StatusCode)
+	oRet, err := GetStoredOperation(operation.OperationID)
+	suite.True(err == nil)
+	suite.Equal(storage.OperationSignalSkip, oRet.Signal)
+
+	//a store from doLaunch/doVerify holding an older copy must not drop the signal
+	operation.StateHelper = "stale copy"
+	suite.True(StoreInFlightOperation(&operation) == nil)
+	suite.Equal(storage.OperationSignalSkip, operation.Signal)
+	oRet, err = GetStoredOperation(operation.OperationID)
+	suite.True(err == nil)
+	suite.Equal(storage.OperationSignalSkip, oRet.Signal)
+
+	//any other store, e.g. a restore, is written as it is
+	operation.Signal = ""
+	suite.True(StoreOperation(operation) == nil)
+	oRet, err = GetStoredOperation(operation.OperationID)
+	suite.True(err == nil)
+	suite.Equal("", oRet.Signal)
+
+	launched := storage.HelperGetStockOperation()
+	launched.State.SetState("inProgress")
+	suite.True(StoreOperation(launched) == nil)
+	pb = SkipOperationID(launched.OperationID)
+	suite.True(pb.IsError)
+	suite.Equal(http.StatusConflict, pb.StatusCode)
+
+	pb = AbortOperationID(launched.OperationID)
+	suite.False(pb.IsError)
+	suite.Equal(http.StatusAccepted, pb.StatusCode)
+	oRet, err = GetStoredOperation(launched.OperationID)
+	suite.True(err == nil)
+	suite.Equal(storage.OperationSignalAbort, oRet.Signal)
+
+	oRet.State.SetState("succeeded")
+	suite.True(StoreOperation(oRet) == nil)
+	pb = AbortOperationID(launched.OperationID)
+	suite.False(pb.IsError)
+	suite.Equal(http.StatusOK, pb.StatusCode)
+
+	pb = AbortOperationID(uuid.New())
+	suite.True(pb.IsError)
+	suite.Equal(http.StatusNotFound, pb.StatusCode)
+}
+
+func (suite *Actions_TS) Test_RetryActionID() {
+	parent := storage.HelperGetStockAction()
+	parent.State.SetState("completed")
+	states := map[string]string{"x9c0s1b0": "failed", "x9c0s2b0": "succeeded", "x9c0s3b0": "noSolution"}
+	for xname, state := range states {
+		operation := storage.HelperGetStockOperation()
+		operation.ActionID = parent.ActionID
+		operation.Xname = xname
+		operation.Target = "BMC"
+		operation.State.SetState(state)
+		parent.OperationIDs = append(parent.OperationIDs, operation.OperationID)
+		suite.True(StoreOperation(operation) == nil)
+	}
+	suite.True(StoreAction(parent) == nil)
+
+	pb := RetryActionID(parent.ActionID, []string{"failed"})
+	suite.False(pb.IsError)
+	suite.Equal(http.StatusAccepted, pb.StatusCode)
+	childID := pb.Obj.(presentation.CreateActionPayload).ActionID
+
+	child, err := GetStoredAction(childID)
+	suite.True(err == nil)
+	suite.Equal(parent.ActionID, child.ParentActionID)
+	suite.Equal([]storage.XnameTarget{{Xname: "x9c0s1b0", Target: "BMC"}}, child.Parameters.XnameTargetFilter.XnameTargets)
+	suite.Equal([]string{"x9c0s1b0"}, child.Parameters.StateComponentFilter.Xnames)
+
+	parentRet, err := GetStoredAction(parent.ActionID)
+	suite.True(err == nil)
+	suite.Equal([]uuid.UUID{childID}, parentRet.RetryActionIDs)
+
+	suite.Equal([]uuid.UUID{parent.ActionID, childID}, GetRetryChain(child))
+	suite.Equal([]uuid.UUID{parent.ActionID, childID}, GetRetryChain(parentRet))
+
+	//wait for the operations of the retry to be generated before cleaning up
+	for i := 0; i < 10; i++ {
+		child, err = GetStoredAction(childID)
+		if err != nil || !child.State.Is("new") {
+			break
+		}
+		time.Sleep(time.Second)
+	}
+	child.State.SetState("completed")
+	suite.True(StoreAction(child) == nil)
+	suite.False(DeleteAction(childID).IsError)
+	suite.False(DeleteAction(parent.ActionID).IsError)
+}
+
+func (suite *Actions_TS) Test_RetryActionID_Errors() {
+	pb := RetryActionID(uuid.New(), nil)
+	suite.True(pb.IsError)
+	suite.Equal(http.StatusNotFound, pb.StatusCode)
+
+	action := storage.HelperGetStockAction()
+	action.State.SetState("running")
+	suite.True(StoreAction(action) == nil)
+	pb = RetryActionID(action.ActionID, nil)
+	suite.True(pb.IsError)
+	suite.Equal(http.StatusConflict, pb.StatusCode)
+
+	pb = RetryActionID(action.ActionID, []string{"succeeded"})
+	suite.True(pb.IsError)
+	suite.Equal(http.StatusBadRequest, pb.StatusCode)
+
+	//nothing failed, nothing to retry
+	action.State.SetState("completed")
+	suite.True(StoreAction(action) == nil)
+	pb = RetryActionID(action.ActionID, nil)
+	suite.True(pb.IsError)
+	suite.Equal(http.StatusBadRequest, pb.StatusCode)
+
+	suite.False(DeleteAction(action.ActionID).IsError)
+}
+
 func (suite *Actions_TS) Test_GetAllActions_Simple() {
 	pb := GetAllActions()
 	suite.False(pb.IsError)
@@ -0,0 +1,183 @@
+/*
+ * MIT License
+ *
+ * (C) Copyright [2026] Hewlett Packard Enterprise Development LP
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a
+ * copy of this software and associated documentation files (the "Software"),
+ * to deal in the Software without restriction, including without limitation
+ * the rights to use, copy, modify, merge, publish, distribute, sublicense,
+ * and/or sell copies of the Software, and to permit persons to whom the
+ * Software is furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included
+ * in all copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL
+ * THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+ * OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+ * ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+ * OTHER DEALINGS IN THE SOFTWARE.
+ */
+
+package domain
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"net/http"
+	"sort"
+
+	"github.com/Cray-HPE/hms-firmware-action/internal/model"
+	"github.com/Cray-HPE/hms-firmware-action/internal/presentation"
+	"github.com/Cray-HPE/hms-firmware-action/internal/storage"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+)
+
+// DefaultActivationResetType -> the reset that applies a staged image when neither the activation nor the image names one
+const DefaultActivationResetType = "ForceRestart"
+
+// ActivateActionID - reset in the images a staging action left on its devices, a batch of devices at a time.  The
+// action runs again; it completes once every staged operation is verified.
+func ActivateActionID(actionID uuid.UUID, parameters presentation.ActivateActionParameters) (pb model.Passback) {
+	action, err := GetStoredAction(actionID)
+	if err != nil {
+		logrus.Error(err)
+		pb = model.BuildErrorPassback(http.StatusNotFound, err)
+		return pb
+	}
+
+	if parameters.BatchSize < 0 {
+		err = errors.New("batchSize cannot be negative")
+		pb = model.BuildErrorPassback(http.StatusBadRequest, err)
+		return pb
+	}
+	if parameters.BatchSize == 0 {
+		parameters.BatchSize = 1
+	}
+	if !action.Command.Stages() {
+		err = errors.New("action did not stage its images; applyTime is " + action.Command.ApplyTime)
+		pb = model.BuildErrorPassback(http.StatusBadRequest, err)
+		return pb
+	}
+	if action.State.Can("activate") == false {
+		err = errors.New("action is " + action.State.Current() + "; only completed actions can be activated")
+		pb = model.BuildErrorPassback(http.StatusConflict, err)
+		return pb
+	}
+	if GetOperationSummaryFromAction(actionID).Staged == 0 {
+		err = errors.New("action has no staged operations")
+		pb = model.BuildErrorPassback(http.StatusConflict, err)
+		return pb
+	}
+
+	err = action.State.Event(context.Background(), "activate")
+	if err != nil {
+		pb = model.BuildErrorPassback(http.StatusInternalServerError, err)
+		return pb
+	}
+	action.Activation = &storage.Activation{
+		BatchSize: parameters.BatchSize,
+		ResetType: parameters.ResetType,
+	}
+	action.EndTime = sql.NullTime{}
+	err = StoreAction(action)
+	if err != nil {
+		pb = model.BuildErrorPassback(http.StatusInternalServerError, err)
+	} else {
+		pb = model.BuildSuccessPassback(http.StatusAccepted, nil)
+	}
+	return pb
+}
+
+// ActivateStagedOperations -> hands staged operations of an activating action to doVerify, keeping at most a batch of
+// devices in flight.  A device is reset once: one of its operations carries the reset, the others are verified after
+// it is done.
+func ActivateStagedOperations(action storage.Action) {
+	if action.Activation == nil {
+		return
+	}
+	operations, err := GetStoredOperations(action.ActionID)
+	if err != nil {
+		logrus.WithFields(logrus.Fields{"ERROR": err, "actionID": action.ActionID}).Error("Could not get operations from action")
+		return
+	}
+
+	inFlight := make(map[string]bool)
+	resetBy := make(map[string]storage.Operation) //xname -> the operation that carries the reset of the device
+	staged := make(map[string][]storage.Operation)
+	var xnames []string
+	for _, operation := range operations {
+		if operation.State.Is("needsVerified") || operation.State.Is("verifying") {
+			inFlight[operation.Xname] = true
+		} else if operation.State.Is("staged") && operation.Signal == "" {
+			if len(staged[operation.Xname]) == 0 {
+				xnames = append(xnames, operation.Xname)
+			}
+			staged[operation.Xname] = append(staged[operation.Xname], operation)
+		}
+		if operation.ResetType != "" {
+			resetBy[operation.Xname] = operation
+		}
+	}
+	sort.Strings(xnames)
+
+	//the other targets of a device that was reset already only need verifying
+	var waiting []string
+	for _, xname := range xnames {
+		lead, ok := resetBy[xname]
+		if !ok {
+			waiting = append(waiting, xname)
+			continue
+		}
+		if lead.State.Is("needsVerified") || lead.State.Is("verifying") {
+			continue
+		}
+		for _, operation := range staged[xname] {
+			operation.ResetBy = lead.OperationID
+			if activateStagedOperation(operation, "verifying staged image after the reset by operation "+lead.OperationID.String()) {
+				inFlight[xname] = true
+			}
+		}
+	}
+
+	launchable := LaunchableOperations(action.Parameters.LaunchPolicies, operations, "staged")
+	for _, xname := range waiting {
+		if len(inFlight) >= action.Activation.BatchSize {
+			return
+		}
+		for _, operation := range staged[xname] {
+			if !launchable[operation.OperationID] {
+				continue
+			}
+			operation.ResetType = action.Activation.ResetType
+			if operation.ResetType == "" {
+				if image, err := GetStoredImage(operation.ToImageID); err == nil {
+					operation.ResetType = image.ForceResetType
+				}
+			}
+			if operation.ResetType == "" {
+				operation.ResetType = DefaultActivationResetType
+			}
+			if activateStagedOperation(operation, "activating staged image") {
+				inFlight[xname] = true
+				break
+			}
+		}
+	}
+}
+
+func activateStagedOperation(operation storage.Operation, stateHelper string) bool {
+	err := operation.State.Event(context.Background(), "activate")
+	if err != nil {
+		logrus.WithFields(logrus.Fields{"ERROR": err, "operationID": operation.OperationID}).Error("Could not activate operation")
+		return false
+	}
+	operation.StateHelper = stateHelper
+	StoreOperation(operation)
+	return true
+}
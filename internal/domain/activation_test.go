@@ -0,0 +1,210 @@
+/*
+ * MIT License
+ *
+ * (C) Copyright [2026] Hewlett Packard Enterprise Development LP
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a
+ * copy of this software and associated documentation files (the "Software"),
+ * to deal in the Software without restriction, including without limitation
+ * the rights to use, copy, modify, merge, publish, distribute, sublicense,
+ * and/or sell copies of the Software, and to permit persons to whom the
+ * Software is furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included
+ * in all copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL
+ * THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+ * OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+ * ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+ * OTHER DEALINGS IN THE SOFTWARE.
+ */
+
+package domain
+
+import (
+	"net/http"
+	"strconv"
+	"testing"
+
+	"github.com/Cray-HPE/hms-firmware-action/internal/presentation"
+	"github.com/Cray-HPE/hms-firmware-action/internal/storage"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/suite"
+)
+
+type Activation_TS struct {
+	suite.Suite
+}
+
+// stagedAction -> a completed staging action with count staged operations updating to image, one device each
+func (suite *Activation_TS) stagedAction(count int, image storage.Image) (action storage.Action, operations []storage.Operation) {
+	action = storage.HelperGetStockAction()
+	action.Command.ApplyTime = storage.ApplyTimeOnReset
+	action.State.SetState("completed")
+	for i := 0; i < count; i++ {
+		operation := storage.HelperGetStockOperation()
+		operation.ActionID = action.ActionID
+		operation.Xname = "x0c0s" + strconv.Itoa(i) + "b0"
+		operation.Target = "BMC"
+		operation.ToImageID = image.ImageID
+		operation.State.SetState("staged")
+		suite.True(StoreOperation(operation) == nil)
+		action.OperationIDs = append(action.OperationIDs, operation.OperationID)
+		operations = append(operations, operation)
+	}
+	suite.True(StoreAction(action) == nil)
+	return
+}
+
+func (suite *Activation_TS) Test_ActivateActionID() {
+	image := storage.HelperGetStockImage()
+	image.ForceResetType = "GracefulRestart"
+	suite.True(StoreImage(image) == nil)
+	action, operations := suite.stagedAction(3, image)
+
+	pb := ActivateActionID(action.ActionID, presentation.ActivateActionParameters{BatchSize: 2})
+	suite.False(pb.IsError)
+	suite.Equal(http.StatusAccepted, pb.StatusCode)
+	aRet, err := GetStoredAction(action.ActionID)
+	suite.True(err == nil)
+	suite.True(aRet.State.Is("configured"))
+	suite.Equal(2, aRet.Activation.BatchSize)
+	suite.False(aRet.EndTime.Valid)
+
+	ActivateStagedOperations(aRet)
+	counts := GetOperationSummaryFromAction(action.ActionID)
+	suite.Equal(2, counts.NeedsVerified)
+	suite.Equal(1, counts.Staged)
+	for _, operation := range operations {
+		oRet, err := GetStoredOperation(operation.OperationID)
+		suite.True(err == nil)
+		if oRet.State.Is("needsVerified") {
+			suite.Equal("GracefulRestart", oRet.ResetType)
+		}
+	}
+
+	// the batch is full until one of them is verified
+	ActivateStagedOperations(aRet)
+	suite.Equal(1, GetOperationSummaryFromAction(action.ActionID).Staged)
+
+	// only completed actions can be activated
+	pb = ActivateActionID(action.ActionID, presentation.ActivateActionParameters{})
+	suite.Equal(http.StatusConflict, pb.StatusCode)
+
+	for _, operation := range operations {
+		_ = DeleteStoredOperation(operation.OperationID)
+	}
+	_ = DeleteStoredAction(action.ActionID)
+	_ = DeleteStoredImage(image.ImageID)
+}
+
+func (suite *Activation_TS) Test_ActivateActionID_OneResetPerDevice() {
+	image := storage.HelperGetStockImage()
+	suite.True(StoreImage(image) == nil)
+	action, operations := suite.stagedAction(2, image)
+	//a second target on the first device
+	bios := storage.HelperGetStockOperation()
+	bios.ActionID = action.ActionID
+	bios.Xname = operations[0].Xname
+	bios.Target = "BIOS"
+	bios.ToImageID = image.ImageID
+	bios.State.SetState("staged")
+	suite.True(StoreOperation(bios) == nil)
+	action.OperationIDs = append(action.OperationIDs, bios.OperationID)
+	suite.True(StoreAction(action) == nil)
+	operations = append(operations, bios)
+
+	pb := ActivateActionID(action.ActionID, presentation.ActivateActionParameters{BatchSize: 1})
+	suite.False(pb.IsError)
+	aRet, _ := GetStoredAction(action.ActionID)
+
+	// one device, reset once
+	ActivateStagedOperations(aRet)
+	suite.Equal(1, GetOperationSummaryFromAction(action.ActionID).NeedsVerified)
+	var lead storage.Operation
+	for _, operation := range operations {
+		oRet, _ := GetStoredOperation(operation.OperationID)
+		if oRet.State.Is("needsVerified") {
+			lead = oRet
+		}
+	}
+	suite.Equal(operations[0].Xname, lead.Xname)
+	suite.Equal(DefaultActivationResetType, lead.ResetType)
+
+	// the other target waits for the reset
+	ActivateStagedOperations(aRet)
+	suite.Equal(2, GetOperationSummaryFromAction(action.ActionID).Staged)
+
+	lead.State.SetState("succeeded")
+	suite.True(StoreOperation(lead) == nil)
+	ActivateStagedOperations(aRet)
+	counts := GetOperationSummaryFromAction(action.ActionID)
+	suite.Equal(1, counts.NeedsVerified)
+	suite.Equal(1, counts.Staged)
+	for _, operation := range operations {
+		oRet, _ := GetStoredOperation(operation.OperationID)
+		if oRet.State.Is("needsVerified") {
+			suite.Equal(lead.Xname, oRet.Xname)
+			suite.Equal("", oRet.ResetType)
+			suite.Equal(lead.OperationID, oRet.ResetBy)
+		}
+	}
+
+	for _, operation := range operations {
+		_ = DeleteStoredOperation(operation.OperationID)
+	}
+	_ = DeleteStoredAction(action.ActionID)
+	_ = DeleteStoredImage(image.ImageID)
+}
+
+func (suite *Activation_TS) Test_ActivateActionID_DefaultResetType() {
+	image := storage.HelperGetStockImage()
+	image.ForceResetType = ""
+	suite.True(StoreImage(image) == nil)
+	action, operations := suite.stagedAction(1, image)
+
+	pb := ActivateActionID(action.ActionID, presentation.ActivateActionParameters{})
+	suite.False(pb.IsError)
+	aRet, _ := GetStoredAction(action.ActionID)
+	suite.Equal(1, aRet.Activation.BatchSize)
+	ActivateStagedOperations(aRet)
+	oRet, err := GetStoredOperation(operations[0].OperationID)
+	suite.True(err == nil)
+	suite.True(oRet.State.Is("needsVerified"))
+	suite.Equal(DefaultActivationResetType, oRet.ResetType)
+
+	_ = DeleteStoredOperation(operations[0].OperationID)
+	_ = DeleteStoredAction(action.ActionID)
+	_ = DeleteStoredImage(image.ImageID)
+}
+
+func (suite *Activation_TS) Test_ActivateActionID_Rejected() {
+	pb := ActivateActionID(uuid.New(), presentation.ActivateActionParameters{})
+	suite.Equal(http.StatusNotFound, pb.StatusCode)
+
+	image := storage.HelperGetStockImage()
+	action, operations := suite.stagedAction(1, image)
+	pb = ActivateActionID(action.ActionID, presentation.ActivateActionParameters{BatchSize: -1})
+	suite.Equal(http.StatusBadRequest, pb.StatusCode)
+
+	action.Command.ApplyTime = storage.ApplyTimeImmediate
+	suite.True(StoreAction(action) == nil)
+	pb = ActivateActionID(action.ActionID, presentation.ActivateActionParameters{})
+	suite.Equal(http.StatusBadRequest, pb.StatusCode)
+
+	action.Command.ApplyTime = storage.ApplyTimeAtMaintenanceWindowStart
+	suite.True(StoreAction(action) == nil)
+	_ = DeleteStoredOperation(operations[0].OperationID)
+	pb = ActivateActionID(action.ActionID, presentation.ActivateActionParameters{})
+	suite.Equal(http.StatusConflict, pb.StatusCode)
+
+	_ = DeleteStoredAction(action.ActionID)
+}
+
+func Test_Domain_Activation(t *testing.T) {
+	ConfigureSystemForUnitTesting()
+	suite.Run(t, new(Activation_TS))
+}
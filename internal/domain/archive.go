@@ -0,0 +1,157 @@
+/*
+ * MIT License
+ *
+ * (C) Copyright [2026] Hewlett Packard Enterprise Development LP
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a
+ * copy of this software and associated documentation files (the "Software"),
+ * to deal in the Software without restriction, including without limitation
+ * the rights to use, copy, modify, merge, publish, distribute, sublicense,
+ * and/or sell copies of the Software, and to permit persons to whom the
+ * Software is furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included
+ * in all copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL
+ * THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+ * OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+ * ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+ * OTHER DEALINGS IN THE SOFTWARE.
+ */
+
+package domain
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/Cray-HPE/hms-firmware-action/internal/model"
+	"github.com/Cray-HPE/hms-firmware-action/internal/presentation"
+	"github.com/Cray-HPE/hms-firmware-action/internal/storage"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+)
+
+var errArchiveNotConfigured = errors.New("no archive is configured")
+
+func archiveEnabled() bool {
+	return GLOB.ASP != nil && *GLOB.ASP != nil
+}
+
+// ArchiveAction - writes the action and all of its operations to the archive.
+// A no-op if archiving is disabled.
+func ArchiveAction(action storage.Action) (err error) {
+	if !archiveEnabled() {
+		return nil
+	}
+	operations, err := GetStoredOperations(action.ActionID)
+	if err != nil {
+		return err
+	}
+	err = (*GLOB.ASP).ArchiveAction(storage.ToArchivedAction(action, operations))
+	return err
+}
+
+// ArchiveSnapshot - writes the snapshot, including its devices, to the archive.
+// A no-op if archiving is disabled.
+func ArchiveSnapshot(name string) (err error) {
+	if !archiveEnabled() {
+		return nil
+	}
+	snapshot, err := GetStoredSnapshot(name)
+	if err != nil {
+		return err
+	}
+	err = (*GLOB.ASP).ArchiveSnapshot(storage.ToArchivedSnapshot(snapshot))
+	return err
+}
+
+// GetArchivedActions - searches the archive, returning action summaries
+func GetArchivedActions(filter storage.ArchiveFilter) (pb model.Passback) {
+	if !archiveEnabled() {
+		pb = model.BuildErrorPassback(http.StatusServiceUnavailable, errArchiveNotConfigured)
+		return
+	}
+	recs, err := (*GLOB.ASP).GetArchivedActions(filter)
+	if err != nil {
+		logrus.Error(err)
+		pb = model.BuildErrorPassback(http.StatusInternalServerError, err)
+		return
+	}
+
+	summaries := presentation.ArchivedActionSummaries{Actions: []presentation.ArchivedActionSummary{}}
+	for _, rec := range recs {
+		summary, err := presentation.ToArchivedActionSummary(rec)
+		if err != nil {
+			logrus.WithFields(logrus.Fields{"ERROR": err, "actionID": rec.Action.ActionID.String()}).Error("Could not convert archived action")
+			continue
+		}
+		summaries.Actions = append(summaries.Actions, summary)
+	}
+	pb = model.BuildSuccessPassback(http.StatusOK, summaries)
+	return
+}
+
+// GetArchivedAction - gets an archived action with its operation details, without restoring it
+func GetArchivedAction(actionID uuid.UUID) (pb model.Passback) {
+	if !archiveEnabled() {
+		pb = model.BuildErrorPassback(http.StatusServiceUnavailable, errArchiveNotConfigured)
+		return
+	}
+	rec, err := (*GLOB.ASP).GetArchivedAction(actionID)
+	if err != nil {
+		pb = model.BuildErrorPassback(http.StatusNotFound, err)
+		return
+	}
+	action, operations := storage.ToActionFromArchived(rec)
+	detail := presentation.ArchivedActionDetail{ArchiveTime: rec.ArchiveTime.String()}
+	detail.ActionOperationsDetail, err = buildActionOperationsDetail(action, operations)
+	if err != nil {
+		pb = model.BuildErrorPassback(http.StatusInternalServerError, err)
+		return
+	}
+	pb = model.BuildSuccessPassback(http.StatusOK, detail)
+	return
+}
+
+// RestoreArchivedAction - puts an archived action and its operations back into
+// the live store. The restored action is kept for another retention period.
+func RestoreArchivedAction(actionID uuid.UUID) (pb model.Passback) {
+	if !archiveEnabled() {
+		pb = model.BuildErrorPassback(http.StatusServiceUnavailable, errArchiveNotConfigured)
+		return
+	}
+	if _, err := GetStoredAction(actionID); err == nil {
+		err = errors.New("action already exists")
+		pb = model.BuildErrorPassback(http.StatusConflict, err)
+		return
+	}
+	rec, err := (*GLOB.ASP).GetArchivedAction(actionID)
+	if err != nil {
+		pb = model.BuildErrorPassback(http.StatusNotFound, err)
+		return
+	}
+
+	action, operations := storage.ToActionFromArchived(rec)
+	for _, operation := range operations {
+		err = StoreOperation(operation)
+		if err != nil {
+			logrus.Error(err)
+			pb = model.BuildErrorPassback(http.StatusInternalServerError, err)
+			return
+		}
+	}
+	action.RestoredTime.Scan(time.Now())
+	err = StoreAction(action)
+	if err != nil {
+		logrus.Error(err)
+		pb = model.BuildErrorPassback(http.StatusInternalServerError, err)
+		return
+	}
+	pb = model.BuildSuccessPassback(http.StatusOK, action.ActionID)
+	return
+}
@@ -0,0 +1,159 @@
+/*
+ * MIT License
+ *
+ * (C) Copyright [2026] Hewlett Packard Enterprise Development LP
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a
+ * copy of this software and associated documentation files (the "Software"),
+ * to deal in the Software without restriction, including without limitation
+ * the rights to use, copy, modify, merge, publish, distribute, sublicense,
+ * and/or sell copies of the Software, and to permit persons to whom the
+ * Software is furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included
+ * in all copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL
+ * THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+ * OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+ * ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+ * OTHER DEALINGS IN THE SOFTWARE.
+ */
+
+package domain
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/Cray-HPE/hms-firmware-action/internal/presentation"
+	"github.com/Cray-HPE/hms-firmware-action/internal/storage"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/suite"
+)
+
+type Archive_TS struct {
+	suite.Suite
+	dir string
+}
+
+func (suite *Archive_TS) SetupSuite() {
+	suite.dir, _ = os.MkdirTemp("", "fas-archive")
+	var asp storage.ArchiveProvider = &storage.FileArchive{Path: suite.dir}
+	suite.True(asp.Init(logrus.New()) == nil)
+	GLOB.ASP = &asp
+}
+
+func (suite *Archive_TS) TearDownSuite() {
+	GLOB.ASP = nil
+	os.RemoveAll(suite.dir)
+}
+
+func (suite *Archive_TS) Test_DeleteExpiredActions_Archives() {
+	action := storage.HelperGetStockAction()
+	action.Command.Description = "archive me"
+	action.State.SetState("completed")
+	action.EndTime.Scan(time.Now().AddDate(0, 0, -30))
+	operation := storage.HelperGetStockOperation()
+	operation.ActionID = action.ActionID
+	operation.Xname = "x9c0s0b0"
+	operation.State.SetState("succeeded")
+	action.OperationIDs = []uuid.UUID{operation.OperationID}
+	suite.True(StoreOperation(operation) == nil)
+	suite.True(StoreAction(action) == nil)
+
+	DeleteExpiredActions(7)
+	_, err := GetStoredAction(action.ActionID)
+	suite.False(err == nil)
+
+	pb := GetArchivedActions(storage.ArchiveFilter{Xname: "x9c0s0b0"})
+	suite.False(pb.IsError)
+	summaries := pb.Obj.(presentation.ArchivedActionSummaries)
+	suite.Equal(1, len(summaries.Actions))
+	suite.Equal(1, summaries.Actions[0].OperationCounts.Succeeded)
+
+	pb = GetArchivedAction(action.ActionID)
+	suite.False(pb.IsError)
+
+	pb = RestoreArchivedAction(action.ActionID)
+	suite.False(pb.IsError)
+	restored, err := GetStoredAction(action.ActionID)
+	suite.True(err == nil)
+	suite.True(restored.RestoredTime.Valid)
+	_, err = GetStoredOperation(operation.OperationID)
+	suite.True(err == nil)
+
+	// restored actions get a new retention period
+	DeleteExpiredActions(7)
+	_, err = GetStoredAction(action.ActionID)
+	suite.True(err == nil)
+
+	pb = RestoreArchivedAction(action.ActionID)
+	suite.True(pb.IsError)
+	suite.Equal(http.StatusConflict, pb.StatusCode)
+
+	pb = DeleteAction(action.ActionID)
+	suite.False(pb.IsError)
+}
+
+func (suite *Archive_TS) Test_DeleteExpiredActions_ArchiveFails() {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer srv.Close()
+	saved := GLOB.ASP
+	var asp storage.ArchiveProvider = &storage.S3Archive{Endpoint: srv.URL, Bucket: "missing", AccessKey: "access", SecretKey: "secret"}
+	suite.True(asp.Init(logrus.New()) == nil)
+	GLOB.ASP = &asp
+	defer func() { GLOB.ASP = saved }()
+
+	action := storage.HelperGetStockAction()
+	action.Command.Description = "keep me"
+	action.State.SetState("completed")
+	action.EndTime.Scan(time.Now().AddDate(0, 0, -30))
+	suite.True(StoreAction(action) == nil)
+
+	DeleteExpiredActions(7)
+	_, err := GetStoredAction(action.ActionID)
+	suite.True(err == nil)
+
+	pb := DeleteAction(action.ActionID)
+	suite.False(pb.IsError)
+}
+
+func (suite *Archive_TS) Test_DeleteExpiredActions_KeepsStaged() {
+	action := storage.HelperGetStockAction()
+	action.Command.Description = "activate me"
+	action.State.SetState("completed")
+	action.EndTime.Scan(time.Now().AddDate(0, 0, -30))
+	operation := storage.HelperGetStockOperation()
+	operation.ActionID = action.ActionID
+	operation.State.SetState("staged")
+	action.OperationIDs = []uuid.UUID{operation.OperationID}
+	suite.True(StoreOperation(operation) == nil)
+	suite.True(StoreAction(action) == nil)
+
+	DeleteExpiredActions(7)
+	_, err := GetStoredAction(action.ActionID)
+	suite.True(err == nil)
+
+	pb := DeleteAction(action.ActionID)
+	suite.False(pb.IsError)
+}
+
+func (suite *Archive_TS) Test_RestoreArchivedAction_NotFound() {
+	pb := RestoreArchivedAction(uuid.New())
+	suite.True(pb.IsError)
+	suite.Equal(http.StatusNotFound, pb.StatusCode)
+}
+
+func Test_Domain_Archive(t *testing.T) {
+	ConfigureSystemForUnitTesting()
+	suite.Run(t, new(Archive_TS))
+}
@@ -0,0 +1,337 @@
+/*
+ * MIT License
+ *
+ * (C) Copyright [2026] Hewlett Packard Enterprise Development LP
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a
+ * copy of this software and associated documentation files (the "Software"),
+ * to deal in the Software without restriction, including without limitation
+ * the rights to use, copy, modify, merge, publish, distribute, sublicense,
+ * and/or sell copies of the Software, and to permit persons to whom the
+ * Software is furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included
+ * in all copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL
+ * THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+ * OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+ * ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+ * OTHER DEALINGS IN THE SOFTWARE.
+ */
+
+package domain
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/Cray-HPE/hms-firmware-action/internal/model"
+	"github.com/Cray-HPE/hms-firmware-action/internal/presentation"
+	"github.com/Cray-HPE/hms-firmware-action/internal/storage"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	ConflictModeSkip      = "skip"
+	ConflictModeOverwrite = "overwrite"
+	ConflictModeFail      = "fail"
+)
+
+// WriteBackup - streams every image, compatibility rule, exclusion policy, quarantine entry, snapshot, action and operation as JSON
+// lines. Errors after the first write cannot be reported to the client; the
+// missing trailer is what lets RestoreBackup detect the truncated stream.
+func WriteBackup(w io.Writer) (err error) {
+	enc := json.NewEncoder(w)
+	now := time.Now()
+	err = enc.Encode(storage.BackupRecord{Kind: storage.BackupKindHeader, FormatVersion: storage.BackupFormatVersion, CreateTime: &now})
+	if err != nil {
+		return err
+	}
+	count := 0
+
+	images, err := GetStoredImages()
+	if err != nil {
+		return err
+	}
+	for i := range images {
+		if err = enc.Encode(storage.BackupRecord{Kind: storage.BackupKindImage, Image: &images[i]}); err != nil {
+			return err
+		}
+		count++
+	}
+
+	rules, err := GetStoredCompatibilityRules()
+	if err != nil {
+		return err
+	}
+	for i := range rules {
+		if err = enc.Encode(storage.BackupRecord{Kind: storage.BackupKindRule, Rule: &rules[i]}); err != nil {
+			return err
+		}
+		count++
+	}
+
+	exclusions, err := GetStoredExclusionPolicies()
+	if err != nil {
+		return err
+	}
+	for i := range exclusions {
+		if err = enc.Encode(storage.BackupRecord{Kind: storage.BackupKindExclusion, Exclusion: &exclusions[i]}); err != nil {
+			return err
+		}
+		count++
+	}
+
+	quarantine, err := GetStoredQuarantineEntries()
+	if err != nil {
+		return err
+	}
+	for i := range quarantine {
+		if err = enc.Encode(storage.BackupRecord{Kind: storage.BackupKindQuarantine, Quarantine: &quarantine[i]}); err != nil {
+			return err
+		}
+		count++
+	}
+
+	snapshots, err := GetStoredSnapshots()
+	if err != nil {
+		return err
+	}
+	for _, s := range snapshots {
+		// the snapshot list does not carry devices on every backend
+		snapshot, err := GetStoredSnapshot(s.Name)
+		if err != nil {
+			return err
+		}
+		ss := storage.ToSnapshotStorableWithDevices(snapshot)
+		if err = enc.Encode(storage.BackupRecord{Kind: storage.BackupKindSnapshot, Snapshot: &ss}); err != nil {
+			return err
+		}
+		count++
+	}
+
+	operations, err := GetAllOperations()
+	if err != nil {
+		return err
+	}
+	for _, o := range operations {
+		ops := storage.ToOperationStorable(o)
+		history, err := (*GLOB.DSP).GetOperationHistory(o.OperationID)
+		if err != nil {
+			return err
+		}
+		if err = enc.Encode(storage.BackupRecord{Kind: storage.BackupKindOperation, Operation: &ops, History: history}); err != nil {
+			return err
+		}
+		count++
+	}
+
+	actions, err := GetStoredActions()
+	if err != nil {
+		return err
+	}
+	for _, a := range actions {
+		as := storage.ToActionStorable(a)
+		if err = enc.Encode(storage.BackupRecord{Kind: storage.BackupKindAction, Action: &as}); err != nil {
+			return err
+		}
+		count++
+	}
+
+	err = enc.Encode(storage.BackupRecord{Kind: storage.BackupKindTrailer, RecordCount: count})
+	return err
+}
+
+// readBackup - reads and checks the whole stream before anything is restored,
+// so a truncated or corrupt backup never leaves a partial restore behind.
+// gzip compressed backups are accepted as well.
+func readBackup(r io.Reader) (records []storage.BackupRecord, err error) {
+	br := bufio.NewReader(r)
+	if magic, _ := br.Peek(2); bytes.Equal(magic, []byte{0x1f, 0x8b}) {
+		zr, err := gzip.NewReader(br)
+		if err != nil {
+			return records, err
+		}
+		defer zr.Close()
+		r = zr
+	} else {
+		r = br
+	}
+
+	dec := json.NewDecoder(r)
+	var header storage.BackupRecord
+	if err = dec.Decode(&header); err != nil {
+		return records, fmt.Errorf("could not read backup header: %w", err)
+	}
+	if header.Kind != storage.BackupKindHeader {
+		return records, errors.New("backup does not start with a header")
+	}
+	if header.FormatVersion < 1 || header.FormatVersion > storage.BackupFormatVersion {
+		return records, fmt.Errorf("unsupported backup format version: %d", header.FormatVersion)
+	}
+
+	for {
+		var rec storage.BackupRecord
+		if err = dec.Decode(&rec); err == io.EOF {
+			return records, errors.New("backup is truncated, no trailer found")
+		} else if err != nil {
+			return records, err
+		}
+		if rec.Kind == storage.BackupKindTrailer {
+			if rec.RecordCount != len(records) {
+				return records, fmt.Errorf("backup trailer expects %d records, found %d", rec.RecordCount, len(records))
+			}
+			return records, nil
+		}
+		if (rec.Kind == storage.BackupKindImage && rec.Image == nil) ||
+			(rec.Kind == storage.BackupKindSnapshot && rec.Snapshot == nil) ||
+			(rec.Kind == storage.BackupKindAction && rec.Action == nil) ||
+			(rec.Kind == storage.BackupKindOperation && rec.Operation == nil) ||
+			(rec.Kind == storage.BackupKindRule && rec.Rule == nil) ||
+			(rec.Kind == storage.BackupKindExclusion && rec.Exclusion == nil) ||
+			(rec.Kind == storage.BackupKindQuarantine && rec.Quarantine == nil) {
+			return records, fmt.Errorf("backup record of kind %s has no content", rec.Kind)
+		}
+		switch rec.Kind {
+		case storage.BackupKindImage, storage.BackupKindSnapshot, storage.BackupKindAction, storage.BackupKindOperation,
+			storage.BackupKindRule, storage.BackupKindExclusion, storage.BackupKindQuarantine:
+			records = append(records, rec)
+		default:
+			return records, fmt.Errorf("unknown backup record kind: %s", rec.Kind)
+		}
+	}
+}
+
+// backupRecordExists - returns a printable key for the record and whether it is already stored
+func backupRecordExists(rec storage.BackupRecord) (key string, exists bool) {
+	var err error
+	switch rec.Kind {
+	case storage.BackupKindImage:
+		key = "image " + rec.Image.ImageID.String()
+		_, err = (*GLOB.DSP).GetImage(rec.Image.ImageID)
+	case storage.BackupKindSnapshot:
+		key = "snapshot " + rec.Snapshot.Name
+		_, err = (*GLOB.DSP).GetSnapshot(rec.Snapshot.Name)
+	case storage.BackupKindAction:
+		key = "action " + rec.Action.ActionID.String()
+		_, err = (*GLOB.DSP).GetAction(rec.Action.ActionID)
+	case storage.BackupKindOperation:
+		key = "operation " + rec.Operation.OperationID.String()
+		_, err = (*GLOB.DSP).GetOperation(rec.Operation.OperationID)
+	case storage.BackupKindRule:
+		key = "compatibility rule " + rec.Rule.RuleID.String()
+		_, err = (*GLOB.DSP).GetCompatibilityRule(rec.Rule.RuleID)
+	case storage.BackupKindExclusion:
+		key = "exclusion policy " + rec.Exclusion.PolicyID.String()
+		_, err = (*GLOB.DSP).GetExclusionPolicy(rec.Exclusion.PolicyID)
+	case storage.BackupKindQuarantine:
+		key = "quarantine entry " + rec.Quarantine.Xname + " " + rec.Quarantine.Target
+		_, err = (*GLOB.DSP).GetQuarantineEntry(rec.Quarantine.Xname, rec.Quarantine.Target)
+	}
+	return key, err == nil
+}
+
+func restoreBackupRecord(rec storage.BackupRecord, overwrite bool) (err error) {
+	switch rec.Kind {
+	case storage.BackupKindImage:
+		err = StoreImage(*rec.Image)
+	case storage.BackupKindSnapshot:
+		// replace rather than merge the devices of an existing snapshot
+		if overwrite {
+			_ = DeleteStoredSnapshot(rec.Snapshot.Name)
+		}
+		err = StoreSnapshot(storage.ToSnapshotFromStorable(*rec.Snapshot))
+	case storage.BackupKindAction:
+		// bypass StoreAction, the stored state must not be merged with the backup
+		err = (*GLOB.DSP).StoreAction(storage.ToActionFromStorable(*rec.Action, rec.Action.ActionID))
+	case storage.BackupKindOperation:
+		// the history is replaced by the one in the backup, not added to
+		err = StoreOperation(storage.ToOperationFromStorable(*rec.Operation))
+		if err == nil && len(rec.History) > 0 {
+			if err = (*GLOB.DSP).DeleteOperationHistory(rec.Operation.OperationID); err == nil {
+				err = (*GLOB.DSP).AppendOperationHistory(rec.Operation.OperationID, rec.History)
+			}
+		}
+	case storage.BackupKindRule:
+		err = StoreCompatibilityRule(*rec.Rule)
+	case storage.BackupKindExclusion:
+		err = StoreExclusionPolicy(*rec.Exclusion)
+	case storage.BackupKindQuarantine:
+		quarantineLock.Lock()
+		err = (*GLOB.DSP).StoreQuarantineEntry(*rec.Quarantine)
+		quarantineLock.Unlock()
+	}
+	return err
+}
+
+// RestoreBackup - imports a stream written by WriteBackup. mode decides what
+// happens to records that already exist: skip them, overwrite them, or fail
+// the whole restore before anything is written.
+func RestoreBackup(r io.Reader, mode string) (pb model.Passback) {
+	if mode != ConflictModeSkip && mode != ConflictModeOverwrite && mode != ConflictModeFail {
+		err := fmt.Errorf("invalid conflict mode %q, must be one of %s, %s, %s", mode, ConflictModeSkip, ConflictModeOverwrite, ConflictModeFail)
+		pb = model.BuildErrorPassback(http.StatusBadRequest, err)
+		return
+	}
+
+	records, err := readBackup(r)
+	if err != nil {
+		logrus.Error(err)
+		pb = model.BuildErrorPassback(http.StatusBadRequest, err)
+		return
+	}
+
+	summary := presentation.BackupRestoreSummary{}
+	var conflicts []string
+	exists := make([]bool, len(records))
+	for i, rec := range records {
+		var key string
+		key, exists[i] = backupRecordExists(rec)
+		if exists[i] {
+			conflicts = append(conflicts, key)
+		}
+	}
+	if mode == ConflictModeFail && len(conflicts) > 0 {
+		err = model.NewInvalidInputError("backup conflicts with existing entries", conflicts)
+		pb = model.BuildErrorPassback(http.StatusConflict, err)
+		return
+	}
+
+	// actions go last so the control loop never sees an action without its operations
+	for _, kind := range []string{storage.BackupKindImage, storage.BackupKindRule, storage.BackupKindExclusion, storage.BackupKindQuarantine,
+		storage.BackupKindSnapshot, storage.BackupKindOperation, storage.BackupKindAction} {
+		for i, rec := range records {
+			if rec.Kind != kind {
+				continue
+			}
+			counts := summary.CountsFor(kind)
+			if exists[i] && mode == ConflictModeSkip {
+				counts.Skipped++
+				continue
+			}
+			err = restoreBackupRecord(rec, exists[i])
+			if err != nil {
+				logrus.Error(err)
+				pb = model.BuildErrorPassback(http.StatusInternalServerError, err)
+				return
+			}
+			if exists[i] {
+				counts.Overwritten++
+			} else {
+				counts.Restored++
+			}
+		}
+	}
+	pb = model.BuildSuccessPassback(http.StatusOK, summary)
+	return
+}
@@ -0,0 +1,182 @@
+/*
+ * MIT License
+ *
+ * (C) Copyright [2026] Hewlett Packard Enterprise Development LP
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a
+ * copy of this software and associated documentation files (the "Software"),
+ * to deal in the Software without restriction, including without limitation
+ * the rights to use, copy, modify, merge, publish, distribute, sublicense,
+ * and/or sell copies of the Software, and to permit persons to whom the
+ * Software is furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included
+ * in all copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL
+ * THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+ * OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+ * ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+ * OTHER DEALINGS IN THE SOFTWARE.
+ */
+
+package domain
+
+import (
+	"bytes"
+	"compress/gzip"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Cray-HPE/hms-firmware-action/internal/presentation"
+	"github.com/Cray-HPE/hms-firmware-action/internal/storage"
+	"github.com/stretchr/testify/suite"
+)
+
+type Backup_TS struct {
+	suite.Suite
+}
+
+func (suite *Backup_TS) storeEntities() (i storage.Image, s storage.Snapshot, a storage.Action, o storage.Operation) {
+	i = storage.HelperGetStockImage()
+	suite.True(StoreImage(i) == nil)
+	s = storage.HelperGetFilledSnapshot(2)
+	s.Name = "backup-" + i.ImageID.String()
+	suite.True(StoreSnapshot(s) == nil)
+	a = storage.HelperGetStockAction()
+	o = storage.HelperGetStockOperation()
+	o.ActionID = a.ActionID
+	a.OperationIDs = append(a.OperationIDs, o.OperationID)
+	suite.True(StoreOperation(o) == nil)
+	suite.True(StoreAction(a) == nil)
+	return
+}
+
+func (suite *Backup_TS) deleteEntities(i storage.Image, s storage.Snapshot, a storage.Action, o storage.Operation) {
+	_ = DeleteStoredImage(i.ImageID)
+	_ = DeleteStoredSnapshot(s.Name)
+	_ = DeleteStoredOperation(o.OperationID)
+	_ = DeleteStoredAction(a.ActionID)
+}
+
+func (suite *Backup_TS) Test_Backup_RoundTrip() {
+	i, s, a, o := suite.storeEntities()
+	rule := storage.HelperGetStockCompatibilityRule()
+	suite.True(StoreCompatibilityRule(rule) == nil)
+	exclusion := storage.HelperGetStockExclusionPolicy()
+	suite.True(StoreExclusionPolicy(exclusion) == nil)
+	quarantine := storage.QuarantineEntry{Xname: "x9c7s0b0", Target: "BMC", ConsecutiveFailures: 3,
+		LastFailureTime: time.Now().UTC(), LastFailureCode: storage.FailureBMCTaskFailed,
+		LastOperationID: o.OperationID, Quarantined: true, QuarantineTime: time.Now().UTC()}
+	suite.True((*GLOB.DSP).StoreQuarantineEntry(quarantine) == nil)
+	history, err := (*GLOB.DSP).GetOperationHistory(o.OperationID)
+	suite.True(err == nil)
+	suite.True(len(history) > 0)
+	var buf bytes.Buffer
+	suite.True(WriteBackup(&buf) == nil)
+	suite.deleteEntities(i, s, a, o)
+	suite.Equal(http.StatusNoContent, DeleteCompatibilityRule(rule.RuleID).StatusCode)
+	suite.Equal(http.StatusNoContent, DeleteExclusionPolicy(exclusion.PolicyID).StatusCode)
+	suite.Equal(http.StatusNoContent, ClearQuarantine(quarantine.Xname, quarantine.Target).StatusCode)
+
+	// other suites share the store, so only what was deleted is restored
+	pb := RestoreBackup(bytes.NewReader(buf.Bytes()), ConflictModeSkip)
+	suite.False(pb.IsError)
+	summary := pb.Obj.(presentation.BackupRestoreSummary)
+	suite.True(summary.Images.Restored >= 1)
+	suite.True(summary.Actions.Restored >= 1)
+
+	iRet, err := GetStoredImage(i.ImageID)
+	suite.True(err == nil)
+	suite.True(i.Equals(iRet))
+	rRet, err := GetStoredCompatibilityRule(rule.RuleID)
+	suite.True(err == nil)
+	suite.True(rule.Equals(rRet))
+	suite.Equal(1, summary.Rules.Restored)
+	eRet, err := GetStoredExclusionPolicy(exclusion.PolicyID)
+	suite.True(err == nil)
+	suite.True(exclusion.Equals(eRet))
+	suite.Equal(1, summary.Exclusions.Restored)
+	qRet, err := (*GLOB.DSP).GetQuarantineEntry(quarantine.Xname, quarantine.Target)
+	suite.True(err == nil)
+	suite.Equal(quarantine.ConsecutiveFailures, qRet.ConsecutiveFailures)
+	suite.True(qRet.Quarantined)
+	suite.Equal(1, summary.Quarantine.Restored)
+	sRet, err := GetStoredSnapshot(s.Name)
+	suite.True(err == nil)
+	suite.Equal(len(s.Devices), len(sRet.Devices))
+	aRet, err := GetStoredAction(a.ActionID)
+	suite.True(err == nil)
+	suite.True(a.Equals(aRet))
+	oRet, err := GetStoredOperation(o.OperationID)
+	suite.True(err == nil)
+	// the provider stamps the refresh time on every store
+	suite.Equal(o.ActionID, oRet.ActionID)
+	suite.Equal(o.State.Current(), oRet.State.Current())
+	hRet, err := (*GLOB.DSP).GetOperationHistory(o.OperationID)
+	suite.True(err == nil)
+	suite.Equal(len(history), len(hRet))
+
+	// everything exists now
+	pb = RestoreBackup(bytes.NewReader(buf.Bytes()), ConflictModeFail)
+	suite.True(pb.IsError)
+	suite.Equal(http.StatusConflict, pb.StatusCode)
+
+	pb = RestoreBackup(bytes.NewReader(buf.Bytes()), ConflictModeSkip)
+	suite.False(pb.IsError)
+	summary = pb.Obj.(presentation.BackupRestoreSummary)
+	suite.Equal(0, summary.Images.Restored+summary.Actions.Restored+summary.Operations.Restored+summary.Snapshots.Restored)
+	suite.True(summary.Images.Skipped >= 1)
+
+	var zbuf bytes.Buffer
+	zw := gzip.NewWriter(&zbuf)
+	zw.Write(buf.Bytes())
+	zw.Close()
+	pb = RestoreBackup(&zbuf, ConflictModeOverwrite)
+	suite.False(pb.IsError)
+	summary = pb.Obj.(presentation.BackupRestoreSummary)
+	suite.True(summary.Operations.Overwritten >= 1)
+	suite.Equal(1, summary.Quarantine.Overwritten)
+	hRet, err = (*GLOB.DSP).GetOperationHistory(o.OperationID)
+	suite.True(err == nil)
+	suite.Equal(len(history), len(hRet)) //replaced, not added to
+
+	suite.deleteEntities(i, s, a, o)
+	// a policy left behind would exclude devices in the other suites
+	_ = DeleteExclusionPolicy(exclusion.PolicyID)
+	_ = ClearQuarantine(quarantine.Xname, quarantine.Target)
+}
+
+func (suite *Backup_TS) Test_Backup_Truncated() {
+	i, s, a, o := suite.storeEntities()
+	var buf bytes.Buffer
+	suite.True(WriteBackup(&buf) == nil)
+	suite.deleteEntities(i, s, a, o)
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	truncated := strings.Join(lines[:len(lines)-1], "\n")
+	pb := RestoreBackup(strings.NewReader(truncated), ConflictModeOverwrite)
+	suite.True(pb.IsError)
+	suite.Equal(http.StatusBadRequest, pb.StatusCode)
+	_, err := GetStoredImage(i.ImageID)
+	suite.False(err == nil)
+}
+
+func (suite *Backup_TS) Test_Backup_BadInput() {
+	pb := RestoreBackup(strings.NewReader(""), "merge")
+	suite.True(pb.IsError)
+	suite.Equal(http.StatusBadRequest, pb.StatusCode)
+
+	pb = RestoreBackup(strings.NewReader(`{"kind":"header","formatVersion":99}`), ConflictModeFail)
+	suite.True(pb.IsError)
+	suite.Equal(http.StatusBadRequest, pb.StatusCode)
+}
+
+func Test_Domain_Backup(t *testing.T) {
+	ConfigureSystemForUnitTesting()
+	suite.Run(t, new(Backup_TS))
+}
@@ -0,0 +1,440 @@
+/*
+ * MIT License
+ *
+ * (C) Copyright [2026] Hewlett Packard Enterprise Development LP
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a
+ * copy of this software and associated documentation files (the "Software"),
+ * to deal in the Software without restriction, including without limitation
+ * the rights to use, copy, modify, merge, publish, distribute, sublicense,
+ * and/or sell copies of the Software, and to permit persons to whom the
+ * Software is furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included
+ * in all copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL
+ * THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+ * OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+ * ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+ * OTHER DEALINGS IN THE SOFTWARE.
+ */
+
+package domain
+
+import (
+	"errors"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/Cray-HPE/hms-firmware-action/internal/model"
+	"github.com/Cray-HPE/hms-firmware-action/internal/presentation"
+	"github.com/Cray-HPE/hms-firmware-action/internal/storage"
+	"github.com/Cray-HPE/hms-xname/xnametypes"
+	"github.com/Masterminds/semver/v3"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+)
+
+func GetStoredCompatibilityRules() (rules []storage.CompatibilityRule, err error) {
+	rules, err = (*GLOB.DSP).GetCompatibilityRules()
+	return
+}
+
+func GetStoredCompatibilityRule(ruleID uuid.UUID) (rule storage.CompatibilityRule, err error) {
+	if ruleID == uuid.Nil {
+		err = errors.New("Null rule id")
+		return
+	}
+	rule, err = (*GLOB.DSP).GetCompatibilityRule(ruleID)
+	return
+}
+
+func StoreCompatibilityRule(rule storage.CompatibilityRule) (err error) {
+	err = (*GLOB.DSP).StoreCompatibilityRule(rule)
+	return
+}
+
+// CreateCompatibilityRule - will create a compatibility rule
+func CreateCompatibilityRule(r presentation.RawCompatibilityRule) (pb model.Passback) {
+	rule := r.NewCompatibilityRule()
+	err := ValidateCompatibilityRule(&rule)
+	if err != nil {
+		pb = model.BuildErrorPassback(http.StatusBadRequest, err)
+		return
+	}
+	err = StoreCompatibilityRule(rule)
+	if err == nil {
+		pb = model.BuildSuccessPassback(http.StatusOK, storage.CompatibilityRuleID{RuleID: rule.RuleID})
+	} else {
+		pb = model.BuildErrorPassback(http.StatusInternalServerError, err)
+	}
+	return
+}
+
+// GetCompatibilityRules - returns all compatibility rules
+func GetCompatibilityRules() (pb model.Passback) {
+	rules := presentation.CompatibilityRules{CompatibilityRules: []presentation.CompatibilityRuleMarshaled{}}
+	stored, err := GetStoredCompatibilityRules()
+	if err == nil {
+		for _, r := range stored {
+			rules.CompatibilityRules = append(rules.CompatibilityRules, presentation.ToCompatibilityRuleMarshaled(r))
+		}
+		pb = model.BuildSuccessPassback(http.StatusOK, rules)
+	} else {
+		pb = model.BuildErrorPassback(http.StatusInternalServerError, err)
+	}
+	return pb
+}
+
+// GetCompatibilityRule - returns a compatibility rule by ruleID
+func GetCompatibilityRule(ruleID uuid.UUID) (pb model.Passback) {
+	rule, err := GetStoredCompatibilityRule(ruleID)
+	if err == nil {
+		pb = model.BuildSuccessPassback(http.StatusOK, presentation.ToCompatibilityRuleMarshaled(rule))
+	} else {
+		pb = model.BuildErrorPassback(http.StatusNotFound, err)
+	}
+	return pb
+}
+
+// UpdateCompatibilityRule - create or replace a compatibility rule
+func UpdateCompatibilityRule(r presentation.RawCompatibilityRule, ruleID uuid.UUID) (pb model.Passback) {
+	rule := r.NewCompatibilityRule()
+	rule.RuleID = ruleID
+	err := ValidateCompatibilityRule(&rule)
+	if err != nil {
+		pb = model.BuildErrorPassback(http.StatusBadRequest, err)
+		return
+	}
+	status := http.StatusOK
+	if existing, err := GetStoredCompatibilityRule(ruleID); err != nil {
+		status = http.StatusCreated
+	} else {
+		rule.CreateTime = existing.CreateTime
+	}
+	err = StoreCompatibilityRule(rule)
+	if err != nil {
+		pb = model.BuildErrorPassback(http.StatusInternalServerError, err)
+		return
+	}
+	pb = model.BuildSuccessPassback(status, nil)
+	return pb
+}
+
+// DeleteCompatibilityRule - deletes a compatibility rule
+func DeleteCompatibilityRule(ruleID uuid.UUID) (pb model.Passback) {
+	_, err := GetStoredCompatibilityRule(ruleID)
+	if err != nil {
+		logrus.Error(err)
+		pb = model.BuildErrorPassback(http.StatusNotFound, err)
+		return pb
+	}
+	err = (*GLOB.DSP).DeleteCompatibilityRule(ruleID)
+	if err == nil {
+		pb = model.BuildSuccessPassback(http.StatusNoContent, nil)
+		return pb
+	}
+	pb = model.BuildErrorPassback(http.StatusInternalServerError, err)
+	return pb
+}
+
+// plannedTarget -> the version a target of an xname ends up at if the plan goes ahead, and the operations that get
+// it there
+type plannedTarget struct {
+	target       string
+	targetName   string
+	manufacturer string
+	model        string
+	version      *semver.Version
+	operations   []uuid.UUID
+}
+
+type compatibilityViolation struct {
+	explanation string
+	operations  []uuid.UUID
+}
+
+// ScopeComponents -> the HSM components of the device types in the plan.  A uniform rule cannot see the versions of
+// the members of a group the action leaves out, so it checks them here.
+type ScopeComponents struct {
+	Xnames []string
+	Err    error
+}
+
+// loadScopeComponents -> only uniform rules need to know the other members of a group
+func loadScopeComponents(candidateOperations *map[uuid.UUID]storage.Operation, rules []storage.CompatibilityRule) (components ScopeComponents) {
+	uniform := false
+	for _, rule := range rules {
+		uniform = uniform || rule.Type == storage.CompatibilityRuleUniform
+	}
+	if !uniform {
+		return
+	}
+	typeSet := make(map[string]bool)
+	for _, operation := range *candidateOperations {
+		typeSet[xnametypes.GetHMSType(operation.Xname).String()] = true
+	}
+	var types []string
+	for t := range typeSet {
+		types = append(types, t)
+	}
+	var emptyArray []string
+	data, err := (*GLOB.HSM).GetStateComponents(emptyArray, emptyArray, emptyArray, types)
+	if err != nil {
+		components.Err = err
+		return
+	}
+	for _, component := range data.Components {
+		components.Xnames = append(components.Xnames, component.ID)
+	}
+	return
+}
+
+// ApplyCompatibilityRules -> enforces every stored compatibility rule on the candidate operations
+func ApplyCompatibilityRules(candidateOperations *map[uuid.UUID]storage.Operation, imageMap *map[uuid.UUID]storage.Image,
+	deviceMap *map[string]storage.Device) (violations []string) {
+	rules, err := GetStoredCompatibilityRules()
+	if err != nil {
+		logrus.Error(err)
+		return
+	}
+	components := loadScopeComponents(candidateOperations, rules)
+	if components.Err != nil {
+		logrus.WithField("ERROR", components.Err).Error("Could not get the HSM components of the compatibility rule scopes")
+	}
+	return EnforceCompatibilityRules(candidateOperations, imageMap, deviceMap, rules, components)
+}
+
+// EnforceCompatibilityRules -> checks the versions every target ends up at against the compatibility rules.  A broken
+// noSolution rule sets the operations that would break it to noSolution, a broken reject rule sets every operation of
+// the plan to noSolution.  A uniform rule is broken as well if the action changes part of a group only.  Returns an
+// explanation of every broken rule.
+func EnforceCompatibilityRules(candidateOperations *map[uuid.UUID]storage.Operation, imageMap *map[uuid.UUID]storage.Image,
+	deviceMap *map[string]storage.Device, rules []storage.CompatibilityRule, components ScopeComponents) (violations []string) {
+	for {
+		//dropping operations changes the plan, so start over after every broken rule
+		planned := planTargetVersions(candidateOperations, imageMap, deviceMap)
+		var broken []compatibilityViolation
+		var rule storage.CompatibilityRule
+		for _, rule = range rules {
+			if broken = checkCompatibilityRule(rule, planned, components); len(broken) > 0 {
+				break
+			}
+		}
+		if len(broken) == 0 {
+			return model.RemoveDuplicateStrings(violations)
+		}
+
+		for _, violation := range broken {
+			logrus.WithFields(logrus.Fields{"ruleID": rule.RuleID, "violation": violation.explanation}).Debug("compatibility rule broken")
+			violations = append(violations, violation.explanation)
+			if rule.Enforcement == storage.CompatibilityEnforcementReject {
+				for operationID, operation := range *candidateOperations {
+					if willRun(operation) {
+						setNoSolution(candidateOperations, operationID, storage.FailureIncompatible, "plan rejected: "+violation.explanation)
+					}
+				}
+				return model.RemoveDuplicateStrings(violations)
+			}
+			for _, operationID := range violation.operations {
+				setNoSolution(candidateOperations, operationID, storage.FailureIncompatible, "incompatible: "+violation.explanation)
+			}
+		}
+	}
+}
+
+func planTargetVersions(candidateOperations *map[uuid.UUID]storage.Operation, imageMap *map[uuid.UUID]storage.Image,
+	deviceMap *map[string]storage.Device) (planned map[string][]*plannedTarget) {
+	planned = make(map[string][]*plannedTarget)
+	get := func(xname string, target string) *plannedTarget {
+		if p := findPlannedTarget(planned[xname], target); p != nil {
+			return p
+		}
+		p := &plannedTarget{target: target}
+		planned[xname] = append(planned[xname], p)
+		return p
+	}
+
+	for _, operation := range *candidateOperations {
+		p := get(operation.Xname, operation.Target)
+		p.targetName = operation.TargetName
+		p.manufacturer = operation.Manufacturer
+		p.model = operation.Model
+		if willRun(operation) {
+			p.operations = append(p.operations, operation.OperationID)
+		}
+		//only the last step of an upgrade path says where the target ends up
+		if len(operation.UpgradePath) > 0 && operation.UpgradePath[len(operation.UpgradePath)-1] != operation.OperationID {
+			continue
+		}
+		if willRun(operation) {
+			p.version = (*imageMap)[operation.ToImageID].SemanticFirmwareVersion
+			continue
+		}
+		origin := operation
+		if len(operation.UpgradePath) > 0 {
+			origin = (*candidateOperations)[operation.UpgradePath[0]]
+		}
+		p.version = currentSemanticVersion(origin.Manufacturer, origin.Target, origin.FromFirmwareVersion, imageMap)
+		if fromImage, ok := (*imageMap)[origin.FromImageID]; ok {
+			p.version = fromImage.SemanticFirmwareVersion
+		}
+	}
+
+	//targets that are not part of the action stay where they are
+	for xname, device := range *deviceMap {
+		var manufacturer, deviceModel string
+		if len(planned[xname]) > 0 {
+			manufacturer, deviceModel = planned[xname][0].manufacturer, planned[xname][0].model
+		}
+		for _, target := range device.Targets {
+			if findPlannedTarget(planned[xname], target.Name) != nil {
+				continue
+			}
+			p := get(xname, target.Name)
+			p.targetName = target.TargetName
+			p.manufacturer = manufacturer
+			p.model = deviceModel
+			p.version = currentSemanticVersion(manufacturer, target.Name, target.FirmwareVersion, imageMap)
+		}
+	}
+	return planned
+}
+
+func findPlannedTarget(targets []*plannedTarget, target string) *plannedTarget {
+	for _, p := range targets {
+		if p.target == target || (p.targetName != "" && p.targetName == target) {
+			return p
+		}
+	}
+	return nil
+}
+
+func checkCompatibilityRule(rule storage.CompatibilityRule, planned map[string][]*plannedTarget,
+	components ScopeComponents) (broken []compatibilityViolation) {
+	prefix := "compatibility rule " + rule.RuleID.String()
+	if rule.Description != "" {
+		prefix += " (" + rule.Description + ")"
+	}
+
+	switch rule.Type {
+	case storage.CompatibilityRuleRequires:
+		for xname, targets := range planned {
+			trigger := findPlannedTarget(targets, rule.Target)
+			if trigger == nil || !rule.AppliesTo(trigger.manufacturer, trigger.model) ||
+				!meetsConstraint(rule.Versions, trigger.version) {
+				continue
+			}
+			var requiredVersion *semver.Version
+			operations := append([]uuid.UUID{}, trigger.operations...)
+			if required := findPlannedTarget(targets, rule.RequiredTarget); required != nil {
+				requiredVersion = required.version
+				operations = append(operations, required.operations...)
+			}
+			//a combination this action does not touch is not this action's problem
+			if meetsConstraint(rule.RequiredVersions, requiredVersion) || len(operations) == 0 {
+				continue
+			}
+			broken = append(broken, compatibilityViolation{
+				explanation: prefix + ": " + xname + " " + rule.Target + " at " + versionString(trigger.version) + " requires " +
+					rule.RequiredTarget + " " + rule.RequiredVersions + ", it would be at " + versionString(requiredVersion),
+				operations: operations,
+			})
+		}
+
+	case storage.CompatibilityRuleUniform:
+		groups := make(map[string][]*plannedTarget)
+		for xname, targets := range planned {
+			p := findPlannedTarget(targets, rule.Target)
+			if p == nil || !rule.AppliesTo(p.manufacturer, p.model) {
+				continue
+			}
+			if group := ancestorOfType(xname, xnametypes.HMSType(rule.Scope)); group != "" {
+				groups[group] = append(groups[group], p)
+			}
+		}
+		for group, members := range groups {
+			var operations []uuid.UUID
+			versions := make(map[string]bool)
+			for _, p := range members {
+				operations = append(operations, p.operations...)
+				if p.version != nil {
+					versions[p.version.String()] = true
+				}
+			}
+			if len(operations) == 0 {
+				continue
+			}
+			if len(versions) > 1 {
+				var list []string
+				for v := range versions {
+					list = append(list, v)
+				}
+				sort.Strings(list)
+				broken = append(broken, compatibilityViolation{
+					explanation: prefix + ": " + rule.Target + " in " + group + " would be at " + strings.Join(list, ", ") +
+						"; every " + rule.Target + " in the " + strings.ToLower(rule.Scope) + " must run the same version",
+					operations: operations,
+				})
+				continue
+			}
+			//the versions of the members the action leaves out are unknown, so the whole group has to be in it
+			if components.Err != nil {
+				broken = append(broken, compatibilityViolation{
+					explanation: prefix + ": could not get the other members of " + group + " from HSM: " + components.Err.Error(),
+					operations:  operations,
+				})
+				continue
+			}
+			if outside := membersOutsidePlan(group, xnametypes.HMSType(rule.Scope), components, planned); len(outside) > 0 {
+				broken = append(broken, compatibilityViolation{
+					explanation: prefix + ": " + strings.Join(outside, ", ") + " in " + group + " would be left out; every " +
+						rule.Target + " in the " + strings.ToLower(rule.Scope) + " must be updated together",
+					operations: operations,
+				})
+			}
+		}
+	}
+	return broken
+}
+
+func membersOutsidePlan(group string, scope xnametypes.HMSType, components ScopeComponents,
+	planned map[string][]*plannedTarget) (outside []string) {
+	for _, xname := range components.Xnames {
+		xname = xnametypes.NormalizeHMSCompID(xname)
+		if _, ok := planned[xname]; !ok && ancestorOfType(xname, scope) == group {
+			outside = append(outside, xname)
+		}
+	}
+	sort.Strings(outside)
+	return model.RemoveDuplicateStrings(outside)
+}
+
+// meetsConstraint -> an empty constraint is met by anything, a non-empty one never by an unknown version
+func meetsConstraint(constraint string, version *semver.Version) bool {
+	if constraint == "" {
+		return true
+	}
+	c, err := semver.NewConstraint(constraint)
+	if err != nil || version == nil {
+		return false
+	}
+	return c.Check(version)
+}
+
+// ancestorOfType -> the xname itself or its closest parent of the given type, e.g. the chassis of a node BMC
+func ancestorOfType(xname string, hmsType xnametypes.HMSType) string {
+	for x := xnametypes.NormalizeHMSCompID(xname); x != ""; x = xnametypes.GetHMSCompParent(x) {
+		if xnametypes.GetHMSType(x) == hmsType {
+			return x
+		}
+		if xnametypes.GetHMSType(x) == xnametypes.System {
+			break
+		}
+	}
+	return ""
+}
@@ -0,0 +1,189 @@
+/*
+ * MIT License
+ *
+ * (C) Copyright [2026] Hewlett Packard Enterprise Development LP
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a
+ * copy of this software and associated documentation files (the "Software"),
+ * to deal in the Software without restriction, including without limitation
+ * the rights to use, copy, modify, merge, publish, distribute, sublicense,
+ * and/or sell copies of the Software, and to permit persons to whom the
+ * Software is furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included
+ * in all copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL
+ * THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+ * OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+ * ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+ * OTHER DEALINGS IN THE SOFTWARE.
+ */
+
+package domain
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/Cray-HPE/hms-firmware-action/internal/presentation"
+	"github.com/Cray-HPE/hms-firmware-action/internal/storage"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/suite"
+)
+
+type Compatibility_Rules_TS struct {
+	suite.Suite
+}
+
+func (suite *Compatibility_Rules_TS) Test_EnforceCompatibilityRules_Requires() {
+	bios := helperPrerequisiteImage("BIOS", "2.1.0")
+	bmc := helperPrerequisiteImage("BMC", "1.6.0")
+	imageMap := map[uuid.UUID]storage.Image{bios.ImageID: bios, bmc.ImageID: bmc}
+
+	//the BMC stays at 1.0.0 here
+	lonelyBiosOp := helperPrerequisiteOperation("x0c0s1b0", "BIOS", bios)
+	//and goes to 1.6.0 here
+	biosOp := helperPrerequisiteOperation("x0c0s2b0", "BIOS", bios)
+	bmcOp := helperPrerequisiteOperation("x0c0s2b0", "BMC", bmc)
+	candidates := map[uuid.UUID]storage.Operation{lonelyBiosOp.OperationID: lonelyBiosOp, biosOp.OperationID: biosOp,
+		bmcOp.OperationID: bmcOp}
+	deviceMap := map[string]storage.Device{
+		"x0c0s1b0": {Xname: "x0c0s1b0", Targets: []storage.Target{{Name: "BMC", FirmwareVersion: "1.0.0"}}},
+	}
+	rule := storage.HelperGetStockCompatibilityRule()
+	rule.Manufacturer = ""
+
+	violations := EnforceCompatibilityRules(&candidates, &imageMap, &deviceMap, []storage.CompatibilityRule{rule}, ScopeComponents{})
+
+	suite.Len(violations, 1)
+	suite.Contains(violations[0], rule.RuleID.String())
+	suite.True(candidates[lonelyBiosOp.OperationID].State.Is("noSolution"))
+	suite.Contains(candidates[lonelyBiosOp.OperationID].StateHelper, "incompatible: ")
+	suite.Contains(candidates[lonelyBiosOp.OperationID].StateHelper, "it would be at 1.0.0")
+	suite.Equal(storage.FailureIncompatible, candidates[lonelyBiosOp.OperationID].FailureCode)
+	suite.True(candidates[biosOp.OperationID].State.Is("configured"))
+	suite.True(candidates[bmcOp.OperationID].State.Is("configured"))
+}
+
+func (suite *Compatibility_Rules_TS) Test_EnforceCompatibilityRules_Uniform() {
+	bmc := helperPrerequisiteImage("BMC", "2.0.0")
+	imageMap := map[uuid.UUID]storage.Image{bmc.ImageID: bmc}
+
+	op := helperPrerequisiteOperation("x0c0s1b0", "BMC", bmc)
+	//a different chassis, nothing to line up with
+	otherOp := helperPrerequisiteOperation("x0c1s1b0", "BMC", bmc)
+	candidates := map[uuid.UUID]storage.Operation{op.OperationID: op, otherOp.OperationID: otherOp}
+	deviceMap := map[string]storage.Device{
+		"x0c0s2b0": {Xname: "x0c0s2b0", Targets: []storage.Target{{Name: "BMC", FirmwareVersion: "1.0.0"}}},
+	}
+	rule := storage.CompatibilityRule{RuleID: uuid.New(), Type: storage.CompatibilityRuleUniform, Target: "BMC"}
+	suite.True(ValidateCompatibilityRule(&rule) == nil)
+	suite.Equal("Chassis", rule.Scope)
+	components := ScopeComponents{Xnames: []string{"x0c0s1b0", "x0c0s2b0", "x0c1s1b0"}}
+
+	violations := EnforceCompatibilityRules(&candidates, &imageMap, &deviceMap, []storage.CompatibilityRule{rule}, components)
+
+	suite.Len(violations, 1)
+	suite.Contains(violations[0], "x0c0 would be at 1.0.0, 2.0.0")
+	suite.True(candidates[op.OperationID].State.Is("noSolution"))
+	suite.True(candidates[otherOp.OperationID].State.Is("configured"))
+}
+
+func (suite *Compatibility_Rules_TS) Test_EnforceCompatibilityRules_UniformPartialGroup() {
+	bmc := helperPrerequisiteImage("BMC", "2.0.0")
+	imageMap := map[uuid.UUID]storage.Image{bmc.ImageID: bmc}
+
+	op := helperPrerequisiteOperation("x0c0s1b0", "BMC", bmc)
+	wholeOp := helperPrerequisiteOperation("x0c1s1b0", "BMC", bmc)
+	candidates := map[uuid.UUID]storage.Operation{op.OperationID: op, wholeOp.OperationID: wholeOp}
+	deviceMap := map[string]storage.Device{}
+	rule := storage.CompatibilityRule{RuleID: uuid.New(), Type: storage.CompatibilityRuleUniform, Target: "BMC"}
+	suite.True(ValidateCompatibilityRule(&rule) == nil)
+	//x0c0s2b0 shares the chassis with x0c0s1b0 but is not part of the action
+	components := ScopeComponents{Xnames: []string{"x0c0s1b0", "x0c0s2b0", "x0c1s1b0"}}
+
+	violations := EnforceCompatibilityRules(&candidates, &imageMap, &deviceMap, []storage.CompatibilityRule{rule}, components)
+
+	suite.Len(violations, 1)
+	suite.Contains(violations[0], "x0c0s2b0 in x0c0 would be left out")
+	suite.True(candidates[op.OperationID].State.Is("noSolution"))
+	suite.Equal(storage.FailureIncompatible, candidates[op.OperationID].FailureCode)
+	suite.True(candidates[wholeOp.OperationID].State.Is("configured"))
+
+	//without HSM the other members are unknown
+	op = helperPrerequisiteOperation("x0c0s1b0", "BMC", bmc)
+	candidates = map[uuid.UUID]storage.Operation{op.OperationID: op}
+	components = ScopeComponents{Err: errors.New("HSM is down")}
+	violations = EnforceCompatibilityRules(&candidates, &imageMap, &deviceMap, []storage.CompatibilityRule{rule}, components)
+
+	suite.Len(violations, 1)
+	suite.Contains(violations[0], "HSM is down")
+	suite.True(candidates[op.OperationID].State.Is("noSolution"))
+}
+
+func (suite *Compatibility_Rules_TS) Test_EnforceCompatibilityRules_Reject() {
+	bios := helperPrerequisiteImage("BIOS", "2.1.0")
+	bmc := helperPrerequisiteImage("BMC", "1.6.0")
+	imageMap := map[uuid.UUID]storage.Image{bios.ImageID: bios, bmc.ImageID: bmc}
+
+	biosOp := helperPrerequisiteOperation("x0c0s1b0", "BIOS", bios)
+	bmcOp := helperPrerequisiteOperation("x0c0s2b0", "BMC", bmc)
+	candidates := map[uuid.UUID]storage.Operation{biosOp.OperationID: biosOp, bmcOp.OperationID: bmcOp}
+	deviceMap := map[string]storage.Device{}
+	rule := storage.HelperGetStockCompatibilityRule()
+	rule.Manufacturer = ""
+	rule.Enforcement = storage.CompatibilityEnforcementReject
+
+	violations := EnforceCompatibilityRules(&candidates, &imageMap, &deviceMap, []storage.CompatibilityRule{rule}, ScopeComponents{})
+
+	suite.Len(violations, 1)
+	for _, operation := range candidates {
+		suite.True(operation.State.Is("noSolution"))
+		suite.Contains(operation.StateHelper, "plan rejected: ")
+		suite.Equal(storage.FailureIncompatible, operation.FailureCode)
+	}
+}
+
+func (suite *Compatibility_Rules_TS) Test_CompatibilityRule_CRUD() {
+	raw := presentation.RawCompatibilityRule{
+		Type:             storage.CompatibilityRuleRequires,
+		Target:           "BIOS",
+		RequiredTarget:   "BMC",
+		RequiredVersions: ">=1.5.0",
+	}
+	pb := CreateCompatibilityRule(raw)
+	suite.False(pb.IsError)
+	suite.Equal(http.StatusOK, pb.StatusCode)
+	ruleID := pb.Obj.(storage.CompatibilityRuleID).RuleID
+
+	pb = GetCompatibilityRule(ruleID)
+	suite.False(pb.IsError)
+	suite.Equal(storage.CompatibilityEnforcementNoSolution, pb.Obj.(presentation.CompatibilityRuleMarshaled).Enforcement)
+
+	raw.RequiredVersions = ">=1.6.0"
+	pb = UpdateCompatibilityRule(raw, ruleID)
+	suite.Equal(http.StatusOK, pb.StatusCode)
+	pb = UpdateCompatibilityRule(raw, uuid.New())
+	suite.Equal(http.StatusCreated, pb.StatusCode)
+
+	raw.RequiredVersions = "not a constraint"
+	pb = CreateCompatibilityRule(raw)
+	suite.Equal(http.StatusBadRequest, pb.StatusCode)
+	raw.Type = "sometimes"
+	pb = UpdateCompatibilityRule(raw, ruleID)
+	suite.Equal(http.StatusBadRequest, pb.StatusCode)
+
+	pb = DeleteCompatibilityRule(ruleID)
+	suite.Equal(http.StatusNoContent, pb.StatusCode)
+	pb = DeleteCompatibilityRule(ruleID)
+	suite.Equal(http.StatusNotFound, pb.StatusCode)
+}
+
+func Test_Domain_Compatibility_Rules(t *testing.T) {
+	ConfigureSystemForUnitTesting()
+	suite.Run(t, new(Compatibility_Rules_TS))
+}
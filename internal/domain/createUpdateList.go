@@ -32,6 +32,7 @@ import (
 	"github.com/Cray-HPE/hms-firmware-action/internal/hsm"
 	"github.com/Cray-HPE/hms-firmware-action/internal/model"
 	"github.com/Cray-HPE/hms-firmware-action/internal/storage"
+	"github.com/Masterminds/semver/v3"
 	"github.com/google/uuid"
 	"github.com/sirupsen/logrus"
 )
@@ -44,6 +45,36 @@ func GenerateOperations(actionID uuid.UUID) {
 		return
 	}
 
+	candidateOperations, errs := PlanOperations(action)
+	action.Errors = append(action.Errors, errs...)
+
+	// Clean up Error List - Only have one of each error string
+	action.Errors = model.RemoveDuplicateStrings(action.Errors)
+	//Start or Finish the Action!
+	if len(candidateOperations) == 0 {
+		action.EndTime.Scan(time.Now())
+		action.State.Event(context.Background(), "finish")
+	} else {
+		if action.State.Can("configure") { //if it cant start its because it got kicked out!
+			action.State.Event(context.Background(), "configure")
+		}
+
+		//store the operations and load the OperationIDs into the action
+		for k, v := range candidateOperations {
+			action.OperationIDs = append(action.OperationIDs, k)
+			err := StoreOperation(v)
+			if err != nil {
+				logrus.Error(err)
+			}
+		}
+	}
+	//Store the action
+	StoreAction(action)
+}
+
+// PlanOperations -> works out the operations an action would run, without storing anything.  errs are the problems
+// collecting the device data along the way.
+func PlanOperations(action storage.Action) (candidateOperations map[uuid.UUID]storage.Operation, errs []string) {
 	//Ok this is a bit fluid; The general flow will be:
 	// Generate initial xname list -> by using stateComponent filter
 	// GetHSMData
@@ -55,24 +86,19 @@ func GenerateOperations(actionID uuid.UUID) {
 	//	        like if the image could NEVER be applicable based on target, device type, manaufacturer, model
 	// THEN foreach operation:
 	//    Fill out the image stuff
+	//    find out if the image can be applied on top of what is there, or needs intermediate steps
 	//    find out if the image has depenencies
-	//    create any dependent operations and queue them to be filled and have depenedencies created
-	// store ops and action
+	//    block it on the operations for its prerequisites, or set it to noSolution if they cannot be met
+	// the caller stores ops and action
 
 	//STEP 1 -> filter for xnames | if the struct is empty it will get ALL xnames
-	hsmDataMap, errs := (*GLOB.HSM).FillHSMData(action.Parameters.StateComponentFilter.Xnames,
+	hsmDataMap, hsmErrs := (*GLOB.HSM).FillHSMData(action.Parameters.StateComponentFilter.Xnames,
 		action.Parameters.StateComponentFilter.Partitions,
 		action.Parameters.StateComponentFilter.Groups,
 		action.Parameters.StateComponentFilter.DeviceTypes)
 
-	if len(errs) > 0 {
-		for _, value := range errs {
-			action.Errors = append(action.Errors, value.Error())
-		}
-	}
-	err = StoreAction(action)
-	if err != nil {
-		logrus.Error(err)
+	for _, value := range hsmErrs {
+		errs = append(errs, value.Error())
 	}
 	//STEP 2 -> Get the target data based on the reduced hsmDataMap; and filter it accordingly
 	_, MatchedXnameTargets, _ := FilterTargets(&hsmDataMap, action.Parameters.TargetFilter)
@@ -82,6 +108,9 @@ func GenerateOperations(actionID uuid.UUID) {
 		XnameTargetHSMMap[MatchedXnameTargets[key]] = hsmDataMap[value.Xname]
 	}
 
+	//STEP 2b -> a retry action only wants the exact xname/target pairs that did not finish in its parent
+	FilterXnameTargets(&XnameTargetHSMMap, action.Parameters.XnameTargetFilter)
+
 	//TODO Perhaps move THIS to a global? Not going to do it In June of 2020 b.c it works and
 	//  I dont want to spend the time monkeying around with it!
 	specialTargets := make(map[string]string)
@@ -94,7 +123,7 @@ func GenerateOperations(actionID uuid.UUID) {
 	FilterModelManufacturer(&XnameTargetHSMMap, action.Parameters.InventoryHardwareFilter)
 
 	//STEP 4 -> generate candidate operations
-	candidateOperations := make(map[uuid.UUID]storage.Operation)
+	candidateOperations = make(map[uuid.UUID]storage.Operation)
 	for XT, _ := range XnameTargetHSMMap {
 		hsmdata := XnameTargetHSMMap[XT]
 		op := storage.NewOperation()
@@ -118,14 +147,15 @@ func GenerateOperations(actionID uuid.UUID) {
 	// I think this is the lesser of two evils, to get a bit more data, that I may need, then to do a very expensive query MANY times!
 
 	deviceMap, errlist := GetCurrentFirmwareVersionsFromHsmDataAndTargets(XnameTargetHSMMap)
-	action.Errors = append(action.Errors, errlist...)
-	err = StoreAction(action)
-	if err != nil {
-		logrus.Error(err)
-	}
+	errs = append(errs, errlist...)
 	//6b -> get all images
 	imageMap := GetImageMap()
+	quarantined := quarantinedDevices()
+
+	//STEP 6c -> devices an exclusion policy covers are never touched
+	errs = append(errs, ApplyExclusionPolicies(&candidateOperations, &deviceMap)...)
 
+	pathOperations := make(map[uuid.UUID]storage.Operation)
 	buildOperations := true
 	for buildOperations == true {
 		buildOperations = false //immediately set to false, so we dont just loop infinite
@@ -155,36 +185,59 @@ func GenerateOperations(actionID uuid.UUID) {
 					//STEP 9 -> SetNoOperationOperations!
 					SetNoOpOp(&operation, action.Command.OverwriteSameImage)
 
+					//STEP 9a -> do not go back to older firmware unless asked to
+					if operation.State.Can("configure") {
+						if err := CheckDowngrade(operation, &imageMap, action.Command.AllowDowngrade); err != nil {
+							operation.State.Event(context.Background(), "nosol")
+							operation.EndTime.Scan(time.Now())
+							operation.StateHelper = err.Error()
+							operation.FailureCode = storage.FailureDowngradeNotAllowed
+						}
+					}
+
+					//STEP 9b -> plan the steps it takes to get there, if the image cannot be applied on top of what is on the device
+					var path []storage.Image
+					if operation.State.Can("configure") {
+						var err error
+						path, err = PlanUpgradePath(operation, &imageMap, action.Parameters.Command.TagPreference())
+						if err != nil {
+							operation.State.Event(context.Background(), "nosol")
+							operation.EndTime.Scan(time.Now())
+							operation.StateHelper = err.Error()
+							operation.FailureCode = storage.FailureNoUpgradePath
+						}
+					}
+
+					//STEP 9c -> a device that failed too often in earlier actions is left alone until an admin clears it
+					if entry, ok := quarantined[operation.Xname][operation.Target]; ok && operation.State.Can("configure") {
+						SetQuarantinedOp(&operation, entry)
+					}
+
 					//Not a NoSOl nor a NoOP
 					if operation.State.Can("configure") { //it has been configured; it is now READY to be 'started'
-						//TODO here is a good place to add in @DependencyManagment -> see develop @ tag: with-dependency to see what we had.
 						operation.State.Event(context.Background(), "configure")
-
+						for _, step := range BuildUpgradePathOperations(&operation, path) {
+							pathOperations[step.OperationID] = step
+						}
 					}
 				}
-
-				err := StoreOperation(operation)
-				if err != nil {
-					logrus.Error(err)
-				}
 			}
 			candidateOperations[operationID] = operation
 		}
 	}
 
-	// Clean up Error List - Only have one of each error string
-	action.Errors = model.RemoveDuplicateStrings(action.Errors)
-	//Start or Finish the Action!
-	if len(candidateOperations) == 0 {
-		action.EndTime.Scan(time.Now())
-		action.State.Event(context.Background(), "finish")
-	} else {
-		if action.State.Can("configure") { //if it cant start its because it got kicked out!
-			action.State.Event(context.Background(), "configure")
-		}
+	for operationID, operation := range pathOperations {
+		candidateOperations[operationID] = operation
+	}
+
+	if len(candidateOperations) > 0 {
+		//STEP 10 -> order operations on the same xname by the prerequisites of their images
+		SetPrerequisiteBlockers(&candidateOperations, &imageMap, &deviceMap)
+
+		//STEP 11 -> hold the plan against the compatibility rules
+		errs = append(errs, ApplyCompatibilityRules(&candidateOperations, &imageMap, &deviceMap)...)
 
 		//Figure out if there are any sibling blockers (xname == xname)
-		//store the operations and load the OperationIDs into the action
 		xnameOps := make(map[string][]uuid.UUID)
 		for k, v := range candidateOperations {
 			if v.State.Is("configured") {
@@ -201,62 +254,85 @@ func GenerateOperations(actionID uuid.UUID) {
 					xnameOps[v.Xname] = append(xnameOps[v.Xname], v.OperationID)
 				}
 			}
-
-			//regardless of that state, save it to the action
-			action.OperationIDs = append(action.OperationIDs, k)
-			err := StoreOperation(v)
-			if err != nil {
-				logrus.Error(err)
-			}
 		}
 	}
-	//Store the action
-	StoreAction(action)
+	return
 }
 
 func FillInImageId(operation *storage.Operation, imageMap *map[uuid.UUID]storage.Image, parameters storage.ActionParameters) (err error) {
+	exactFrom := false
 	for _, image := range *imageMap {
-		_, found := model.Find(image.Models, operation.Model)
-		_, softwareIdFound := model.Find(image.SoftwareIds, operation.SoftwareId)
-		// if a software id is found on the node and the image, but does not match, do not use image
-		if (!softwareIdFound) && (len(image.SoftwareIds) > 0 && len(operation.SoftwareId) > 0) {
-			continue
-		}
-		// If a software id is found and matches the image, use this image no need to check other fields
-		// Otherwise Model, DeviceType, Target, and Manufacturer must be the same
-		if (softwareIdFound && (image.Target == operation.Target || image.Target == operation.TargetName)) ||
-			(found &&
-				strings.EqualFold(image.DeviceType, operation.DeviceType) &&
-				(image.Target == operation.Target || image.Target == operation.TargetName) &&
-				strings.EqualFold(image.Manufacturer, operation.Manufacturer)) { //if the image could be on. or could be applied
+		if ImageAppliesTo(image, *operation) { //if the image could be on. or could be applied
 			if image.FirmwareVersion == operation.FromFirmwareVersion { //We found the FROM IMAGE!!
 				//TODO problem: The tag thing gets hard here... new rule: the firmware version must be unique for the devicetype/manf/model;
 				operation.FromImageID = image.ImageID
+				exactFrom = true
+			} else if !exactFrom && FirmwareVersionMatchesImage(operation.Manufacturer, operation.Target, operation.FromFirmwareVersion, image) {
+				//the vendor reports it differently than the image record has it
+				operation.FromImageID = image.ImageID
 			}
-			if parameters.Command.Version != "explicit" && operation.AutomaticallyGenerated == false { //then try to figure out what to set it to!
-				_, found := model.Find(image.Tags, parameters.Command.Tag) //This satisfies CASMHMS-3169
-				if parameters.Command.Version == "latest" {                //check if there is something later!
-					if found {
-						if operation.ToImageID == uuid.Nil {
-							operation.ToImageID = image.ImageID
-						}
-						if (*imageMap)[operation.ToImageID].SemanticFirmwareVersion.LessThan(image.SemanticFirmwareVersion) {
-							operation.ToImageID = image.ImageID
-						}
-					}
-				} else if parameters.Command.Version == "earliest" { //check if there is something earlier!
-					if (operation.ToImageID == uuid.Nil ||
-						(*imageMap)[operation.ToImageID].SemanticFirmwareVersion.GreaterThan(image.SemanticFirmwareVersion)) &&
-						found { // you can ONLY get default image, unless you used an EXPLICIT image filter! {
-						operation.ToImageID = image.ImageID
-					}
-				}
-			}
+		}
+	}
+	if parameters.Command.Version == "explicit" || operation.AutomaticallyGenerated { //the ToImage is already set
+		return nil
+	}
+	//then try to figure out what to set it to! The first tag, in order of preference, that has a fitting image wins.
+	// you can ONLY get tagged images, unless you used an EXPLICIT image filter! This satisfies CASMHMS-3169
+	for _, tag := range parameters.Command.TagPreference() {
+		if imageID := selectImage(*operation, imageMap, tag, parameters.Command.Version); imageID != uuid.Nil {
+			operation.ToImageID = imageID
+			break
 		}
 	}
 	return nil
 }
 
+// selectImage -> the image with the tag that applies to the operation: the latest, the earliest, or the latest that
+// meets the version constraint
+func selectImage(operation storage.Operation, imageMap *map[uuid.UUID]storage.Image, tag string, version string) (selected uuid.UUID) {
+	var constraint *semver.Constraints
+	if version != "latest" && version != "earliest" {
+		var err error
+		if constraint, err = semver.NewConstraint(version); err != nil {
+			logrus.WithFields(logrus.Fields{"version": version, "err": err}).Error("not a version constraint")
+			return uuid.Nil
+		}
+	}
+	var selectedVersion *semver.Version
+	for _, image := range *imageMap {
+		if _, found := model.Find(image.Tags, tag); !found || !ImageAppliesTo(image, operation) ||
+			image.SemanticFirmwareVersion == nil {
+			continue
+		}
+		if constraint != nil && !constraint.Check(image.SemanticFirmwareVersion) {
+			continue
+		}
+		if selectedVersion == nil ||
+			(version == "earliest" && image.SemanticFirmwareVersion.LessThan(selectedVersion)) ||
+			(version != "earliest" && image.SemanticFirmwareVersion.GreaterThan(selectedVersion)) {
+			selected, selectedVersion = image.ImageID, image.SemanticFirmwareVersion
+		}
+	}
+	return selected
+}
+
+// ImageAppliesTo -> true if the image could be on the operation's target, or could be applied to it
+func ImageAppliesTo(image storage.Image, operation storage.Operation) bool {
+	_, found := model.Find(image.Models, operation.Model)
+	_, softwareIdFound := model.Find(image.SoftwareIds, operation.SoftwareId)
+	// if a software id is found on the node and the image, but does not match, do not use image
+	if (!softwareIdFound) && (len(image.SoftwareIds) > 0 && len(operation.SoftwareId) > 0) {
+		return false
+	}
+	// If a software id is found and matches the image, use this image no need to check other fields
+	// Otherwise Model, DeviceType, Target, and Manufacturer must be the same
+	return (softwareIdFound && (image.Target == operation.Target || image.Target == operation.TargetName)) ||
+		(found &&
+			strings.EqualFold(image.DeviceType, operation.DeviceType) &&
+			(image.Target == operation.Target || image.Target == operation.TargetName) &&
+			strings.EqualFold(image.Manufacturer, operation.Manufacturer))
+}
+
 func FilterImage(candidateOperations *map[uuid.UUID]storage.Operation, parameters storage.ActionParameters) (err error) {
 	//Filter on Image filter. Need to have all the operation data to see if the explicit image would fit from a Generic TYPE perspective
 	logrus.WithFields(logrus.Fields{"Parameters": parameters}).Trace("IN FilterImage")
@@ -308,6 +384,19 @@ func FilterModelManufacturer(dataMap *map[hsm.XnameTarget]hsm.HsmData, parameter
 	return
 }
 
+func FilterXnameTargets(dataMap *map[hsm.XnameTarget]hsm.HsmData, parameters storage.XnameTargetFilter) {
+	if parameters.Empty() {
+		return
+	}
+	for xnameTarget := range *dataMap {
+		if !parameters.Contains(xnameTarget.Xname, xnameTarget.Target) &&
+			!parameters.Contains(xnameTarget.Xname, xnameTarget.TargetName) {
+			delete(*dataMap, xnameTarget)
+			logrus.WithFields(logrus.Fields{"xnameTarget": xnameTarget}).Trace("removing device as candidate; not in xnameTargetFilter. ")
+		}
+	}
+}
+
 func FilterTargets(hsmDataMap *map[string]hsm.HsmData, parameters storage.TargetFilter) (XnameTargets []hsm.XnameTarget, MatchedXnameTargets []hsm.XnameTarget, UnMatchedXnameTargets []hsm.XnameTarget) {
 	XnameTargets, errs := (*GLOB.HSM).GetTargetsRF(hsmDataMap)
 	if len(errs) != 0 {
@@ -340,6 +429,7 @@ func SetErrSolOp(candidateOperation *storage.Operation) {
 	candidateOperation.State.Event(context.Background(), "fail")
 	candidateOperation.EndTime.Scan(time.Now())
 	candidateOperation.StateHelper = "ERROR Found See Operation Details"
+	candidateOperation.FailureCode = storage.FailureInvalidOperation
 }
 
 func SetNoSolOp(candidateOperation *storage.Operation) {
@@ -347,6 +437,7 @@ func SetNoSolOp(candidateOperation *storage.Operation) {
 		candidateOperation.State.Event(context.Background(), "nosol")
 		candidateOperation.EndTime.Scan(time.Now())
 		candidateOperation.StateHelper = "No Image available"
+		candidateOperation.FailureCode = storage.FailureImageNotFound
 	}
 	return
 }
@@ -357,6 +448,7 @@ func SetNoOpOp(candidateOperation *storage.Operation, overwriteSameImage bool) {
 			candidateOperation.State.Event(context.Background(), "noop")
 			candidateOperation.EndTime.Scan(time.Now())
 			candidateOperation.StateHelper = "Firmware at requested version"
+			candidateOperation.FailureCode = storage.FailureSameVersion
 		}
 	}
 	return
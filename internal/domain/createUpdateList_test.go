@@ -0,0 +1,85 @@
+/*
+ * MIT License
+ *
+ * (C) Copyright [2026] Hewlett Packard Enterprise Development LP
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a
+ * copy of this software and associated documentation files (the "Software"),
+ * to deal in the Software without restriction, including without limitation
+ * the rights to use, copy, modify, merge, publish, distribute, sublicense,
+ * and/or sell copies of the Software, and to permit persons to whom the
+ * Software is furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included
+ * in all copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL
+ * THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+ * OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+ * ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+ * OTHER DEALINGS IN THE SOFTWARE.
+ */
+
+package domain
+
+import (
+	"testing"
+
+	"github.com/Cray-HPE/hms-firmware-action/internal/storage"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/suite"
+)
+
+type Create_Update_List_TS struct {
+	suite.Suite
+}
+
+func (suite *Create_Update_List_TS) Test_FillInImageId_Version() {
+	installed := helperPathImage("2.3.0", "")
+	v240 := helperPathImage("2.4.0", "")
+	v245 := helperPathImage("2.4.5", "")
+	v250 := helperPathImage("2.5.0", "")
+	imageMap := map[uuid.UUID]storage.Image{installed.ImageID: installed, v240.ImageID: v240, v245.ImageID: v245,
+		v250.ImageID: v250}
+
+	for version, expected := range map[string]uuid.UUID{
+		"latest":         v250.ImageID,
+		"earliest":       installed.ImageID,
+		"~2.4":           v245.ImageID,
+		">=2.3.1 <2.4.5": v240.ImageID,
+		"^3":             uuid.Nil,
+	} {
+		operation := helperPathOperation(installed, v250)
+		operation.ToImageID = uuid.Nil
+		parameters := storage.ActionParameters{Command: storage.Command{Version: version, Tag: "default"}}
+		FillInImageId(&operation, &imageMap, parameters)
+		suite.Equal(installed.ImageID, operation.FromImageID, version)
+		suite.Equal(expected, operation.ToImageID, version)
+	}
+}
+
+func (suite *Create_Update_List_TS) Test_FillInImageId_TagPreference() {
+	installed := helperPathImage("2.3.0", "")
+	approved := helperPathImage("2.4.0", "")
+	approved.Tags = []string{"site-approved"}
+	newest := helperPathImage("2.5.0", "")
+	imageMap := map[uuid.UUID]storage.Image{installed.ImageID: installed, approved.ImageID: approved, newest.ImageID: newest}
+
+	operation := helperPathOperation(installed, newest)
+	operation.ToImageID = uuid.Nil
+	parameters := storage.ActionParameters{Command: storage.Command{Version: "latest", Tag: "site-approved,default"}}
+	FillInImageId(&operation, &imageMap, parameters)
+	suite.Equal(approved.ImageID, operation.ToImageID)
+
+	//nothing approved in the 2.5 line, so it falls back to default
+	operation.ToImageID = uuid.Nil
+	parameters.Command.Version = "~2.5"
+	FillInImageId(&operation, &imageMap, parameters)
+	suite.Equal(newest.ImageID, operation.ToImageID)
+}
+
+func Test_Domain_Create_Update_List(t *testing.T) {
+	suite.Run(t, new(Create_Update_List_TS))
+}
@@ -0,0 +1,64 @@
+/*
+ * MIT License
+ *
+ * (C) Copyright [2026] Hewlett Packard Enterprise Development LP
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a
+ * copy of this software and associated documentation files (the "Software"),
+ * to deal in the Software without restriction, including without limitation
+ * the rights to use, copy, modify, merge, publish, distribute, sublicense,
+ * and/or sell copies of the Software, and to permit persons to whom the
+ * Software is furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included
+ * in all copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL
+ * THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+ * OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+ * ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+ * OTHER DEALINGS IN THE SOFTWARE.
+ */
+
+package domain
+
+import (
+	"errors"
+
+	"github.com/Cray-HPE/hms-firmware-action/internal/storage"
+	"github.com/Masterminds/semver/v3"
+	"github.com/google/uuid"
+)
+
+// CheckDowngrade -> returns why the operation may not go ahead if it would put an older firmware version on the
+// device than the one already there.  Downgrades need allowDowngrade on the action, and never go below the
+// noDowngradeBelow of the image currently installed.  If either version is unknown there is nothing to compare.
+func CheckDowngrade(operation storage.Operation, imageMap *map[uuid.UUID]storage.Image, allowDowngrade bool) (err error) {
+	toImage, ok := (*imageMap)[operation.ToImageID]
+	if !ok || toImage.SemanticFirmwareVersion == nil {
+		return nil
+	}
+	fromImage, knownFrom := (*imageMap)[operation.FromImageID]
+	fromVersion := currentSemanticVersion(operation.Manufacturer, operation.Target, operation.FromFirmwareVersion, imageMap)
+	if knownFrom && fromImage.SemanticFirmwareVersion != nil {
+		fromVersion = fromImage.SemanticFirmwareVersion
+	}
+	if fromVersion == nil || !toImage.SemanticFirmwareVersion.LessThan(fromVersion) {
+		return nil
+	}
+
+	if knownFrom && fromImage.NoDowngradeBelow != "" {
+		floor, err := semver.NewVersion(fromImage.NoDowngradeBelow)
+		if err == nil && toImage.SemanticFirmwareVersion.LessThan(floor) {
+			return errors.New("downgrade not possible: " + operation.Target + " at " + fromVersion.String() +
+				" cannot go below " + floor.String() + ", " + toImage.SemanticFirmwareVersion.String() + " was selected")
+		}
+	}
+	if !allowDowngrade {
+		return errors.New("downgrade not allowed: " + operation.Target + " would go from " + fromVersion.String() +
+			" to " + toImage.SemanticFirmwareVersion.String() + "; set allowDowngrade to permit it")
+	}
+	return nil
+}
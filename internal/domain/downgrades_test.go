@@ -0,0 +1,83 @@
+/*
+ * MIT License
+ *
+ * (C) Copyright [2026] Hewlett Packard Enterprise Development LP
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a
+ * copy of this software and associated documentation files (the "Software"),
+ * to deal in the Software without restriction, including without limitation
+ * the rights to use, copy, modify, merge, publish, distribute, sublicense,
+ * and/or sell copies of the Software, and to permit persons to whom the
+ * Software is furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included
+ * in all copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL
+ * THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+ * OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+ * ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+ * OTHER DEALINGS IN THE SOFTWARE.
+ */
+
+package domain
+
+import (
+	"testing"
+
+	"github.com/Cray-HPE/hms-firmware-action/internal/storage"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/suite"
+)
+
+type Downgrades_TS struct {
+	suite.Suite
+}
+
+func (suite *Downgrades_TS) Test_CheckDowngrade() {
+	older := helperPrerequisiteImage("BMC", "1.0.0")
+	installed := helperPrerequisiteImage("BMC", "2.0.0")
+	newer := helperPrerequisiteImage("BMC", "3.0.0")
+	imageMap := map[uuid.UUID]storage.Image{older.ImageID: older, installed.ImageID: installed, newer.ImageID: newer}
+
+	operation := helperPrerequisiteOperation("x0c0s1b0", "BMC", newer)
+	operation.FromImageID = installed.ImageID
+	suite.True(CheckDowngrade(operation, &imageMap, false) == nil)
+
+	operation.ToImageID = older.ImageID
+	err := CheckDowngrade(operation, &imageMap, false)
+	suite.True(err != nil)
+	suite.Contains(err.Error(), "would go from 2.0.0 to 1.0.0")
+	suite.True(CheckDowngrade(operation, &imageMap, true) == nil)
+
+	//without an image for what is installed the version on the device is compared
+	operation.FromImageID = uuid.Nil
+	operation.FromFirmwareVersion = "2.5.0"
+	suite.True(CheckDowngrade(operation, &imageMap, false) != nil)
+	operation.FromFirmwareVersion = "not a version"
+	suite.True(CheckDowngrade(operation, &imageMap, false) == nil)
+}
+
+func (suite *Downgrades_TS) Test_CheckDowngrade_Floor() {
+	older := helperPrerequisiteImage("BMC", "1.0.0")
+	middle := helperPrerequisiteImage("BMC", "1.8.0")
+	installed := helperPrerequisiteImage("BMC", "2.0.0")
+	installed.NoDowngradeBelow = "1.5.0"
+	imageMap := map[uuid.UUID]storage.Image{older.ImageID: older, middle.ImageID: middle, installed.ImageID: installed}
+
+	operation := helperPrerequisiteOperation("x0c0s1b0", "BMC", older)
+	operation.FromImageID = installed.ImageID
+	err := CheckDowngrade(operation, &imageMap, true)
+	suite.True(err != nil)
+	suite.Contains(err.Error(), "cannot go below 1.5.0")
+
+	operation.ToImageID = middle.ImageID
+	suite.True(CheckDowngrade(operation, &imageMap, true) == nil)
+	suite.True(CheckDowngrade(operation, &imageMap, false) != nil)
+}
+
+func Test_Domain_Downgrades(t *testing.T) {
+	suite.Run(t, new(Downgrades_TS))
+}
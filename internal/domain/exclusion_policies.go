@@ -0,0 +1,314 @@
+/*
+ * MIT License
+ *
+ * (C) Copyright [2026] Hewlett Packard Enterprise Development LP
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a
+ * copy of this software and associated documentation files (the "Software"),
+ * to deal in the Software without restriction, including without limitation
+ * the rights to use, copy, modify, merge, publish, distribute, sublicense,
+ * and/or sell copies of the Software, and to permit persons to whom the
+ * Software is furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included
+ * in all copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL
+ * THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+ * OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+ * ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+ * OTHER DEALINGS IN THE SOFTWARE.
+ */
+
+package domain
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+
+	base "github.com/Cray-HPE/hms-base/v2"
+	"github.com/Cray-HPE/hms-firmware-action/internal/model"
+	"github.com/Cray-HPE/hms-firmware-action/internal/presentation"
+	"github.com/Cray-HPE/hms-firmware-action/internal/storage"
+	"github.com/Cray-HPE/hms-xname/xnametypes"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+)
+
+// NodeBlacklistPolicyID -> the policy the roles of the retired node_blacklist flag are carried over into
+var NodeBlacklistPolicyID = uuid.NewSHA1(uuid.NameSpaceURL, []byte("hms-firmware-action/node_blacklist"))
+
+func GetStoredExclusionPolicies() (policies []storage.ExclusionPolicy, err error) {
+	policies, err = (*GLOB.DSP).GetExclusionPolicies()
+	return
+}
+
+func GetStoredExclusionPolicy(policyID uuid.UUID) (policy storage.ExclusionPolicy, err error) {
+	if policyID == uuid.Nil {
+		err = errors.New("Null policy id")
+		return
+	}
+	policy, err = (*GLOB.DSP).GetExclusionPolicy(policyID)
+	return
+}
+
+func StoreExclusionPolicy(policy storage.ExclusionPolicy) (err error) {
+	err = (*GLOB.DSP).StoreExclusionPolicy(policy)
+	return
+}
+
+// CreateExclusionPolicy - will create an exclusion policy
+func CreateExclusionPolicy(p presentation.RawExclusionPolicy) (pb model.Passback) {
+	policy := p.NewExclusionPolicy()
+	err := ValidateExclusionPolicy(&policy)
+	if err != nil {
+		pb = model.BuildErrorPassback(http.StatusBadRequest, err)
+		return
+	}
+	err = StoreExclusionPolicy(policy)
+	if err == nil {
+		pb = model.BuildSuccessPassback(http.StatusOK, storage.ExclusionPolicyID{PolicyID: policy.PolicyID})
+	} else {
+		pb = model.BuildErrorPassback(http.StatusInternalServerError, err)
+	}
+	return
+}
+
+// GetExclusionPolicies - returns all exclusion policies
+func GetExclusionPolicies() (pb model.Passback) {
+	policies := presentation.ExclusionPolicies{ExclusionPolicies: []presentation.ExclusionPolicyMarshaled{}}
+	stored, err := GetStoredExclusionPolicies()
+	if err == nil {
+		for _, p := range stored {
+			policies.ExclusionPolicies = append(policies.ExclusionPolicies, presentation.ToExclusionPolicyMarshaled(p))
+		}
+		pb = model.BuildSuccessPassback(http.StatusOK, policies)
+	} else {
+		pb = model.BuildErrorPassback(http.StatusInternalServerError, err)
+	}
+	return pb
+}
+
+// GetExclusionPolicy - returns an exclusion policy by policyID
+func GetExclusionPolicy(policyID uuid.UUID) (pb model.Passback) {
+	policy, err := GetStoredExclusionPolicy(policyID)
+	if err == nil {
+		pb = model.BuildSuccessPassback(http.StatusOK, presentation.ToExclusionPolicyMarshaled(policy))
+	} else {
+		pb = model.BuildErrorPassback(http.StatusNotFound, err)
+	}
+	return pb
+}
+
+// UpdateExclusionPolicy - create or replace an exclusion policy
+func UpdateExclusionPolicy(p presentation.RawExclusionPolicy, policyID uuid.UUID) (pb model.Passback) {
+	policy := p.NewExclusionPolicy()
+	policy.PolicyID = policyID
+	err := ValidateExclusionPolicy(&policy)
+	if err != nil {
+		pb = model.BuildErrorPassback(http.StatusBadRequest, err)
+		return
+	}
+	status := http.StatusOK
+	if existing, err := GetStoredExclusionPolicy(policyID); err != nil {
+		status = http.StatusCreated
+	} else {
+		policy.CreateTime = existing.CreateTime
+	}
+	err = StoreExclusionPolicy(policy)
+	if err != nil {
+		pb = model.BuildErrorPassback(http.StatusInternalServerError, err)
+		return
+	}
+	pb = model.BuildSuccessPassback(status, nil)
+	return pb
+}
+
+// DeleteExclusionPolicy - deletes an exclusion policy
+func DeleteExclusionPolicy(policyID uuid.UUID) (pb model.Passback) {
+	_, err := GetStoredExclusionPolicy(policyID)
+	if err != nil {
+		logrus.Error(err)
+		pb = model.BuildErrorPassback(http.StatusNotFound, err)
+		return pb
+	}
+	err = (*GLOB.DSP).DeleteExclusionPolicy(policyID)
+	if err == nil {
+		pb = model.BuildSuccessPassback(http.StatusNoContent, nil)
+		return pb
+	}
+	pb = model.BuildErrorPassback(http.StatusInternalServerError, err)
+	return pb
+}
+
+// MigrateNodeBlacklist - keeps the roles of the node_blacklist flag excluded, as a policy that can be managed through
+// the API from now on.  The flag is migrated once; after that the policy is what counts, and edits made to it
+// through the API are not overwritten on the next start.
+func MigrateNodeBlacklist(roles []string) (err error) {
+	if _, err := GetStoredExclusionPolicy(NodeBlacklistPolicyID); err == nil {
+		logrus.WithField("policyID", NodeBlacklistPolicyID.String()).Warn("node_blacklist is ignored, it was migrated " +
+			"into an exclusion policy already; manage the roles through /exclusionpolicies and remove the flag")
+		return nil
+	}
+	raw := presentation.RawExclusionPolicy{
+		Description: "migrated from the node_blacklist flag",
+		Roles:       roles,
+	}
+	policy := raw.NewExclusionPolicy()
+	policy.PolicyID = NodeBlacklistPolicyID
+	if err = ValidateExclusionPolicy(&policy); err != nil {
+		return err
+	}
+	return StoreExclusionPolicy(policy)
+}
+
+// exclusionData -> what HSM knows about the devices of a plan, for the policies that look beyond the operation
+type exclusionData struct {
+	nodes      map[string][]*base.Component //by the BMC that manages them
+	groups     map[string]map[string]bool   //group -> xnames of its members
+	partitions map[string]map[string]bool   //partition -> xnames of its members
+}
+
+func loadExclusionData(policies []storage.ExclusionPolicy) (data exclusionData, err error) {
+	data = exclusionData{
+		nodes:      make(map[string][]*base.Component),
+		groups:     make(map[string]map[string]bool),
+		partitions: make(map[string]map[string]bool),
+	}
+	needed := false
+	for _, policy := range policies {
+		needed = needed || policy.NeedsComponents()
+		for _, group := range policy.Groups {
+			data.groups[group] = nil
+		}
+		for _, partition := range policy.Partitions {
+			data.partitions[partition] = nil
+		}
+	}
+	if !needed {
+		return
+	}
+
+	var emptyArray []string
+	nodes, err := (*GLOB.HSM).GetStateComponents(emptyArray, emptyArray, emptyArray, []string{xnametypes.Node.String()})
+	if err != nil {
+		return
+	}
+	for _, node := range nodes.Components {
+		parent := xnametypes.GetHMSCompParent(node.ID)
+		data.nodes[parent] = append(data.nodes[parent], node)
+	}
+	members := func(partitions []string, groups []string) (set map[string]bool, err error) {
+		set = make(map[string]bool)
+		components, err := (*GLOB.HSM).GetStateComponents(emptyArray, partitions, groups, emptyArray)
+		for _, component := range components.Components {
+			set[component.ID] = true
+		}
+		return
+	}
+	for group := range data.groups {
+		if data.groups[group], err = members(emptyArray, []string{group}); err != nil {
+			return
+		}
+	}
+	for partition := range data.partitions {
+		if data.partitions[partition], err = members([]string{partition}, emptyArray); err != nil {
+			return
+		}
+	}
+	return
+}
+
+// policyExcludes -> true if the policy covers the operation.  Roles and memberships are those of the device itself
+// and of the nodes it manages.
+func policyExcludes(policy storage.ExclusionPolicy, operation storage.Operation, data exclusionData) bool {
+	if !policy.MatchesTarget(operation.Xname, operation.Manufacturer, operation.Model, operation.Target, operation.TargetName) {
+		return false
+	}
+	xname := xnametypes.NormalizeHMSCompID(operation.Xname)
+	subjects := append([]*base.Component{{ID: xname, Role: operation.HsmData.Role}}, data.nodes[xname]...)
+
+	matches := func(values []string, field func(c *base.Component) string) bool {
+		if len(values) == 0 {
+			return true
+		}
+		for _, c := range subjects {
+			for _, v := range values {
+				if field(c) != "" && strings.EqualFold(v, field(c)) {
+					return true
+				}
+			}
+		}
+		return false
+	}
+	memberOf := func(names []string, sets map[string]map[string]bool) bool {
+		if len(names) == 0 {
+			return true
+		}
+		for _, c := range subjects {
+			for _, name := range names {
+				if sets[name][c.ID] {
+					return true
+				}
+			}
+		}
+		return false
+	}
+	return matches(policy.Roles, func(c *base.Component) string { return c.Role }) &&
+		matches(policy.SubRoles, func(c *base.Component) string { return c.SubRole }) &&
+		memberOf(policy.Groups, data.groups) &&
+		memberOf(policy.Partitions, data.partitions)
+}
+
+// ApplyExclusionPolicies -> sets the operations on devices an exclusion policy covers to noSolution.  If HSM cannot
+// tell the roles or memberships a policy asks for, the devices the rest of that policy covers are excluded as well;
+// better to leave a device alone than to update one that must not be.
+func ApplyExclusionPolicies(candidateOperations *map[uuid.UUID]storage.Operation, deviceMap *map[string]storage.Device) (errs []string) {
+	policies, err := GetStoredExclusionPolicies()
+	if err != nil {
+		logrus.Error(err)
+		return []string{"could not get the exclusion policies: " + err.Error()}
+	}
+	if len(policies) == 0 {
+		return
+	}
+	data, dataErr := loadExclusionData(policies)
+	if dataErr != nil {
+		logrus.WithField("ERROR", dataErr).Error("Could not get the HSM data of the exclusion policies")
+		errs = append(errs, "could not get the HSM data of the exclusion policies: "+dataErr.Error())
+	}
+
+	for operationID, operation := range *candidateOperations {
+		if !operation.State.Can("configure") {
+			continue
+		}
+		for _, policy := range policies {
+			unknown := dataErr != nil && policy.NeedsComponents()
+			if unknown && !policy.MatchesTarget(operation.Xname, operation.Manufacturer, operation.Model, operation.Target, operation.TargetName) {
+				continue
+			}
+			if !unknown && !policyExcludes(policy, operation, data) {
+				continue
+			}
+			SetFirmwareVersion(&operation, deviceMap)
+			operation.State.Event(context.Background(), "nosol")
+			operation.EndTime.Scan(time.Now())
+			operation.StateHelper = "excluded by policy " + policy.PolicyID.String()
+			if policy.Description != "" {
+				operation.StateHelper += " (" + policy.Description + ")"
+			}
+			if unknown {
+				operation.StateHelper += ", it could not be evaluated"
+			}
+			operation.FailureCode = storage.FailureExcluded
+			(*candidateOperations)[operationID] = operation
+			break
+		}
+	}
+	return errs
+}
@@ -0,0 +1,172 @@
+/*
+ * MIT License
+ *
+ * (C) Copyright [2026] Hewlett Packard Enterprise Development LP
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a
+ * copy of this software and associated documentation files (the "Software"),
+ * to deal in the Software without restriction, including without limitation
+ * the rights to use, copy, modify, merge, publish, distribute, sublicense,
+ * and/or sell copies of the Software, and to permit persons to whom the
+ * Software is furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included
+ * in all copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL
+ * THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+ * OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+ * ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+ * OTHER DEALINGS IN THE SOFTWARE.
+ */
+
+package domain
+
+import (
+	"net/http"
+	"testing"
+
+	base "github.com/Cray-HPE/hms-base/v2"
+	"github.com/Cray-HPE/hms-firmware-action/internal/presentation"
+	"github.com/Cray-HPE/hms-firmware-action/internal/storage"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/suite"
+)
+
+type Exclusion_Policies_TS struct {
+	suite.Suite
+}
+
+func helperExclusionOperation(xname string, target string) storage.Operation {
+	operation := storage.HelperGetStockOperation()
+	operation.Xname = xname
+	operation.Target = target
+	operation.Manufacturer = "cray"
+	operation.Model = "WindomNodeCard_REV_D"
+	return operation
+}
+
+func (suite *Exclusion_Policies_TS) Test_MatchesTarget() {
+	policy := storage.ExclusionPolicy{Xnames: []string{"x3000c0s*b0"}, Targets: []string{"BIOS"}}
+	suite.True(policy.MatchesTarget("x3000c0s17b0", "cray", "", "BIOS", ""))
+	suite.True(policy.MatchesTarget("X3000C0S17B0", "cray", "", "other", "BIOS"))
+	suite.False(policy.MatchesTarget("x3000c0s17b0", "cray", "", "BMC", ""))
+	suite.False(policy.MatchesTarget("x1000c0s1b0", "cray", "", "BIOS", ""))
+
+	policy = storage.ExclusionPolicy{Manufacturer: "Cray", Models: []string{"WindomNodeCard_REV_D"}}
+	suite.True(policy.MatchesTarget("x1000c0s1b0", "cray", "WindomNodeCard_REV_D", "BMC", ""))
+	suite.False(policy.MatchesTarget("x1000c0s1b0", "gigabyte", "WindomNodeCard_REV_D", "BMC", ""))
+	suite.False(policy.MatchesTarget("x1000c0s1b0", "cray", "other", "BMC", ""))
+}
+
+func (suite *Exclusion_Policies_TS) Test_PolicyExcludes_Components() {
+	data := exclusionData{
+		nodes: map[string][]*base.Component{
+			"x3000c0s1b0": {{ID: "x3000c0s1b0n0", Role: "Management", SubRole: "Master"}},
+			"x3000c0s2b0": {{ID: "x3000c0s2b0n0", Role: "Compute"}},
+		},
+		groups:     map[string]map[string]bool{"blue": {"x3000c0s2b0n0": true}},
+		partitions: map[string]map[string]bool{"p1": {"x3000c0s2b0": true}},
+	}
+	management := helperExclusionOperation("x3000c0s1b0", "BMC")
+	compute := helperExclusionOperation("x3000c0s2b0", "BMC")
+
+	//the role of the node is the role of its BMC
+	policy := storage.ExclusionPolicy{Roles: []string{"management"}}
+	suite.True(policyExcludes(policy, management, data))
+	suite.False(policyExcludes(policy, compute, data))
+
+	policy = storage.ExclusionPolicy{Roles: []string{"Management"}, SubRoles: []string{"Worker"}}
+	suite.False(policyExcludes(policy, management, data))
+
+	policy = storage.ExclusionPolicy{Groups: []string{"blue"}}
+	suite.False(policyExcludes(policy, management, data))
+	suite.True(policyExcludes(policy, compute, data))
+
+	policy = storage.ExclusionPolicy{Partitions: []string{"p1"}, Targets: []string{"BIOS"}}
+	suite.False(policyExcludes(policy, compute, data))
+	compute.Target = "BIOS"
+	suite.True(policyExcludes(policy, compute, data))
+
+	//a BMC that reports a role itself
+	policy = storage.ExclusionPolicy{Roles: []string{"Storage"}}
+	other := helperExclusionOperation("x3000c0s3b0", "BMC")
+	other.HsmData.Role = "Storage"
+	suite.True(policyExcludes(policy, other, data))
+}
+
+func (suite *Exclusion_Policies_TS) Test_ApplyExclusionPolicies() {
+	policy := storage.ExclusionPolicy{PolicyID: uuid.New(), Description: "leave the river nodes alone",
+		Xnames: []string{"x3000c0s*b0"}}
+	suite.True(StoreExclusionPolicy(policy) == nil)
+	defer DeleteExclusionPolicy(policy.PolicyID)
+
+	excluded := helperExclusionOperation("x3000c0s5b0", "BMC")
+	kept := helperExclusionOperation("x1000c0s5b0", "BMC")
+	candidates := map[uuid.UUID]storage.Operation{excluded.OperationID: excluded, kept.OperationID: kept}
+	deviceMap := map[string]storage.Device{
+		"x3000c0s5b0": {Xname: "x3000c0s5b0", Targets: []storage.Target{{Name: "BMC", FirmwareVersion: "1.0.0"}}},
+	}
+
+	errs := ApplyExclusionPolicies(&candidates, &deviceMap)
+	suite.Empty(errs)
+	suite.True(candidates[excluded.OperationID].State.Is("noSolution"))
+	suite.Equal(storage.FailureExcluded, candidates[excluded.OperationID].FailureCode)
+	suite.Contains(candidates[excluded.OperationID].StateHelper, policy.PolicyID.String())
+	suite.Contains(candidates[excluded.OperationID].StateHelper, "leave the river nodes alone")
+	suite.Equal("1.0.0", candidates[excluded.OperationID].FromFirmwareVersion)
+	suite.True(candidates[kept.OperationID].State.Is("initial"))
+}
+
+func (suite *Exclusion_Policies_TS) Test_ValidateExclusionPolicy() {
+	policy := storage.ExclusionPolicy{PolicyID: uuid.New()}
+	suite.NotNil(ValidateExclusionPolicy(&policy))
+	policy.Xnames = []string{"x3000c0s[b0"}
+	suite.NotNil(ValidateExclusionPolicy(&policy))
+	policy.Xnames = []string{"x3000c0s*b0"}
+	suite.Nil(ValidateExclusionPolicy(&policy))
+	policy.PolicyID = uuid.Nil
+	suite.NotNil(ValidateExclusionPolicy(&policy))
+}
+
+func (suite *Exclusion_Policies_TS) Test_ExclusionPolicy_CRUD() {
+	pb := CreateExclusionPolicy(presentation.RawExclusionPolicy{Roles: []string{"Management"}})
+	suite.False(pb.IsError)
+	policyID := pb.Obj.(storage.ExclusionPolicyID).PolicyID
+
+	pb = UpdateExclusionPolicy(presentation.RawExclusionPolicy{Roles: []string{"Management"}, SubRoles: []string{"Master"}}, policyID)
+	suite.Equal(http.StatusOK, pb.StatusCode)
+	pb = GetExclusionPolicy(policyID)
+	suite.False(pb.IsError)
+	suite.Equal([]string{"Master"}, pb.Obj.(presentation.ExclusionPolicyMarshaled).SubRoles)
+
+	pb = CreateExclusionPolicy(presentation.RawExclusionPolicy{})
+	suite.Equal(http.StatusBadRequest, pb.StatusCode)
+
+	suite.Equal(http.StatusNoContent, DeleteExclusionPolicy(policyID).StatusCode)
+	suite.Equal(http.StatusNotFound, DeleteExclusionPolicy(policyID).StatusCode)
+}
+
+func (suite *Exclusion_Policies_TS) Test_MigrateNodeBlacklist() {
+	suite.Nil(MigrateNodeBlacklist([]string{"Management"}))
+	first, err := GetStoredExclusionPolicy(NodeBlacklistPolicyID)
+	suite.Nil(err)
+	suite.Equal([]string{"Management"}, first.Roles)
+
+	//once migrated the policy is managed through the API, a restart with the flag still set leaves it alone
+	first.Roles = []string{"Management", "Application"}
+	suite.Nil(StoreExclusionPolicy(first))
+	suite.Nil(MigrateNodeBlacklist([]string{"Management", "Storage"}))
+	second, err := GetStoredExclusionPolicy(NodeBlacklistPolicyID)
+	suite.Nil(err)
+	suite.Equal([]string{"Management", "Application"}, second.Roles)
+	suite.True(first.CreateTime.Time.Equal(second.CreateTime.Time))
+	_ = DeleteExclusionPolicy(NodeBlacklistPolicyID)
+}
+
+func Test_Domain_Exclusion_Policies(t *testing.T) {
+	ConfigureSystemForUnitTesting()
+	suite.Run(t, new(Exclusion_Policies_TS))
+}
@@ -0,0 +1,90 @@
+/*
+ * MIT License
+ *
+ * (C) Copyright [2026] Hewlett Packard Enterprise Development LP
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a
+ * copy of this software and associated documentation files (the "Software"),
+ * to deal in the Software without restriction, including without limitation
+ * the rights to use, copy, modify, merge, publish, distribute, sublicense,
+ * and/or sell copies of the Software, and to permit persons to whom the
+ * Software is furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included
+ * in all copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL
+ * THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+ * OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+ * ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+ * OTHER DEALINGS IN THE SOFTWARE.
+ */
+
+package domain
+
+import (
+	"errors"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+)
+
+// FileCheck -> turns an s3 or tftp image location into the URL the BMC downloads from, and makes sure an s3 or http
+// file is there.  tftp cannot be checked.
+func FileCheck(fileLocation string) (returnLocation string, err error) {
+	returnLocation = fileLocation
+	URL, err := url.Parse(fileLocation)
+	if err != nil {
+		return returnLocation, err
+	}
+
+	if strings.ToLower(URL.Scheme) == "s3" {
+
+		bucket := URL.Host //this helps us capture the bucket name // fw-update in s3://fw-update/f1.1123.24.xz.iso
+
+		s3endpoint, err := url.Parse(GLOB.S3Endpoint)
+		if err != nil {
+			return returnLocation, err
+		}
+
+		URL.Host = s3endpoint.Host //ex: http://rgw.local:8080
+		URL.Scheme = s3endpoint.Scheme
+		URL.Path = bucket + URL.Path
+
+		returnLocation = URL.String()
+	} else if strings.ToLower(URL.Scheme) == "tftp" {
+		bucket := URL.Host //this helps us capture the bucket name // fw-update in s3://fw-update/f1.1123.24.xz.iso
+		tftpEndpoint, err := url.Parse(GLOB.TFTPEndpoint)
+		if err != nil {
+			return returnLocation, err
+		}
+		URL.Host = tftpEndpoint.Host
+		URL.Scheme = tftpEndpoint.Scheme
+		URL.Path = bucket + URL.Path
+
+		returnLocation = URL.String()
+		// Cannot check for file with tftp, so just return
+		return returnLocation, err
+	}
+	//else the scheme is http
+
+	// Comment this next section out for testing without S3 buckets
+	// DO NOT RELEASE with this section commented out!
+	logrus.WithFields(logrus.Fields{"URL": returnLocation}).Debug("GETTING HEAD of FILE")
+	response, err := http.Head(returnLocation)
+	if err != nil {
+		logrus.Error(err)
+		return returnLocation, err
+	}
+	defer response.Body.Close()
+	if response.StatusCode != http.StatusOK {
+		err = errors.New("unexpected status code; could not find file via HEAD")
+		return returnLocation, err
+	}
+
+	return returnLocation, nil
+}
@@ -40,18 +40,26 @@ func Init(glob *DOMAIN_GLOBALS) {
 }
 
 type DOMAIN_GLOBALS struct {
-	CAUri             string
-	BaseTRSTask       *trs_http_api.HttpTask
-	RFTloc            *trs_http_api.TrsAPI
-	HSMTloc           *trs_http_api.TrsAPI
-	RFClientLock      *sync.RWMutex
-	Running           *bool
-	DSP               *storage.StorageProvider
-	HSM               *hsm.HSMProvider
-	RFHttpClient      *hms_certs.HTTPClientPair
-	SVCHttpClient     *hms_certs.HTTPClientPair
-	RFTransportReady  *bool
-	DaysToKeepActions int
+	CAUri               string
+	BaseTRSTask         *trs_http_api.HttpTask
+	RFTloc              *trs_http_api.TrsAPI
+	HSMTloc             *trs_http_api.TrsAPI
+	RFClientLock        *sync.RWMutex
+	Running             *bool
+	DSP                 *storage.StorageProvider
+	ASP                 *storage.ArchiveProvider //nil when archiving is disabled
+	HSM                 *hsm.HSMProvider
+	RFHttpClient        *hms_certs.HTTPClientPair
+	SVCHttpClient       *hms_certs.HTTPClientPair
+	RFTransportReady    *bool
+	DaysToKeepActions   int
+	EventDestination    string       //base URL BMCs post Redfish events to; no subscriptions are made when empty
+	RFLimiter           *HostLimiter //caps the Redfish requests per BMC and chassis; nil means no caps
+	Hooks               []Hook       //run at action and operation boundaries
+	PowerClient         PowerClient  //resets devices after an update; nil leaves the reboot of needManualReboot images to the admin
+	S3Endpoint          string       //where s3:// image URLs are fetched from
+	TFTPEndpoint        string       //where tftp:// image URLs are fetched from
+	QuarantineThreshold int          //consecutive failures that quarantine a device; 0 never quarantines
 }
 
 func (g *DOMAIN_GLOBALS) NewGlobals(base *trs_http_api.HttpTask,
@@ -0,0 +1,310 @@
+/*
+ * MIT License
+ *
+ * (C) Copyright [2026] Hewlett Packard Enterprise Development LP
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a
+ * copy of this software and associated documentation files (the "Software"),
+ * to deal in the Software without restriction, including without limitation
+ * the rights to use, copy, modify, merge, publish, distribute, sublicense,
+ * and/or sell copies of the Software, and to permit persons to whom the
+ * Software is furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included
+ * in all copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL
+ * THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+ * OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+ * ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+ * OTHER DEALINGS IN THE SOFTWARE.
+ */
+
+package domain
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"os/exec"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Cray-HPE/hms-firmware-action/internal/storage"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+)
+
+// Hook -> a site provided step run at an action or operation boundary, e.g. draining a node from the workload manager
+// before it is flashed.  A hook is either a local executable, which gets the HookContext on stdin, or an http callout
+// the HookContext is POSTed to.  Hooks are read from the file named by FAS_HOOKS_FILE.
+type Hook struct {
+	Name           string   `json:"name"`
+	Point          string   `json:"point"`                    //preAction, postAction, preOperation or postOperation
+	Command        []string `json:"command,omitempty"`        //executable and its arguments; exit 0 is success
+	URL            string   `json:"url,omitempty"`            //any 2xx response is success
+	TimeoutSeconds int      `json:"timeoutSeconds,omitempty"` //DefaultHookTimeoutSeconds when 0, at most MaxHookTimeoutSeconds
+	FailState      string   `json:"failState,omitempty"`      //preOperation only: failed (default) or noSolution
+}
+
+// HookContext -> what a hook is told about the action or operation it runs for
+type HookContext struct {
+	Point               string          `json:"point"`
+	ActionID            uuid.UUID       `json:"actionID"`
+	OperationID         uuid.UUID       `json:"operationID"`
+	Xname               string          `json:"xname,omitempty"`
+	DeviceType          string          `json:"deviceType,omitempty"`
+	Target              string          `json:"target,omitempty"`
+	FromFirmwareVersion string          `json:"fromFirmwareVersion,omitempty"`
+	ToFirmwareVersion   string          `json:"toFirmwareVersion,omitempty"`
+	State               string          `json:"state"`
+	StateHelper         string          `json:"stateHelper,omitempty"`
+	Command             storage.Command `json:"command"`
+}
+
+const (
+	DefaultHookTimeoutSeconds = 300
+	MaxHookTimeoutSeconds     = 1800
+	// a hook result is stored on the operation, so only the tail of a chatty hook is kept
+	HookOutputLimit = 1024
+)
+
+// LoadHooks -> reads and validates the hook configuration, a json list of hooks
+func LoadHooks(path string) (hooks []Hook, err error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	if err = json.Unmarshal(data, &hooks); err != nil {
+		return nil, err
+	}
+	for i := range hooks {
+		if err = ValidateHook(&hooks[i]); err != nil {
+			return nil, errors.New("hook " + strconv.Itoa(i) + ": " + err.Error())
+		}
+	}
+	return hooks, nil
+}
+
+func ValidateHook(h *Hook) (err error) {
+	if h.Name == "" {
+		return errors.New("name is required")
+	}
+	switch h.Point {
+	case storage.HookPreAction, storage.HookPostAction, storage.HookPreOperation, storage.HookPostOperation:
+	default:
+		return errors.New("point must be " + storage.HookPreAction + ", " + storage.HookPostAction + ", " +
+			storage.HookPreOperation + " or " + storage.HookPostOperation)
+	}
+	if (len(h.Command) > 0) == (h.URL != "") {
+		return errors.New("set exactly one of command and url")
+	}
+	if h.TimeoutSeconds < 0 {
+		return errors.New("timeoutSeconds cannot be negative")
+	}
+	if h.TimeoutSeconds > MaxHookTimeoutSeconds {
+		return errors.New("timeoutSeconds cannot be more than " + strconv.Itoa(MaxHookTimeoutSeconds))
+	}
+	if h.FailState == "" {
+		h.FailState = "failed"
+	} else if h.FailState != "failed" && h.FailState != "noSolution" {
+		return errors.New("failState must be failed or noSolution")
+	}
+	return nil
+}
+
+func ActionHookContext(point string, action storage.Action) HookContext {
+	return HookContext{
+		Point:    point,
+		ActionID: action.ActionID,
+		State:    action.State.Current(),
+		Command:  action.Command,
+	}
+}
+
+func OperationHookContext(point string, operation storage.Operation, command storage.Command, toFirmwareVersion string) HookContext {
+	return HookContext{
+		Point:               point,
+		ActionID:            operation.ActionID,
+		OperationID:         operation.OperationID,
+		Xname:               operation.Xname,
+		DeviceType:          operation.DeviceType,
+		Target:              operation.Target,
+		FromFirmwareVersion: operation.FromFirmwareVersion,
+		ToFirmwareVersion:   toFirmwareVersion,
+		State:               operation.State.Current(),
+		StateHelper:         operation.StateHelper,
+		Command:             command,
+	}
+}
+
+// HooksRan -> true once a hook of the point has a result, so a relaunched operation does not run it twice
+func HooksRan(results []storage.HookResult, point string) bool {
+	for _, result := range results {
+		if result.Point == point {
+			return true
+		}
+	}
+	return false
+}
+
+// HookFailState -> the state a failed pre-operation hook leaves the operation in
+func HookFailState(name string) string {
+	for _, h := range GLOB.Hooks {
+		if h.Point == storage.HookPreOperation && h.Name == name {
+			return h.FailState
+		}
+	}
+	return "failed"
+}
+
+// RunHooks -> runs the hooks of a point in the order they are configured.  A failing pre hook stops the run, as
+// whatever follows it should not happen; post hooks all run, and the first failure is returned.
+func RunHooks(point string, hookContext HookContext) (results []storage.HookResult, err error) {
+	payload, err := json.Marshal(hookContext)
+	if err != nil {
+		return nil, err
+	}
+	for _, h := range GLOB.Hooks {
+		if h.Point != point {
+			continue
+		}
+		result := runHook(h, payload)
+		results = append(results, result)
+		fields := logrus.Fields{"hook": h.Name, "point": point, "actionID": hookContext.ActionID, "operationID": hookContext.OperationID}
+		if result.Success {
+			logrus.WithFields(fields).Debug("hook succeeded")
+			continue
+		}
+		logrus.WithFields(fields).WithField("output", result.Output).Warn("hook failed")
+		if err == nil {
+			err = errors.New(point + " hook " + h.Name + " failed: " + result.Output)
+		}
+		if point == storage.HookPreAction || point == storage.HookPreOperation {
+			return results, err
+		}
+	}
+	return results, err
+}
+
+// actionHookRun -> the hooks of one action point, running off the control loop
+type actionHookRun struct {
+	done bool
+	err  error
+}
+
+// action hooks can take minutes, so they run in the background and the control loop polls them on every pass
+var actionHookLock sync.Mutex
+var actionHookRuns = make(map[string]*actionHookRun)
+
+func actionHookKey(point string, actionID uuid.UUID) string {
+	return point + "/" + actionID.String()
+}
+
+// PollActionHooks -> starts the hooks of an action point on the first call; once they have finished, a call reports
+// done and the error RunHooks returned.  A point without hooks is done right away.
+func PollActionHooks(point string, hookContext HookContext) (done bool, err error) {
+	hasHooks := false
+	for _, h := range GLOB.Hooks {
+		hasHooks = hasHooks || h.Point == point
+	}
+	if !hasHooks {
+		return true, nil
+	}
+
+	key := actionHookKey(point, hookContext.ActionID)
+	actionHookLock.Lock()
+	defer actionHookLock.Unlock()
+	run, ok := actionHookRuns[key]
+	if !ok {
+		run = &actionHookRun{}
+		actionHookRuns[key] = run
+		go func() {
+			_, err := RunHooks(point, hookContext)
+			actionHookLock.Lock()
+			run.done, run.err = true, err
+			actionHookLock.Unlock()
+		}()
+		return false, nil
+	}
+	if !run.done {
+		return false, nil
+	}
+	delete(actionHookRuns, key)
+	return true, run.err
+}
+
+// ActionHooksPending -> true between the first PollActionHooks of the point and the one that reports done
+func ActionHooksPending(point string, actionID uuid.UUID) bool {
+	actionHookLock.Lock()
+	defer actionHookLock.Unlock()
+	_, ok := actionHookRuns[actionHookKey(point, actionID)]
+	return ok
+}
+
+func runHook(h Hook, payload []byte) (result storage.HookResult) {
+	result.Name = h.Name
+	result.Point = h.Point
+	result.Time = time.Now()
+	timeout := time.Duration(h.TimeoutSeconds) * time.Second
+	if timeout == 0 {
+		timeout = DefaultHookTimeoutSeconds * time.Second
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	var output []byte
+	var err error
+	if len(h.Command) > 0 {
+		cmd := exec.CommandContext(ctx, h.Command[0], h.Command[1:]...)
+		cmd.Stdin = bytes.NewReader(payload)
+		cmd.Env = append(os.Environ(), "FAS_HOOK_POINT="+h.Point)
+		output, err = cmd.CombinedOutput()
+	} else {
+		output, err = postHook(ctx, h.URL, payload)
+	}
+	result.Duration = time.Since(result.Time).Round(time.Millisecond).String()
+	result.Output = strings.TrimSpace(string(output))
+	if err != nil {
+		if result.Output != "" {
+			result.Output = err.Error() + ": " + result.Output
+		} else {
+			result.Output = err.Error()
+		}
+	}
+	if len(result.Output) > HookOutputLimit {
+		result.Output = result.Output[len(result.Output)-HookOutputLimit:]
+	}
+	result.Success = err == nil
+	return result
+}
+
+func postHook(ctx context.Context, url string, payload []byte) (body []byte, err error) {
+	if GLOB.SVCHttpClient == nil {
+		return nil, errors.New("no service client")
+	}
+	req, err := http.NewRequest(http.MethodPost, url, bytes.NewReader(payload))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req = req.WithContext(ctx)
+	resp, err := GLOB.SVCHttpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	body, _ = ioutil.ReadAll(resp.Body)
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return body, errors.New("status " + strconv.Itoa(resp.StatusCode))
+	}
+	return body, nil
+}
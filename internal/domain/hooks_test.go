@@ -0,0 +1,176 @@
+/*
+ * MIT License
+ *
+ * (C) Copyright [2026] Hewlett Packard Enterprise Development LP
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a
+ * copy of this software and associated documentation files (the "Software"),
+ * to deal in the Software without restriction, including without limitation
+ * the rights to use, copy, modify, merge, publish, distribute, sublicense,
+ * and/or sell copies of the Software, and to permit persons to whom the
+ * Software is furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included
+ * in all copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL
+ * THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+ * OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+ * ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+ * OTHER DEALINGS IN THE SOFTWARE.
+ */
+
+package domain
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Cray-HPE/hms-firmware-action/internal/storage"
+	"github.com/stretchr/testify/suite"
+)
+
+type Hooks_TS struct {
+	suite.Suite
+}
+
+func (suite *Hooks_TS) TearDownTest() {
+	GLOB.Hooks = nil
+}
+
+func (suite *Hooks_TS) Test_LoadHooks() {
+	dir := suite.T().TempDir()
+	path := filepath.Join(dir, "hooks.json")
+	data := `[{"name": "drain", "point": "preOperation", "command": ["/usr/local/bin/drain"], "failState": "noSolution"},
+		{"name": "notify", "point": "postAction", "url": "http://monitor/fas"}]`
+	suite.True(ioutil.WriteFile(path, []byte(data), 0600) == nil)
+	hooks, err := LoadHooks(path)
+	suite.True(err == nil)
+	suite.Equal(2, len(hooks))
+	suite.Equal("noSolution", hooks[0].FailState)
+	suite.Equal("failed", hooks[1].FailState)
+
+	for _, bad := range []string{
+		`[{"name": "drain", "point": "duringOperation", "command": ["/bin/true"]}]`,
+		`[{"name": "drain", "point": "preOperation"}]`,
+		`[{"name": "drain", "point": "preOperation", "command": ["/bin/true"], "url": "http://monitor"}]`,
+		`[{"point": "preOperation", "command": ["/bin/true"]}]`,
+		`[{"name": "drain", "point": "preOperation", "command": ["/bin/true"], "failState": "succeeded"}]`,
+		`{"name": "drain"}`,
+		`[{"name": "drain", "point": "preOperation", "command": ["/bin/true"], "timeoutSeconds": 1801}]`,
+	} {
+		suite.True(ioutil.WriteFile(path, []byte(bad), 0600) == nil)
+		_, err = LoadHooks(path)
+		suite.True(err != nil, bad)
+	}
+	_, err = LoadHooks(filepath.Join(dir, "missing.json"))
+	suite.True(err != nil)
+}
+
+func (suite *Hooks_TS) Test_RunHooks_Command() {
+	operation := storage.HelperGetStockOperation()
+	operation.Xname = "x1000c0s0b0"
+	hookContext := OperationHookContext(storage.HookPreOperation, operation, storage.Command{}, "2.0.0")
+
+	GLOB.Hooks = []Hook{
+		{Name: "echo", Point: storage.HookPreOperation, Command: []string{"/bin/sh", "-c", "cat; echo; echo $FAS_HOOK_POINT"}},
+		{Name: "refuse", Point: storage.HookPreOperation, Command: []string{"/bin/sh", "-c", "echo busy; exit 3"}},
+		{Name: "never", Point: storage.HookPreOperation, Command: []string{"/bin/true"}},
+		{Name: "other", Point: storage.HookPostOperation, Command: []string{"/bin/true"}},
+	}
+	results, err := RunHooks(storage.HookPreOperation, hookContext)
+	suite.True(err != nil)
+	suite.True(strings.Contains(err.Error(), "refuse"))
+	suite.Equal(2, len(results)) //a failing pre hook stops the run
+	suite.True(results[0].Success)
+	suite.True(strings.Contains(results[0].Output, `"xname":"x1000c0s0b0"`))
+	suite.True(strings.Contains(results[0].Output, `"toFirmwareVersion":"2.0.0"`))
+	suite.True(strings.HasSuffix(results[0].Output, storage.HookPreOperation))
+	suite.False(results[1].Success)
+	suite.True(strings.Contains(results[1].Output, "busy"))
+	suite.True(HooksRan(results, storage.HookPreOperation))
+	suite.False(HooksRan(results, storage.HookPostOperation))
+
+	GLOB.Hooks = []Hook{
+		{Name: "slow", Point: storage.HookPostOperation, Command: []string{"/bin/sleep", "5"}, TimeoutSeconds: 1},
+		{Name: "fine", Point: storage.HookPostOperation, Command: []string{"/bin/true"}},
+	}
+	results, err = RunHooks(storage.HookPostOperation, hookContext)
+	suite.True(err != nil)
+	suite.Equal(2, len(results)) //post hooks all run
+	suite.False(results[0].Success)
+	suite.True(results[1].Success)
+}
+
+func (suite *Hooks_TS) Test_RunHooks_URL() {
+	var received HookContext
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		_ = json.NewDecoder(req.Body).Decode(&received)
+		if strings.HasSuffix(req.URL.Path, "/deny") {
+			w.WriteHeader(http.StatusConflict)
+			_, _ = w.Write([]byte("node still has jobs"))
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	action := storage.HelperGetStockAction()
+	GLOB.Hooks = []Hook{{Name: "notify", Point: storage.HookPreAction, URL: server.URL + "/allow"}}
+	results, err := RunHooks(storage.HookPreAction, ActionHookContext(storage.HookPreAction, action))
+	suite.True(err == nil)
+	suite.Equal(1, len(results))
+	suite.Equal(action.ActionID, received.ActionID)
+	suite.Equal(storage.HookPreAction, received.Point)
+
+	GLOB.Hooks = []Hook{{Name: "notify", Point: storage.HookPreAction, URL: server.URL + "/deny"}}
+	results, err = RunHooks(storage.HookPreAction, ActionHookContext(storage.HookPreAction, action))
+	suite.True(err != nil)
+	suite.True(strings.Contains(results[0].Output, "409"))
+	suite.True(strings.Contains(results[0].Output, "node still has jobs"))
+}
+
+func (suite *Hooks_TS) Test_PollActionHooks() {
+	action := storage.HelperGetStockAction()
+	hookContext := ActionHookContext(storage.HookPreAction, action)
+	done, err := PollActionHooks(storage.HookPreAction, hookContext)
+	suite.True(done)
+	suite.True(err == nil)
+
+	GLOB.Hooks = []Hook{{Name: "drain", Point: storage.HookPreAction, Command: []string{"/bin/sh", "-c", "sleep 1; exit 3"}}}
+	done, err = PollActionHooks(storage.HookPreAction, hookContext)
+	suite.False(done) //the hooks run in the background
+	suite.True(ActionHooksPending(storage.HookPreAction, action.ActionID))
+	suite.False(ActionHooksPending(storage.HookPostAction, action.ActionID))
+	for i := 0; !done && i < 50; i++ {
+		time.Sleep(100 * time.Millisecond)
+		done, err = PollActionHooks(storage.HookPreAction, hookContext)
+	}
+	suite.True(done)
+	suite.True(err != nil)
+	suite.True(strings.Contains(err.Error(), "drain"))
+	suite.False(ActionHooksPending(storage.HookPreAction, action.ActionID))
+}
+
+func (suite *Hooks_TS) Test_RunHooks_None() {
+	results, err := RunHooks(storage.HookPreOperation, HookContext{})
+	suite.True(err == nil)
+	suite.Equal(0, len(results))
+}
+
+func Test_Domain_Hooks(t *testing.T) {
+	if _, err := os.Stat("/bin/sh"); err != nil {
+		t.Skip("no shell to run hooks with")
+	}
+	ConfigureSystemForUnitTesting()
+	suite.Run(t, new(Hooks_TS))
+}
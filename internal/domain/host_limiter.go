@@ -0,0 +1,156 @@
+/*
+ * MIT License
+ *
+ * (C) Copyright [2026] Hewlett Packard Enterprise Development LP
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a
+ * copy of this software and associated documentation files (the "Software"),
+ * to deal in the Software without restriction, including without limitation
+ * the rights to use, copy, modify, merge, publish, distribute, sublicense,
+ * and/or sell copies of the Software, and to permit persons to whom the
+ * Software is furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included
+ * in all copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL
+ * THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+ * OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+ * ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+ * OTHER DEALINGS IN THE SOFTWARE.
+ */
+
+package domain
+
+import (
+	"context"
+	"net"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Cray-HPE/hms-xname/xnametypes"
+)
+
+// LimiterWaitTimeout -> how long a request without a deadline of its own waits for a slot
+const LimiterWaitTimeout = 5 * time.Minute
+
+// HostLimiter -> caps the Redfish requests FAS has in flight to one BMC, to the BMCs of one chassis and overall.
+// A cap of 0 is no cap.  A nil limiter lets everything through.
+type HostLimiter struct {
+	PerHost    int
+	PerChassis int
+	Global     int
+
+	lock    sync.Mutex
+	freed   chan struct{} //closed, and replaced, whenever a slot is handed back
+	hosts   map[string]int
+	chassis map[string]int
+	total   int
+}
+
+func NewHostLimiter(perHost int, perChassis int, global int) *HostLimiter {
+	l := &HostLimiter{
+		PerHost:    perHost,
+		PerChassis: perChassis,
+		Global:     global,
+		hosts:      make(map[string]int),
+		chassis:    make(map[string]int),
+		freed:      make(chan struct{}),
+	}
+	return l
+}
+
+// Acquire -> waits for a free slot to the BMC and returns the func that hands it back.  Gives up with the error of
+// ctx when ctx ends first.
+func (l *HostLimiter) Acquire(ctx context.Context, fqdn string) (release func(), err error) {
+	if l == nil {
+		return func() {}, nil
+	}
+	host, chassis := hostAndChassis(fqdn)
+	for {
+		l.lock.Lock()
+		if l.fits(host, chassis) {
+			release = l.take(host, chassis)
+			l.lock.Unlock()
+			return release, nil
+		}
+		freed := l.freed
+		l.lock.Unlock()
+		select {
+		case <-freed:
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+}
+
+// TryAcquire -> takes a slot to the BMC if one is free right now
+func (l *HostLimiter) TryAcquire(fqdn string) (release func(), ok bool) {
+	if l == nil {
+		return func() {}, true
+	}
+	host, chassis := hostAndChassis(fqdn)
+	l.lock.Lock()
+	defer l.lock.Unlock()
+	if !l.fits(host, chassis) {
+		return nil, false
+	}
+	return l.take(host, chassis), true
+}
+
+func (l *HostLimiter) fits(host string, chassis string) bool {
+	if l.Global > 0 && l.total >= l.Global {
+		return false
+	}
+	if l.PerHost > 0 && l.hosts[host] >= l.PerHost {
+		return false
+	}
+	if chassis != "" && l.PerChassis > 0 && l.chassis[chassis] >= l.PerChassis {
+		return false
+	}
+	return true
+}
+
+// take -> must hold the lock
+func (l *HostLimiter) take(host string, chassis string) (release func()) {
+	l.total++
+	l.hosts[host]++
+	if chassis != "" {
+		l.chassis[chassis]++
+	}
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			l.lock.Lock()
+			l.total--
+			if l.hosts[host]--; l.hosts[host] <= 0 {
+				delete(l.hosts, host)
+			}
+			if chassis != "" {
+				if l.chassis[chassis]--; l.chassis[chassis] <= 0 {
+					delete(l.chassis, chassis)
+				}
+			}
+			close(l.freed)
+			l.freed = make(chan struct{})
+			l.lock.Unlock()
+		})
+	}
+}
+
+// hostAndChassis -> the BMC a request goes to, and its chassis when the hostname is an xname
+func hostAndChassis(fqdn string) (host string, chassis string) {
+	host = strings.ToLower(fqdn)
+	name := host
+	if h, _, err := net.SplitHostPort(name); err == nil {
+		name = h
+	}
+	name = strings.Split(name, ".")[0]
+	if xnametypes.GetHMSType(name) != xnametypes.HMSTypeInvalid {
+		chassis = ancestorOfType(name, xnametypes.Chassis)
+	}
+	return host, chassis
+}
@@ -0,0 +1,166 @@
+/*
+ * MIT License
+ *
+ * (C) Copyright [2026] Hewlett Packard Enterprise Development LP
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a
+ * copy of this software and associated documentation files (the "Software"),
+ * to deal in the Software without restriction, including without limitation
+ * the rights to use, copy, modify, merge, publish, distribute, sublicense,
+ * and/or sell copies of the Software, and to permit persons to whom the
+ * Software is furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included
+ * in all copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL
+ * THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+ * OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+ * ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+ * OTHER DEALINGS IN THE SOFTWARE.
+ */
+
+package domain
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/Cray-HPE/hms-firmware-action/internal/hsm"
+	trs_http_api "github.com/Cray-HPE/hms-trs-app-api/v3/pkg/trs_http_api"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/suite"
+)
+
+type Host_Limiter_TS struct {
+	suite.Suite
+}
+
+func (suite *Host_Limiter_TS) Test_HostAndChassis() {
+	host, chassis := hostAndChassis("X1000c3s5b0.local:443")
+	suite.Equal("x1000c3s5b0.local:443", host)
+	suite.Equal("x1000c3", chassis)
+
+	host, chassis = hostAndChassis("10.254.1.12")
+	suite.Equal("10.254.1.12", host)
+	suite.Equal("", chassis)
+}
+
+func (suite *Host_Limiter_TS) Test_PerHost() {
+	l := NewHostLimiter(2, 0, 0)
+	r1, ok := l.TryAcquire("x1000c0s0b0")
+	suite.True(ok)
+	_, ok = l.TryAcquire("x1000c0s0b0")
+	suite.True(ok)
+	_, ok = l.TryAcquire("x1000c0s0b0")
+	suite.False(ok)
+	_, ok = l.TryAcquire("x1000c0s1b0")
+	suite.True(ok)
+
+	r1()
+	r1() // releasing twice frees one slot only
+	_, ok = l.TryAcquire("x1000c0s0b0")
+	suite.True(ok)
+	_, ok = l.TryAcquire("x1000c0s0b0")
+	suite.False(ok)
+}
+
+func (suite *Host_Limiter_TS) Test_PerChassisAndGlobal() {
+	l := NewHostLimiter(0, 2, 3)
+	_, ok := l.TryAcquire("x1000c0s0b0")
+	suite.True(ok)
+	_, ok = l.TryAcquire("x1000c0s1b0")
+	suite.True(ok)
+	_, ok = l.TryAcquire("x1000c0s2b0")
+	suite.False(ok, "chassis x1000c0 is full")
+	_, ok = l.TryAcquire("x1000c1s0b0")
+	suite.True(ok)
+	_, ok = l.TryAcquire("x1000c2s0b0")
+	suite.False(ok, "the global cap is reached")
+}
+
+func (suite *Host_Limiter_TS) Test_AcquireWaits() {
+	l := NewHostLimiter(1, 0, 0)
+	release, err := l.Acquire(context.Background(), "x1000c0s0b0")
+	suite.Nil(err)
+	acquired := make(chan bool)
+	go func() {
+		r, _ := l.Acquire(context.Background(), "x1000c0s0b0")
+		r()
+		acquired <- true
+	}()
+	select {
+	case <-acquired:
+		suite.Fail("the second request did not wait")
+	case <-time.After(50 * time.Millisecond):
+	}
+	release()
+	select {
+	case <-acquired:
+	case <-time.After(time.Second):
+		suite.Fail("the second request never got a slot")
+	}
+}
+
+func (suite *Host_Limiter_TS) Test_AcquireGivesUp() {
+	l := NewHostLimiter(1, 0, 0)
+	release, err := l.Acquire(context.Background(), "x1000c0s0b0")
+	suite.Nil(err)
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	_, err = l.Acquire(ctx, "x1000c0s0b0")
+	suite.Equal(context.DeadlineExceeded, err)
+
+	// the slot that was given up on is not taken
+	release()
+	_, ok := l.TryAcquire("x1000c0s0b0")
+	suite.True(ok)
+}
+
+func (suite *Host_Limiter_TS) Test_NilLimiter() {
+	var l *HostLimiter
+	release, ok := l.TryAcquire("x1000c0s0b0")
+	suite.True(ok)
+	release()
+	release, err := l.Acquire(context.Background(), "x1000c0s0b0")
+	suite.Nil(err)
+	release()
+}
+
+func (suite *Host_Limiter_TS) Test_NextLimitedWave() {
+	saved := GLOB.RFLimiter
+	defer func() { GLOB.RFLimiter = saved }()
+	GLOB.RFLimiter = NewHostLimiter(1, 0, 0)
+
+	taskMap := make(map[uuid.UUID]hsm.XnameTarget)
+	hd := make(map[hsm.XnameTarget]hsm.HsmData)
+	var pending []trs_http_api.HttpTask
+	for _, xt := range []hsm.XnameTarget{{Xname: "x0c0s0b0", Target: "BMC"}, {Xname: "x0c0s0b0", Target: "BIOS"}, {Xname: "x0c0s1b0", Target: "BMC"}} {
+		task := trs_http_api.HttpTask{}
+		task.SetIDIfNotPopulated()
+		taskMap[task.GetID()] = xt
+		hd[xt] = hsm.HsmData{ID: xt.Xname, FQDN: xt.Xname}
+		pending = append(pending, task)
+	}
+
+	wave, rest, release, err := nextLimitedWave(pending, taskMap, hd)
+	suite.Nil(err)
+	suite.Equal(2, len(wave))
+	suite.Equal(1, len(rest))
+	suite.Equal("BIOS", taskMap[rest[0].GetID()].Target)
+	release()
+
+	wave, rest, release, err = nextLimitedWave(rest, taskMap, hd)
+	suite.Nil(err)
+	suite.Equal(1, len(wave))
+	suite.Equal(0, len(rest))
+	release()
+}
+
+func Test_Domain_Host_Limiter(t *testing.T) {
+	ConfigureSystemForUnitTesting()
+	suite.Run(t, new(Host_Limiter_TS))
+}
@@ -67,6 +67,9 @@ func CreateImage(i presentation.RawImage) (pb model.Passback) {
 		pb = model.BuildErrorPassback(http.StatusBadRequest, err)
 		return
 	}
+	if image.SemanticFirmwareVersion == nil {
+		image.SemanticFirmwareVersion = ParseFirmwareVersion(image.Manufacturer, image.Target, image.FirmwareVersion)
+	}
 	err = ValidateImageParameters(&image)
 	if err != nil {
 		pb = model.BuildErrorPassback(http.StatusBadRequest, err)
@@ -157,6 +160,9 @@ func UpdateImage(image presentation.RawImage, imageid uuid.UUID) (pb model.Passb
 		return
 	}
 	i.ImageID = imageid
+	if i.SemanticFirmwareVersion == nil {
+		i.SemanticFirmwareVersion = ParseFirmwareVersion(i.Manufacturer, i.Target, i.FirmwareVersion)
+	}
 
 	err = ValidateImageParameters(&i)
 	if err != nil {
@@ -116,6 +116,26 @@ func (suite *Images_TS) Test_CreateImage_GoodImage() {
 	suite.False(pb.IsError)
 }
 
+func (suite *Images_TS) Test_CreateImage_DerivedSemanticVersion() {
+	rawImage := Helper_GetDefaultRawImage()
+	rawImage.Manufacturer = "hpe"
+	rawImage.FirmwareVersion = "U30 v2.42 (10/30/2023)"
+	rawImage.SemanticFirmwareVersion = ""
+	pb := CreateImage(rawImage)
+	suite.False(pb.IsError)
+	imageID := pb.Obj.(storage.ImageID)
+
+	pb = GetImage(imageID.ImageID)
+	suite.False(pb.IsError)
+	suite.Equal("2.42.0+U30", pb.Obj.(presentation.ImageMarshaled).SemanticFirmwareVersion)
+	DeleteImage(imageID.ImageID)
+
+	//nothing to derive it from
+	rawImage.FirmwareVersion = "release-candidate"
+	pb = CreateImage(rawImage)
+	suite.True(pb.IsError)
+}
+
 func (suite *Images_TS) Test_GetImage_Good() {
 	rImage := Helper_GetDefaultRawImage()
 	pb := CreateImage(rImage)
@@ -0,0 +1,116 @@
+/*
+ * MIT License
+ *
+ * (C) Copyright [2026] Hewlett Packard Enterprise Development LP
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a
+ * copy of this software and associated documentation files (the "Software"),
+ * to deal in the Software without restriction, including without limitation
+ * the rights to use, copy, modify, merge, publish, distribute, sublicense,
+ * and/or sell copies of the Software, and to permit persons to whom the
+ * Software is furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included
+ * in all copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL
+ * THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+ * OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+ * ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+ * OTHER DEALINGS IN THE SOFTWARE.
+ */
+
+package domain
+
+import (
+	"sort"
+
+	"github.com/Cray-HPE/hms-firmware-action/internal/storage"
+	"github.com/Cray-HPE/hms-xname/xnametypes"
+	"github.com/google/uuid"
+)
+
+// launchGroup -> the units of one group (e.g. one chassis) the action updates, and those with an update in flight
+type launchGroup struct {
+	units map[string]bool
+	busy  map[string]bool
+}
+
+// launchKeys -> the group and unit a policy puts an xname in.  An xname without an ancestor of the group type is
+// grouped with the others that have none; one without an ancestor of the unit type is its own unit.
+func launchKeys(policy storage.LaunchPolicy, xname string) (group string, unit string) {
+	unit = xnametypes.NormalizeHMSCompID(xname)
+	if policy.Group != "" {
+		group = ancestorOfType(unit, xnametypes.HMSType(policy.Group))
+	}
+	if policy.Unit != "" {
+		if u := ancestorOfType(unit, xnametypes.HMSType(policy.Unit)); u != "" {
+			unit = u
+		}
+	}
+	return
+}
+
+// LaunchableOperations -> which operations in the candidate state the launch policies let start now.  Operations
+// that are inProgress, needsVerified or verifying keep their unit busy; a candidate may start when its unit is
+// already busy, or when its group has room for another busy unit under every policy.  Candidates are taken in xname
+// order, so the same devices go first on every pass whatever order the store returns them in.
+func LaunchableOperations(policies []storage.LaunchPolicy, operations []storage.Operation, candidateState string) (launchable map[uuid.UUID]bool) {
+	launchable = make(map[uuid.UUID]bool)
+	groups := make([]map[string]*launchGroup, len(policies))
+	for i, policy := range policies {
+		groups[i] = make(map[string]*launchGroup)
+		for _, operation := range operations {
+			group, unit := launchKeys(policy, operation.Xname)
+			g, ok := groups[i][group]
+			if !ok {
+				g = &launchGroup{units: make(map[string]bool), busy: make(map[string]bool)}
+				groups[i][group] = g
+			}
+			g.units[unit] = true
+			if operation.State.Is("inProgress") || operation.State.Is("needsVerified") || operation.State.Is("verifying") {
+				g.busy[unit] = true
+			}
+		}
+	}
+
+	var candidates []storage.Operation
+	for _, operation := range operations {
+		if operation.State.Is(candidateState) {
+			candidates = append(candidates, operation)
+		}
+	}
+	sort.SliceStable(candidates, func(i, j int) bool {
+		a, b := candidates[i], candidates[j]
+		if xa, xb := xnametypes.NormalizeHMSCompID(a.Xname), xnametypes.NormalizeHMSCompID(b.Xname); xa != xb {
+			return xa < xb
+		}
+		if a.Target != b.Target {
+			return a.Target < b.Target
+		}
+		return a.OperationID.String() < b.OperationID.String()
+	})
+
+	for _, operation := range candidates {
+		allowed := true
+		for i, policy := range policies {
+			group, unit := launchKeys(policy, operation.Xname)
+			g := groups[i][group]
+			if !g.busy[unit] && len(g.busy) >= policy.Limit(len(g.units)) {
+				allowed = false
+				break
+			}
+		}
+		if !allowed {
+			continue
+		}
+		launchable[operation.OperationID] = true
+		for i, policy := range policies {
+			group, unit := launchKeys(policy, operation.Xname)
+			groups[i][group].busy[unit] = true
+		}
+	}
+	return launchable
+}
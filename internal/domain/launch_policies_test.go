@@ -0,0 +1,110 @@
+/*
+ * MIT License
+ *
+ * (C) Copyright [2026] Hewlett Packard Enterprise Development LP
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a
+ * copy of this software and associated documentation files (the "Software"),
+ * to deal in the Software without restriction, including without limitation
+ * the rights to use, copy, modify, merge, publish, distribute, sublicense,
+ * and/or sell copies of the Software, and to permit persons to whom the
+ * Software is furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included
+ * in all copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL
+ * THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+ * OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+ * ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+ * OTHER DEALINGS IN THE SOFTWARE.
+ */
+
+package domain
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/Cray-HPE/hms-firmware-action/internal/storage"
+	"github.com/stretchr/testify/suite"
+)
+
+type LaunchPolicies_TS struct {
+	suite.Suite
+}
+
+func launchPolicyOperation(xname string, state string) storage.Operation {
+	operation := storage.HelperGetStockOperation()
+	operation.Xname = xname
+	operation.State.SetState(state)
+	return operation
+}
+
+func (suite *LaunchPolicies_TS) Test_OneSlotPerChassis() {
+	policies := []storage.LaunchPolicy{{Group: "Chassis", Unit: "ComputeModule", MaxUnits: 1}}
+	operations := []storage.Operation{
+		launchPolicyOperation("x1000c0s0b0", "configured"),
+		launchPolicyOperation("x1000c0s0b1", "configured"), //same slot, may go along
+		launchPolicyOperation("x1000c0s1b0", "configured"), //second slot of the chassis, has to wait
+		launchPolicyOperation("x1000c1s0b0", "configured"), //another chassis
+		launchPolicyOperation("x1000c2s0b0", "inProgress"),
+		launchPolicyOperation("x1000c2s1b0", "configured"), //chassis already has a slot in flight
+		launchPolicyOperation("x1000c2s0b1", "configured"), //but this is that slot
+	}
+	launchable := LaunchableOperations(policies, operations, "configured")
+	suite.True(launchable[operations[0].OperationID])
+	suite.True(launchable[operations[1].OperationID])
+	suite.False(launchable[operations[2].OperationID])
+	suite.True(launchable[operations[3].OperationID])
+	suite.False(launchable[operations[4].OperationID])
+	suite.False(launchable[operations[5].OperationID])
+	suite.True(launchable[operations[6].OperationID])
+}
+
+func (suite *LaunchPolicies_TS) Test_PercentOfCabinet() {
+	policies := []storage.LaunchPolicy{{Group: "Cabinet", MaxPercent: 50}}
+	var operations []storage.Operation
+	for _, xname := range []string{"x1000c0s0b0", "x1000c0s1b0", "x1000c0s2b0", "x1000c0s3b0", "x1001c0s0b0"} {
+		operations = append(operations, launchPolicyOperation(xname, "configured"))
+	}
+	operations[0].State.SetState("verifying")
+	launchable := LaunchableOperations(policies, operations, "configured")
+	suite.Equal(2, len(launchable))
+	suite.True(launchable[operations[1].OperationID])
+	suite.False(launchable[operations[2].OperationID])
+	suite.True(launchable[operations[4].OperationID]) //half of one BMC still rounds up to one
+}
+
+func (suite *LaunchPolicies_TS) Test_DeterministicOrder() {
+	policies := []storage.LaunchPolicy{{Group: "Chassis", Unit: "ComputeModule", MaxUnits: 1}}
+	var operations []storage.Operation
+	for _, xname := range []string{"x1000c0s3b0", "x1000c0s1b0", "x1000c0s2b0", "x1000c0s1b1", "x1000c0s0b0"} {
+		operations = append(operations, launchPolicyOperation(xname, "configured"))
+	}
+	//the store hands the operations back in any order, the first slot goes first every time
+	for i := 0; i < 10; i++ {
+		rand.Shuffle(len(operations), func(a, b int) { operations[a], operations[b] = operations[b], operations[a] })
+		launchable := LaunchableOperations(policies, operations, "configured")
+		suite.Equal(1, len(launchable))
+		for _, operation := range operations {
+			suite.Equal(operation.Xname == "x1000c0s0b0", launchable[operation.OperationID])
+		}
+	}
+}
+
+func (suite *LaunchPolicies_TS) Test_NoPolicies() {
+	operations := []storage.Operation{
+		launchPolicyOperation("x1000c0s0b0", "configured"),
+		launchPolicyOperation("x1000c0s1b0", "configured"),
+		launchPolicyOperation("x1000c0s2b0", "succeeded"),
+	}
+	launchable := LaunchableOperations(nil, operations, "configured")
+	suite.Equal(2, len(launchable))
+}
+
+func Test_Domain_LaunchPolicies(t *testing.T) {
+	suite.Run(t, new(LaunchPolicies_TS))
+}
@@ -0,0 +1,171 @@
+/*
+ * MIT License
+ *
+ * (C) Copyright [2026] Hewlett Packard Enterprise Development LP
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a
+ * copy of this software and associated documentation files (the "Software"),
+ * to deal in the Software without restriction, including without limitation
+ * the rights to use, copy, modify, merge, publish, distribute, sublicense,
+ * and/or sell copies of the Software, and to permit persons to whom the
+ * Software is furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included
+ * in all copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL
+ * THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+ * OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+ * ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+ * OTHER DEALINGS IN THE SOFTWARE.
+ */
+
+package domain
+
+import (
+	"embed"
+	"encoding/json"
+	"path"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Cray-HPE/hms-firmware-action/internal/model"
+	"github.com/Cray-HPE/hms-firmware-action/internal/storage"
+	"github.com/sirupsen/logrus"
+)
+
+// The standard DMTF registries, trimmed to the messages BMCs send around a firmware update
+//
+//go:embed registries/*.json
+var registryFiles embed.FS
+
+type messageRegistry struct {
+	RegistryPrefix  string                     `json:"RegistryPrefix"`
+	RegistryVersion string                     `json:"RegistryVersion"`
+	Messages        map[string]registryMessage `json:"Messages"`
+}
+
+type registryMessage struct {
+	Message         string `json:"Message"`
+	Severity        string `json:"Severity"`
+	MessageSeverity string `json:"MessageSeverity"`
+	NumberOfArgs    int    `json:"NumberOfArgs"`
+	Resolution      string `json:"Resolution"`
+}
+
+// registry prefix -> registry; the version in a MessageId is ignored, the registries only ever add messages
+var messageRegistries = loadMessageRegistries()
+
+func loadMessageRegistries() map[string]messageRegistry {
+	registries := make(map[string]messageRegistry)
+	files, _ := registryFiles.ReadDir("registries")
+	for _, file := range files {
+		data, err := registryFiles.ReadFile(path.Join("registries", file.Name()))
+		if err != nil {
+			logrus.WithFields(logrus.Fields{"ERROR": err, "registry": file.Name()}).Error("Could not read message registry")
+			continue
+		}
+		var registry messageRegistry
+		if err = json.Unmarshal(data, &registry); err != nil {
+			logrus.WithFields(logrus.Fields{"ERROR": err, "registry": file.Name()}).Error("Could not parse message registry")
+			continue
+		}
+		registries[registry.RegistryPrefix] = registry
+	}
+	return registries
+}
+
+// lookupRegistryMessage -> finds Base.1.8.GeneralError as GeneralError in the Base registry
+func lookupRegistryMessage(messageID string) (message registryMessage, ok bool) {
+	parts := strings.Split(messageID, ".")
+	if len(parts) < 2 {
+		return
+	}
+	registry, ok := messageRegistries[parts[0]]
+	if !ok {
+		return
+	}
+	message, ok = registry.Messages[parts[len(parts)-1]]
+	return
+}
+
+// fillMessageArgs -> replaces %1..%n; from the highest down, so %1 does not eat the start of %10
+func fillMessageArgs(message string, args []string) string {
+	for i := len(args); i > 0; i-- {
+		message = strings.ReplaceAll(message, "%"+strconv.Itoa(i), args[i-1])
+	}
+	return message
+}
+
+// ParseRedfishMessages -> the messages of a Redfish error response, or the extended info attached to an accepted
+// request.  An error without extended info is returned as a message of its own.
+func ParseRedfishMessages(body []byte) (messages []model.RedfishMessage) {
+	var redfishError model.RedfishError
+	if len(body) == 0 || json.Unmarshal(body, &redfishError) != nil {
+		return
+	}
+	messages = append(messages, redfishError.Error.ExtendedInfo...)
+	messages = append(messages, redfishError.ExtendedInfo...)
+	if len(messages) == 0 && (redfishError.Error.Code != "" || redfishError.Error.Message != "") {
+		messages = append(messages, model.RedfishMessage{
+			MessageId: redfishError.Error.Code,
+			Message:   redfishError.Error.Message,
+		})
+	}
+	return
+}
+
+// ResolveRedfishMessages -> fills in the message, severity and resolution the BMC left out from the bundled
+// registries.  What the BMC did send wins; vendors put the detail that matters into their own text.
+func ResolveRedfishMessages(source string, messages []model.RedfishMessage) (resolved []storage.BMCMessage) {
+	now := time.Now()
+	for _, message := range messages {
+		bmcMessage := storage.BMCMessage{
+			Time:       now,
+			Source:     source,
+			MessageID:  message.MessageId,
+			Message:    message.Message,
+			Severity:   message.MessageSeverity,
+			Resolution: message.Resolution,
+		}
+		if bmcMessage.Severity == "" {
+			bmcMessage.Severity = message.Severity
+		}
+		if entry, ok := lookupRegistryMessage(message.MessageId); ok {
+			if bmcMessage.Message == "" {
+				bmcMessage.Message = fillMessageArgs(entry.Message, message.MessageArgs)
+			}
+			if bmcMessage.Severity == "" {
+				bmcMessage.Severity = entry.MessageSeverity
+			}
+			if bmcMessage.Severity == "" {
+				bmcMessage.Severity = entry.Severity
+			}
+			if bmcMessage.Resolution == "" {
+				bmcMessage.Resolution = entry.Resolution
+			}
+		}
+		if bmcMessage.Message == "" {
+			bmcMessage.Message = "no registry entry for " + message.MessageId
+		}
+		resolved = append(resolved, bmcMessage)
+	}
+	return
+}
+
+var severityRank = map[string]int{"OK": 1, "Warning": 2, "Critical": 3}
+
+// SummarizeBMCMessages -> the most severe message, for the stateHelper; empty when there are none
+func SummarizeBMCMessages(messages []storage.BMCMessage) (summary string) {
+	rank := 0
+	for _, message := range messages {
+		if severityRank[message.Severity] > rank || summary == "" {
+			rank = severityRank[message.Severity]
+			summary = message.Message
+		}
+	}
+	return
+}
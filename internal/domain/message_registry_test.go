@@ -0,0 +1,97 @@
+/*
+ * MIT License
+ *
+ * (C) Copyright [2026] Hewlett Packard Enterprise Development LP
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a
+ * copy of this software and associated documentation files (the "Software"),
+ * to deal in the Software without restriction, including without limitation
+ * the rights to use, copy, modify, merge, publish, distribute, sublicense,
+ * and/or sell copies of the Software, and to permit persons to whom the
+ * Software is furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included
+ * in all copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL
+ * THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+ * OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+ * ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+ * OTHER DEALINGS IN THE SOFTWARE.
+ */
+
+package domain
+
+import (
+	"testing"
+
+	"github.com/Cray-HPE/hms-firmware-action/internal/model"
+	"github.com/Cray-HPE/hms-firmware-action/internal/storage"
+	"github.com/stretchr/testify/suite"
+)
+
+type MessageRegistry_TS struct {
+	suite.Suite
+}
+
+func (suite *MessageRegistry_TS) Test_BundledRegistries() {
+	suite.Contains(messageRegistries, "Base")
+	suite.Contains(messageRegistries, "Update")
+	for prefix, registry := range messageRegistries {
+		suite.NotEmpty(registry.Messages, prefix)
+	}
+}
+
+func (suite *MessageRegistry_TS) Test_ParseRedfishMessages() {
+	rejected := []byte(`{"error": {"code": "Base.1.8.GeneralError", "message": "A general error has occurred.",
+		"@Message.ExtendedInfo": [{"MessageId": "Base.1.8.PropertyValueNotInList",
+		"MessageArgs": ["Immediate", "@Redfish.OperationApplyTime"]}]}}`)
+	messages := ParseRedfishMessages(rejected)
+	suite.Len(messages, 1)
+	suite.Equal("Base.1.8.PropertyValueNotInList", messages[0].MessageId)
+
+	bare := []byte(`{"error": {"code": "iLO.0.10.ExtendedInfo", "message": "See @Message.ExtendedInfo for more information."}}`)
+	messages = ParseRedfishMessages(bare)
+	suite.Len(messages, 1)
+	suite.Equal("See @Message.ExtendedInfo for more information.", messages[0].Message)
+
+	suite.Empty(ParseRedfishMessages([]byte("connection refused")))
+	suite.Empty(ParseRedfishMessages(nil))
+}
+
+func (suite *MessageRegistry_TS) Test_ResolveRedfishMessages() {
+	resolved := ResolveRedfishMessages(storage.BMCMessageSourceTask, []model.RedfishMessage{
+		{MessageId: "Update.1.1.ApplyFailed", MessageArgs: []string{"bios.bin", "BIOS"}},
+		{MessageId: "Base.1.8.PropertyValueNotInList", MessageArgs: []string{"Immediate", "ApplyTime"}, Severity: "Warning"},
+		{MessageId: "Oem.1.0.Unknown", Message: "flash part busy", MessageSeverity: "Critical"},
+		{MessageId: "Oem.1.0.Silent"},
+	})
+	suite.Len(resolved, 4)
+
+	suite.Equal(storage.BMCMessageSourceTask, resolved[0].Source)
+	suite.Equal("Installation of image 'bios.bin' to 'BIOS' failed.", resolved[0].Message)
+	suite.Equal("Critical", resolved[0].Severity)
+
+	suite.Equal("The value 'Immediate' for the property ApplyTime is not in the list of acceptable values.", resolved[1].Message)
+	suite.Equal("Warning", resolved[1].Severity)
+	suite.NotEmpty(resolved[1].Resolution)
+
+	suite.Equal("flash part busy", resolved[2].Message)
+	suite.Equal("Critical", resolved[2].Severity)
+	suite.Equal("no registry entry for Oem.1.0.Silent", resolved[3].Message)
+
+	suite.Equal("Installation of image 'bios.bin' to 'BIOS' failed.", SummarizeBMCMessages(resolved))
+	suite.Empty(SummarizeBMCMessages(nil))
+}
+
+func (suite *MessageRegistry_TS) Test_FillMessageArgs() {
+	suite.Equal("a b c d e f g h i j", fillMessageArgs("%1 %2 %3 %4 %5 %6 %7 %8 %9 %10",
+		[]string{"a", "b", "c", "d", "e", "f", "g", "h", "i", "j"}))
+	suite.Equal("Image '%1' is being verified at '%2'.", fillMessageArgs("Image '%1' is being verified at '%2'.", nil))
+}
+
+func Test_Domain_MessageRegistry(t *testing.T) {
+	suite.Run(t, new(MessageRegistry_TS))
+}
@@ -0,0 +1,113 @@
+/*
+ * MIT License
+ *
+ * (C) Copyright [2026] Hewlett Packard Enterprise Development LP
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a
+ * copy of this software and associated documentation files (the "Software"),
+ * to deal in the Software without restriction, including without limitation
+ * the rights to use, copy, modify, merge, publish, distribute, sublicense,
+ * and/or sell copies of the Software, and to permit persons to whom the
+ * Software is furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included
+ * in all copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL
+ * THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+ * OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+ * ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+ * OTHER DEALINGS IN THE SOFTWARE.
+ */
+
+package domain
+
+import (
+	"math"
+	"net/http"
+	"sort"
+	"time"
+
+	"github.com/Cray-HPE/hms-firmware-action/internal/model"
+	"github.com/Cray-HPE/hms-firmware-action/internal/presentation"
+	"github.com/Cray-HPE/hms-firmware-action/internal/storage"
+	"github.com/google/uuid"
+)
+
+// phaseOrder -> the order an operation passes through its states; anything else sorts after these
+var phaseOrder = []string{"initial", "configured", "blocked", "inProgress", "staged", "needsVerified", "verifying"}
+
+// GetOperationHistory - the state transitions and BMC reports of an operation, oldest first
+func GetOperationHistory(operationID uuid.UUID) (pb model.Passback) {
+	operation, err := GetStoredOperation(operationID)
+	if err != nil {
+		pb = model.BuildErrorPassback(http.StatusNotFound, err)
+		return
+	}
+	events, err := (*GLOB.DSP).GetOperationHistory(operationID)
+	if err != nil {
+		pb = model.BuildErrorPassback(http.StatusInternalServerError, err)
+		return
+	}
+	history := presentation.OperationHistory{
+		OperationID: operation.OperationID,
+		ActionID:    operation.ActionID,
+		Xname:       operation.Xname,
+		Target:      operation.Target,
+		State:       operation.State.Current(),
+		History:     events,
+	}
+	if history.History == nil {
+		history.History = []storage.OperationEvent{}
+	}
+	pb = model.BuildSuccessPassback(http.StatusOK, history)
+	return
+}
+
+// SummarizePhaseDurations -> the average and longest time the operations spent in each state, in the order
+// operations go through them
+func SummarizePhaseDurations(operations []storage.Operation, until time.Time) (phases []presentation.PhaseDuration) {
+	byState := make(map[string]*presentation.PhaseDuration)
+	for _, operation := range operations {
+		history, err := (*GLOB.DSP).GetOperationHistory(operation.OperationID)
+		if err != nil {
+			continue
+		}
+		for state, duration := range storage.StateDurations(history, until) {
+			phase, ok := byState[state]
+			if !ok {
+				phase = &presentation.PhaseDuration{State: state}
+				byState[state] = phase
+			}
+			seconds := duration.Seconds()
+			phase.Operations++
+			phase.AverageSeconds += seconds //a total until the end
+			if seconds > phase.MaxSeconds {
+				phase.MaxSeconds = seconds
+			}
+		}
+	}
+
+	rank := func(state string) int {
+		for i, s := range phaseOrder {
+			if s == state {
+				return i
+			}
+		}
+		return len(phaseOrder)
+	}
+	for _, phase := range byState {
+		phase.AverageSeconds = math.Round(phase.AverageSeconds/float64(phase.Operations)*1000) / 1000
+		phase.MaxSeconds = math.Round(phase.MaxSeconds*1000) / 1000
+		phases = append(phases, *phase)
+	}
+	sort.Slice(phases, func(i, j int) bool {
+		if rank(phases[i].State) != rank(phases[j].State) {
+			return rank(phases[i].State) < rank(phases[j].State)
+		}
+		return phases[i].State < phases[j].State
+	})
+	return phases
+}
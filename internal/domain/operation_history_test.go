@@ -0,0 +1,98 @@
+/*
+ * MIT License
+ *
+ * (C) Copyright [2026] Hewlett Packard Enterprise Development LP
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a
+ * copy of this software and associated documentation files (the "Software"),
+ * to deal in the Software without restriction, including without limitation
+ * the rights to use, copy, modify, merge, publish, distribute, sublicense,
+ * and/or sell copies of the Software, and to permit persons to whom the
+ * Software is furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included
+ * in all copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL
+ * THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+ * OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+ * ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+ * OTHER DEALINGS IN THE SOFTWARE.
+ */
+
+package domain
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/Cray-HPE/hms-firmware-action/internal/presentation"
+	"github.com/Cray-HPE/hms-firmware-action/internal/storage"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/suite"
+)
+
+type Operation_History_TS struct {
+	suite.Suite
+}
+
+func (suite *Operation_History_TS) Test_StoreOperationKeepsHistory() {
+	operation := storage.HelperGetStockOperation()
+	operation.State.SetState("configured")
+	operation.StateHelper = "configured"
+	suite.True(StoreOperation(operation) == nil)
+
+	//two copies store in turn, neither knows what the other recorded
+	first, err := GetStoredOperation(operation.OperationID)
+	suite.True(err == nil)
+	second, err := GetStoredOperation(operation.OperationID)
+	suite.True(err == nil)
+	first.StateHelper = "waiting for lock"
+	suite.True(StoreOperation(first) == nil)
+	second.StateHelper = "got lock"
+	suite.True(StoreOperation(second) == nil)
+
+	pb := GetOperationHistory(operation.OperationID)
+	suite.False(pb.IsError)
+	history := pb.Obj.(presentation.OperationHistory)
+	suite.Equal(3, len(history.History))
+	suite.Equal("waiting for lock", history.History[1].StateHelper)
+	suite.Equal("got lock", history.History[2].StateHelper)
+
+	pb = GetOperationHistory(uuid.New())
+	suite.Equal(http.StatusNotFound, pb.StatusCode)
+	_ = DeleteStoredOperation(operation.OperationID)
+}
+
+func (suite *Operation_History_TS) Test_SummarizePhaseDurations() {
+	start := time.Now().Add(-time.Hour)
+	var operations []storage.Operation
+	for _, verify := range []time.Duration{2 * time.Minute, 6 * time.Minute} {
+		operation := storage.HelperGetStockOperation()
+		suite.True((*GLOB.DSP).AppendOperationHistory(operation.OperationID, []storage.OperationEvent{
+			{Time: start, State: "configured"},
+			{Time: start.Add(time.Minute), State: "inProgress"},
+			{Time: start.Add(2 * time.Minute), State: "verifying"},
+			{Time: start.Add(2*time.Minute + verify), State: "succeeded"},
+		}) == nil)
+		defer (*GLOB.DSP).DeleteOperationHistory(operation.OperationID)
+		operations = append(operations, operation)
+	}
+	phases := SummarizePhaseDurations(operations, time.Now())
+	suite.Equal(3, len(phases))
+	suite.Equal("configured", phases[0].State)
+	suite.Equal("inProgress", phases[1].State)
+	suite.Equal("verifying", phases[2].State)
+	suite.Equal(2, phases[2].Operations)
+	suite.Equal(240.0, phases[2].AverageSeconds)
+	suite.Equal(360.0, phases[2].MaxSeconds)
+	suite.Equal(0, len(SummarizePhaseDurations(nil, time.Now())))
+}
+
+func Test_Domain_Operation_History(t *testing.T) {
+	ConfigureSystemForUnitTesting()
+	suite.Run(t, new(Operation_History_TS))
+}
@@ -0,0 +1,247 @@
+/*
+ * MIT License
+ *
+ * (C) Copyright [2026] Hewlett Packard Enterprise Development LP
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a
+ * copy of this software and associated documentation files (the "Software"),
+ * to deal in the Software without restriction, including without limitation
+ * the rights to use, copy, modify, merge, publish, distribute, sublicense,
+ * and/or sell copies of the Software, and to permit persons to whom the
+ * Software is furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included
+ * in all copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL
+ * THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+ * OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+ * ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+ * OTHER DEALINGS IN THE SOFTWARE.
+ */
+
+package domain
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Cray-HPE/hms-firmware-action/internal/storage"
+	rf "github.com/Cray-HPE/hms-smd/v2/pkg/redfish"
+)
+
+// PowerClient -> resets the device an operation updated and reports its power state; doVerify uses it for images
+// that only take effect after a reboot, and to reset staged images in.
+type PowerClient interface {
+	Reset(operation storage.Operation, resetType string) error
+	PowerState(operation storage.Operation) (string, error) //On when the device is back
+}
+
+// Power clients FAS_POWER_CLIENT can name
+const (
+	PowerClientRedfish = "redfish"
+	PowerClientPCS     = "pcs"
+	PowerClientStub    = "stub"
+)
+
+const DefaultPCSURL = "http://cray-power-control/v1"
+
+func NewPowerClient(kind string, pcsURL string) (PowerClient, error) {
+	switch kind {
+	case "", PowerClientRedfish:
+		return &RedfishPowerClient{}, nil
+	case PowerClientPCS:
+		if pcsURL == "" {
+			pcsURL = DefaultPCSURL
+		}
+		return &PCSPowerClient{URL: strings.TrimSuffix(pcsURL, "/")}, nil
+	case PowerClientStub:
+		return &StubPowerClient{}, nil
+	}
+	return nil, errors.New("unknown power client " + kind + "; must be " + PowerClientRedfish + ", " + PowerClientPCS +
+		" or " + PowerClientStub)
+}
+
+// RedfishPowerClient -> posts ComputerSystem.Reset to the target HSM discovered for the device
+type RedfishPowerClient struct{}
+
+func (c *RedfishPowerClient) Reset(operation storage.Operation, resetType string) error {
+	path := operation.HsmData.ActionReset.Target //"/redfish/v1/Systems/Self/Actions/ComputerSystem.Reset"
+	if path == "" {
+		return errors.New("no reset action discovered for " + operation.Xname)
+	}
+	payload, _ := json.Marshal(map[string]string{"ResetType": resetType})
+	status, body, _, err := sendRedfishRequest(&operation.HsmData, http.MethodPost, path, payload)
+	if err != nil {
+		return err
+	}
+	if status < 200 || status > 299 {
+		return errors.New("reset returned " + strconv.Itoa(status) + ": " + string(body))
+	}
+	return nil
+}
+
+// PowerState -> the PowerState of the system the reset action belongs to.  Without a reset action there is no system
+// to ask, and a device that answers is taken to be on.
+func (c *RedfishPowerClient) PowerState(operation storage.Operation) (string, error) {
+	path := operation.HsmData.ActionReset.Target
+	if i := strings.Index(path, "/Actions/"); i > 0 {
+		path = path[:i]
+	} else {
+		return rf.POWER_STATE_ON, nil
+	}
+	status, body, _, err := sendRedfishRequest(&operation.HsmData, http.MethodGet, path, nil)
+	if err != nil {
+		return "", err
+	}
+	if status != http.StatusOK {
+		return "", errors.New("system returned " + strconv.Itoa(status))
+	}
+	var system rf.ComputerSystem
+	if err = json.Unmarshal(body, &system); err != nil {
+		return "", err
+	}
+	if system.PowerState == "" {
+		return rf.POWER_STATE_ON, nil
+	}
+	return system.PowerState, nil
+}
+
+// PCSPowerClient -> asks the Power Control Service to restart the node, so the reset goes through the same
+// service (and the same locks) as every other power transition on the system
+type PCSPowerClient struct {
+	URL string
+}
+
+var nodeTarget = regexp.MustCompile(`(?i)^node(\d+)`)
+
+// powerXname -> the node behind a node BMC, taken from a target like Node1.BIOS (n0 otherwise); any other device
+// is restarted itself
+func powerXname(operation storage.Operation) string {
+	if !strings.EqualFold(operation.HsmData.Type, "NodeBMC") {
+		return operation.Xname
+	}
+	node := "0"
+	if m := nodeTarget.FindStringSubmatch(operation.Target); m != nil {
+		node = m[1]
+	}
+	return operation.Xname + "n" + node
+}
+
+// pcsOperation -> the PCS transition closest to a Redfish ResetType
+func pcsOperation(resetType string) string {
+	switch resetType {
+	case "GracefulRestart":
+		return "soft-restart"
+	case "On", "ForceOn":
+		return "on"
+	}
+	return "hard-restart"
+}
+
+func (c *PCSPowerClient) Reset(operation storage.Operation, resetType string) error {
+	transition := map[string]interface{}{
+		"operation": pcsOperation(resetType),
+		"location":  []map[string]string{{"xname": powerXname(operation)}},
+	}
+	payload, _ := json.Marshal(transition)
+	status, body, err := c.send(http.MethodPost, "/transitions", payload)
+	if err != nil {
+		return err
+	}
+	if status < 200 || status > 299 {
+		return errors.New("power control returned " + strconv.Itoa(status) + ": " + string(body))
+	}
+	return nil
+}
+
+func (c *PCSPowerClient) PowerState(operation storage.Operation) (string, error) {
+	xname := powerXname(operation)
+	status, body, err := c.send(http.MethodGet, "/power-status?xname="+url.QueryEscape(xname), nil)
+	if err != nil {
+		return "", err
+	}
+	if status != http.StatusOK {
+		return "", errors.New("power control returned " + strconv.Itoa(status) + ": " + string(body))
+	}
+	var powerStatus struct {
+		Status []struct {
+			Xname      string `json:"xname"`
+			PowerState string `json:"powerState"`
+		} `json:"status"`
+	}
+	if err = json.Unmarshal(body, &powerStatus); err != nil {
+		return "", err
+	}
+	for _, s := range powerStatus.Status {
+		if s.Xname == xname {
+			if strings.EqualFold(s.PowerState, "on") {
+				return rf.POWER_STATE_ON, nil
+			}
+			return s.PowerState, nil
+		}
+	}
+	return "", errors.New("power control has no status for " + xname)
+}
+
+func (c *PCSPowerClient) send(method string, path string, payload []byte) (status int, body []byte, err error) {
+	if GLOB.SVCHttpClient == nil {
+		return 0, nil, errors.New("no service client")
+	}
+	req, err := http.NewRequest(method, c.URL+path, bytes.NewReader(payload))
+	if err != nil {
+		return
+	}
+	if payload != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 40*time.Second)
+	defer cancel()
+	resp, err := GLOB.SVCHttpClient.Do(req.WithContext(ctx))
+	if err != nil {
+		return
+	}
+	defer resp.Body.Close()
+	body, err = ioutil.ReadAll(resp.Body)
+	return resp.StatusCode, body, err
+}
+
+// StubPowerClient -> records resets and reports every device as on, unless told otherwise; for tests and the
+// developer environment, which have no devices to reboot
+type StubPowerClient struct {
+	lock    sync.Mutex
+	Resets  []string          //xname:resetType, in order
+	States  map[string]string //power state by xname; On when missing
+	FailAll bool              //every reset fails
+}
+
+func (c *StubPowerClient) Reset(operation storage.Operation, resetType string) error {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	if c.FailAll {
+		return errors.New("stub reset failed")
+	}
+	c.Resets = append(c.Resets, operation.Xname+":"+resetType)
+	return nil
+}
+
+func (c *StubPowerClient) PowerState(operation storage.Operation) (string, error) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	if state, ok := c.States[operation.Xname]; ok {
+		return state, nil
+	}
+	return rf.POWER_STATE_ON, nil
+}
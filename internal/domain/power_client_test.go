@@ -0,0 +1,153 @@
+/*
+ * MIT License
+ *
+ * (C) Copyright [2026] Hewlett Packard Enterprise Development LP
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a
+ * copy of this software and associated documentation files (the "Software"),
+ * to deal in the Software without restriction, including without limitation
+ * the rights to use, copy, modify, merge, publish, distribute, sublicense,
+ * and/or sell copies of the Software, and to permit persons to whom the
+ * Software is furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included
+ * in all copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL
+ * THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+ * OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+ * ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+ * OTHER DEALINGS IN THE SOFTWARE.
+ */
+
+package domain
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/Cray-HPE/hms-firmware-action/internal/storage"
+	"github.com/stretchr/testify/suite"
+)
+
+type PowerClient_TS struct {
+	suite.Suite
+	server   *httptest.Server
+	lock     sync.Mutex
+	requests []string
+	payloads []map[string]interface{}
+}
+
+func (suite *PowerClient_TS) SetupTest() {
+	suite.requests = nil
+	suite.payloads = nil
+	suite.server = httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		suite.lock.Lock()
+		defer suite.lock.Unlock()
+		suite.requests = append(suite.requests, req.Method+" "+req.URL.RequestURI())
+		if req.Method == http.MethodPost {
+			var payload map[string]interface{}
+			_ = json.NewDecoder(req.Body).Decode(&payload)
+			suite.payloads = append(suite.payloads, payload)
+		}
+		switch {
+		case req.Method == http.MethodPost && strings.HasSuffix(req.URL.Path, "ComputerSystem.Reset"):
+			w.WriteHeader(http.StatusNoContent)
+		case req.Method == http.MethodGet && req.URL.Path == "/redfish/v1/Systems/Node1":
+			_ = json.NewEncoder(w).Encode(map[string]string{"PowerState": "Off"})
+		case req.Method == http.MethodPost && req.URL.Path == "/v1/transitions":
+			w.WriteHeader(http.StatusOK)
+		case req.Method == http.MethodGet && req.URL.Path == "/v1/power-status":
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{
+				"status": []map[string]string{{"xname": req.URL.Query().Get("xname"), "powerState": "on"}},
+			})
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+}
+
+func (suite *PowerClient_TS) TearDownTest() {
+	suite.server.Close()
+}
+
+func (suite *PowerClient_TS) operation() storage.Operation {
+	operation := storage.HelperGetStockOperation()
+	operation.Xname = "x1000c0s0b0"
+	operation.Target = "Node1.BIOS"
+	operation.HsmData.Type = "NodeBMC"
+	operation.HsmData.FQDN = strings.TrimPrefix(suite.server.URL, "https://")
+	operation.HsmData.ActionReset.Target = "/redfish/v1/Systems/Node1/Actions/ComputerSystem.Reset"
+	return operation
+}
+
+func (suite *PowerClient_TS) Test_NewPowerClient() {
+	client, err := NewPowerClient("", "")
+	suite.True(err == nil)
+	suite.IsType(&RedfishPowerClient{}, client)
+	client, err = NewPowerClient(PowerClientPCS, "")
+	suite.True(err == nil)
+	suite.Equal(DefaultPCSURL, client.(*PCSPowerClient).URL)
+	client, err = NewPowerClient(PowerClientStub, "")
+	suite.True(err == nil)
+	suite.IsType(&StubPowerClient{}, client)
+	_, err = NewPowerClient("ipmi", "")
+	suite.True(err != nil)
+}
+
+func (suite *PowerClient_TS) Test_Redfish() {
+	client := &RedfishPowerClient{}
+	operation := suite.operation()
+	suite.True(client.Reset(operation, "ForceRestart") == nil)
+	suite.Equal("ForceRestart", suite.payloads[0]["ResetType"])
+	state, err := client.PowerState(operation)
+	suite.True(err == nil)
+	suite.Equal("Off", state)
+
+	operation.HsmData.ActionReset.Target = ""
+	suite.True(client.Reset(operation, "ForceRestart") != nil)
+	state, err = client.PowerState(operation)
+	suite.True(err == nil)
+	suite.Equal("On", state)
+}
+
+func (suite *PowerClient_TS) Test_PCS() {
+	client := &PCSPowerClient{URL: suite.server.URL + "/v1"}
+	operation := suite.operation()
+	suite.True(client.Reset(operation, "GracefulRestart") == nil)
+	suite.Equal("soft-restart", suite.payloads[0]["operation"])
+	suite.Equal("x1000c0s0b0n1", suite.payloads[0]["location"].([]interface{})[0].(map[string]interface{})["xname"])
+	state, err := client.PowerState(operation)
+	suite.True(err == nil)
+	suite.Equal("On", state)
+	suite.Equal("GET /v1/power-status?xname=x1000c0s0b0n1", suite.requests[1])
+
+	operation.HsmData.Type = "RouterBMC"
+	suite.Equal("x1000c0s0b0", powerXname(operation))
+	suite.Equal("hard-restart", pcsOperation("ForceRestart"))
+}
+
+func (suite *PowerClient_TS) Test_Stub() {
+	client := &StubPowerClient{States: map[string]string{"x1000c0s1b0": "Off"}}
+	operation := suite.operation()
+	suite.True(client.Reset(operation, "ForceRestart") == nil)
+	suite.Equal([]string{"x1000c0s0b0:ForceRestart"}, client.Resets)
+	state, _ := client.PowerState(operation)
+	suite.Equal("On", state)
+	operation.Xname = "x1000c0s1b0"
+	state, _ = client.PowerState(operation)
+	suite.Equal("Off", state)
+	client.FailAll = true
+	suite.True(client.Reset(operation, "ForceRestart") != nil)
+}
+
+func Test_Domain_PowerClient(t *testing.T) {
+	ConfigureSystemForUnitTesting()
+	suite.Run(t, new(PowerClient_TS))
+}
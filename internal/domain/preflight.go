@@ -0,0 +1,324 @@
+/*
+ * MIT License
+ *
+ * (C) Copyright [2026] Hewlett Packard Enterprise Development LP
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a
+ * copy of this software and associated documentation files (the "Software"),
+ * to deal in the Software without restriction, including without limitation
+ * the rights to use, copy, modify, merge, publish, distribute, sublicense,
+ * and/or sell copies of the Software, and to permit persons to whom the
+ * Software is furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included
+ * in all copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL
+ * THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+ * OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+ * ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+ * OTHER DEALINGS IN THE SOFTWARE.
+ */
+
+package domain
+
+import (
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/Cray-HPE/hms-firmware-action/internal/hsm"
+	"github.com/Cray-HPE/hms-firmware-action/internal/model"
+	"github.com/Cray-HPE/hms-firmware-action/internal/presentation"
+	"github.com/Cray-HPE/hms-firmware-action/internal/storage"
+	rf "github.com/Cray-HPE/hms-smd/v2/pkg/redfish"
+	"github.com/Cray-HPE/hms-smd/v2/pkg/sm"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	PreflightCheckBMCReachable = "bmcReachable"
+	PreflightCheckCredentials  = "credentials"
+	PreflightCheckLock         = "lock"
+	PreflightCheckImageFile    = "imageFile"
+	PreflightCheckPowerState   = "powerState"
+)
+
+// PreflightAction -> plans the action the parameters describe and checks every device it would update the way
+// doLaunch is going to, except for the power state (see powerStateCheck); nothing is stored and no operation is
+// created
+func PreflightAction(params storage.ActionParameters) (pb model.Passback) {
+	err := ValidateActionParameters(&params)
+	if err != nil {
+		pb = model.BuildErrorPassback(http.StatusBadRequest, err)
+		return
+	}
+
+	action := storage.NewAction(params)
+	operations, errs := PlanOperations(*action)
+	imageMap := GetImageMap()
+
+	var launchable []storage.Operation
+	for _, operation := range operations {
+		if willRun(operation) {
+			launchable = append(launchable, operation)
+		}
+	}
+	checks := preflightChecks(launchable, imageMap, params.Command)
+
+	report := presentation.PreflightReport{
+		Devices: []presentation.PreflightDevice{},
+		Errors:  model.RemoveDuplicateStrings(errs),
+	}
+	if report.Errors == nil {
+		report.Errors = []string{}
+	}
+	for _, operation := range operations {
+		device := presentation.PreflightDevice{
+			Xname:               operation.Xname,
+			Target:              operation.Target,
+			TargetName:          operation.TargetName,
+			State:               operation.State.Current(),
+			StateHelper:         operation.StateHelper,
+			FailureCode:         operation.FailureCode,
+			FromFirmwareVersion: operation.FromFirmwareVersion,
+			ToImageID:           operation.ToImageID,
+		}
+		if image, ok := imageMap[operation.ToImageID]; ok {
+			device.ToFirmwareVersion = image.FirmwareVersion
+		}
+		report.Counts.Total++
+		if willRun(operation) {
+			device.Checks = checks[operation.OperationID]
+			device.Ready = true
+			for _, check := range device.Checks {
+				if check.Result == presentation.PreflightFailed {
+					device.Ready = false
+				}
+			}
+			if device.Ready {
+				report.Counts.Ready++
+			} else {
+				report.Counts.NotReady++
+			}
+		} else if operation.State.Is("noOperation") {
+			report.Counts.NoOperation++
+		} else {
+			report.Counts.NoSolution++
+		}
+		report.Devices = append(report.Devices, device)
+	}
+	sort.Slice(report.Devices, func(i, j int) bool {
+		if report.Devices[i].Xname != report.Devices[j].Xname {
+			return report.Devices[i].Xname < report.Devices[j].Xname
+		}
+		return report.Devices[i].Target < report.Devices[j].Target
+	})
+	report.Ready = report.Counts.NotReady == 0
+
+	pb = model.BuildSuccessPassback(http.StatusOK, report)
+	return
+}
+
+// preflightChecks -> runs the checks of every operation; a BMC is asked once, however many of its targets are updated
+func preflightChecks(operations []storage.Operation, imageMap map[uuid.UUID]storage.Image,
+	command storage.Command) (checks map[uuid.UUID][]presentation.PreflightCheck) {
+	checks = make(map[uuid.UUID][]presentation.PreflightCheck)
+
+	files := make(map[string]presentation.PreflightCheck)
+	byXname := make(map[string][]storage.Operation)
+	for _, operation := range operations {
+		image := imageMap[operation.ToImageID]
+		location := image.S3URL
+		if len(image.TftpURL) > 0 {
+			location = image.TftpURL
+		}
+		if _, ok := files[location]; !ok {
+			files[location] = imageFileCheck(location)
+		}
+		byXname[operation.Xname] = append(byXname[operation.Xname], operation)
+	}
+
+	locks := lockChecks(byXname, command)
+
+	var lock sync.Mutex
+	var wg sync.WaitGroup
+	for xname, xnameOperations := range byXname {
+		wg.Add(1)
+		go func(xname string, xnameOperations []storage.Operation) {
+			defer wg.Done()
+			hd := xnameOperations[0].HsmData
+			(*GLOB.HSM).RestoreCredentials(&hd)
+			reachable, credentials := bmcChecks(&hd)
+			for _, operation := range xnameOperations {
+				operation.HsmData.User = hd.User
+				operation.HsmData.Password = hd.Password
+				image := imageMap[operation.ToImageID]
+				location := image.S3URL
+				if len(image.TftpURL) > 0 {
+					location = image.TftpURL
+				}
+				power := presentation.PreflightCheck{Name: PreflightCheckPowerState, Result: presentation.PreflightSkipped,
+					Detail: "not checked, the BMC is not reachable"}
+				if reachable.Result == presentation.PreflightPassed {
+					power = powerStateCheck(operation, image)
+				}
+				lock.Lock()
+				checks[operation.OperationID] = []presentation.PreflightCheck{reachable, credentials, locks[xname],
+					files[location], power}
+				lock.Unlock()
+			}
+		}(xname, xnameOperations)
+	}
+	wg.Wait()
+	return
+}
+
+// bmcChecks -> the service root answers without credentials; the UpdateService FAS posts to does not
+func bmcChecks(hd *hsm.HsmData) (reachable presentation.PreflightCheck, credentials presentation.PreflightCheck) {
+	reachable = presentation.PreflightCheck{Name: PreflightCheckBMCReachable, Result: presentation.PreflightPassed}
+	credentials = presentation.PreflightCheck{Name: PreflightCheckCredentials, Result: presentation.PreflightPassed}
+
+	status, _, _, err := sendRedfishRequest(hd, http.MethodGet, "/redfish/v1/", nil)
+	if err != nil {
+		reachable.Result = presentation.PreflightFailed
+		reachable.Detail = err.Error()
+		credentials.Result = presentation.PreflightSkipped
+		credentials.Detail = "not checked, the BMC is not reachable"
+		return
+	}
+	reachable.Detail = "service root returned " + strconv.Itoa(status)
+
+	if hd.User == "" && hd.Password == "" {
+		credentials.Result = presentation.PreflightFailed
+		credentials.Detail = "no credentials found for " + hd.ID
+		return
+	}
+	status, _, _, err = sendRedfishRequest(hd, http.MethodGet, "/redfish/v1/UpdateService", nil)
+	if err != nil {
+		credentials.Result = presentation.PreflightFailed
+		credentials.Detail = err.Error()
+	} else if status == http.StatusUnauthorized || status == http.StatusForbidden {
+		credentials.Result = presentation.PreflightFailed
+		credentials.Detail = "the BMC rejected the credentials: " + strconv.Itoa(status)
+	} else {
+		credentials.Detail = "UpdateService returned " + strconv.Itoa(status)
+	}
+	return
+}
+
+// lockChecks -> a dry run never locks; otherwise the xname must be neither locked nor reserved by anyone, FAS included
+func lockChecks(byXname map[string][]storage.Operation, command storage.Command) (checks map[string]presentation.PreflightCheck) {
+	checks = make(map[string]presentation.PreflightCheck)
+	var xnames []string
+	for xname := range byXname {
+		xnames = append(xnames, xname)
+		checks[xname] = presentation.PreflightCheck{Name: PreflightCheckLock, Result: presentation.PreflightSkipped,
+			Detail: "a dry run does not lock"}
+	}
+	if !command.OverrideDryrun || len(xnames) == 0 {
+		return
+	}
+
+	locks, err := (*GLOB.HSM).LockStatus(xnames)
+	for _, xname := range xnames {
+		check := presentation.PreflightCheck{Name: PreflightCheckLock, Result: presentation.PreflightPassed}
+		lock, ok := locks[xname]
+		if err != nil {
+			check.Result = presentation.PreflightFailed
+			check.Detail = "could not get the lock status: " + err.Error()
+		} else if locks == nil {
+			check.Result = presentation.PreflightSkipped
+			check.Detail = "locking is disabled"
+		} else if !ok {
+			check.Result = presentation.PreflightFailed
+			check.Detail = "unknown to HSM"
+		} else if detail := lockProblem(lock); detail != "" {
+			check.Result = presentation.PreflightFailed
+			check.Detail = detail
+		}
+		checks[xname] = check
+	}
+	return
+}
+
+func lockProblem(lock sm.CompLockV2) string {
+	var problems []string
+	if lock.Locked {
+		problems = append(problems, "locked")
+	}
+	if lock.Reserved {
+		problems = append(problems, "reserved until "+lock.ExpirationTime)
+	}
+	if lock.ReservationDisabled {
+		problems = append(problems, "reservations disabled")
+	}
+	return strings.Join(problems, ", ")
+}
+
+// imageFileCheck -> the same check doLaunch makes before it sends the payload
+func imageFileCheck(location string) (check presentation.PreflightCheck) {
+	check = presentation.PreflightCheck{Name: PreflightCheckImageFile, Result: presentation.PreflightPassed}
+	if strings.HasPrefix(strings.ToLower(location), "tftp") {
+		check.Result = presentation.PreflightSkipped
+		check.Detail = "tftp files cannot be checked"
+		return
+	}
+	url, err := FileCheck(location)
+	if err != nil {
+		check.Result = presentation.PreflightFailed
+		check.Detail = url + ": " + err.Error()
+		logrus.WithFields(logrus.Fields{"ERROR": err, "location": location}).Debug("preflight could not find the image file")
+		return
+	}
+	check.Detail = url
+	return
+}
+
+// powerStateCheck -> the device has to be in one of the states the image allows, if it names any, by what the power
+// client reports.  doLaunch does not ask the power client, it takes every device to be On; the detail says what the
+// launch will do about it.
+func powerStateCheck(operation storage.Operation, image storage.Image) (check presentation.PreflightCheck) {
+	check = presentation.PreflightCheck{Name: PreflightCheckPowerState, Result: presentation.PreflightSkipped}
+	if len(image.AllowableDeviceStates) == 0 {
+		check.Detail = "the image allows any power state"
+		return
+	}
+	defer func() {
+		check.Detail += launchPowerStateNote(image)
+	}()
+	if GLOB.PowerClient == nil {
+		check.Detail = "no power client configured"
+		return
+	}
+	state, err := GLOB.PowerClient.PowerState(operation)
+	if err != nil {
+		check.Result = presentation.PreflightFailed
+		check.Detail = "could not get the power state: " + err.Error()
+		return
+	}
+	for _, allowed := range image.AllowableDeviceStates {
+		if strings.EqualFold(allowed, state) {
+			check.Result = presentation.PreflightPassed
+			check.Detail = state
+			return
+		}
+	}
+	check.Result = presentation.PreflightFailed
+	check.Detail = state + " is not one of " + strings.Join(image.AllowableDeviceStates, ", ")
+	return
+}
+
+func launchPowerStateNote(image storage.Image) string {
+	for _, allowed := range image.AllowableDeviceStates {
+		if strings.EqualFold(allowed, rf.POWER_STATE_ON) {
+			return "; the launch takes the device to be On and goes ahead"
+		}
+	}
+	return "; the launch takes the device to be On and waits until the operation expires"
+}
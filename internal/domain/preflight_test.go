@@ -0,0 +1,114 @@
+/*
+ * MIT License
+ *
+ * (C) Copyright [2026] Hewlett Packard Enterprise Development LP
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a
+ * copy of this software and associated documentation files (the "Software"),
+ * to deal in the Software without restriction, including without limitation
+ * the rights to use, copy, modify, merge, publish, distribute, sublicense,
+ * and/or sell copies of the Software, and to permit persons to whom the
+ * Software is furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included
+ * in all copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL
+ * THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+ * OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+ * ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+ * OTHER DEALINGS IN THE SOFTWARE.
+ */
+
+package domain
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/Cray-HPE/hms-firmware-action/internal/presentation"
+	"github.com/Cray-HPE/hms-firmware-action/internal/storage"
+	"github.com/Cray-HPE/hms-smd/v2/pkg/sm"
+	"github.com/stretchr/testify/suite"
+)
+
+type Preflight_TS struct {
+	suite.Suite
+}
+
+func (suite *Preflight_TS) Test_ImageFileCheck() {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if strings.HasSuffix(req.URL.Path, "missing.bin") {
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	check := imageFileCheck(server.URL + "/fw/bios.bin")
+	suite.Equal(presentation.PreflightPassed, check.Result)
+	suite.Equal(PreflightCheckImageFile, check.Name)
+
+	check = imageFileCheck(server.URL + "/fw/missing.bin")
+	suite.Equal(presentation.PreflightFailed, check.Result)
+
+	check = imageFileCheck("tftp://fw-update/bios.bin")
+	suite.Equal(presentation.PreflightSkipped, check.Result)
+}
+
+func (suite *Preflight_TS) Test_PowerStateCheck() {
+	defer func(client PowerClient) { GLOB.PowerClient = client }(GLOB.PowerClient)
+
+	operation := storage.HelperGetStockOperation()
+	operation.Xname = "x0c0s1b0"
+	image := storage.Image{AllowableDeviceStates: []string{"Off"}}
+
+	GLOB.PowerClient = nil
+	suite.Equal(presentation.PreflightSkipped, powerStateCheck(operation, image).Result)
+
+	GLOB.PowerClient = &StubPowerClient{States: map[string]string{"x0c0s1b0": "On"}}
+	suite.Equal(presentation.PreflightSkipped, powerStateCheck(operation, storage.Image{}).Result)
+	check := powerStateCheck(operation, image)
+	suite.Equal(presentation.PreflightFailed, check.Result)
+	suite.Equal("On is not one of Off; the launch takes the device to be On and waits until the operation expires", check.Detail)
+
+	image.AllowableDeviceStates = []string{"Off", "On"}
+	GLOB.PowerClient = nil
+	check = powerStateCheck(operation, image)
+	suite.Equal(presentation.PreflightSkipped, check.Result)
+	suite.Equal("no power client configured; the launch takes the device to be On and goes ahead", check.Detail)
+	image.AllowableDeviceStates = []string{"Off"}
+
+	GLOB.PowerClient = &StubPowerClient{States: map[string]string{"x0c0s1b0": "off"}}
+	suite.Equal(presentation.PreflightPassed, powerStateCheck(operation, image).Result)
+}
+
+func (suite *Preflight_TS) Test_LockProblem() {
+	suite.Empty(lockProblem(sm.CompLockV2{ID: "x0c0s1b0"}))
+	suite.Equal("locked", lockProblem(sm.CompLockV2{ID: "x0c0s1b0", Locked: true}))
+	suite.Equal("reserved until 2026-10-19T10:00:00Z, reservations disabled", lockProblem(sm.CompLockV2{ID: "x0c0s1b0",
+		Reserved: true, ExpirationTime: "2026-10-19T10:00:00Z", ReservationDisabled: true}))
+}
+
+func (suite *Preflight_TS) Test_LockChecks_DryRun() {
+	byXname := map[string][]storage.Operation{"x0c0s1b0": {storage.HelperGetStockOperation()}}
+	checks := lockChecks(byXname, storage.Command{OverrideDryrun: false})
+	suite.Equal(presentation.PreflightSkipped, checks["x0c0s1b0"].Result)
+	suite.Equal(PreflightCheckLock, checks["x0c0s1b0"].Name)
+}
+
+func (suite *Preflight_TS) Test_PreflightAction_BadParameters() {
+	pb := PreflightAction(storage.ActionParameters{
+		StateComponentFilter: storage.StateComponentFilter{Xnames: []string{"badXname"}},
+	})
+	suite.True(pb.IsError)
+	suite.Equal(http.StatusBadRequest, pb.StatusCode)
+}
+
+func Test_Domain_Preflight(t *testing.T) {
+	ConfigureSystemForUnitTesting()
+	suite.Run(t, new(Preflight_TS))
+}
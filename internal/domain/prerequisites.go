@@ -0,0 +1,216 @@
+/*
+ * MIT License
+ *
+ * (C) Copyright [2026] Hewlett Packard Enterprise Development LP
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a
+ * copy of this software and associated documentation files (the "Software"),
+ * to deal in the Software without restriction, including without limitation
+ * the rights to use, copy, modify, merge, publish, distribute, sublicense,
+ * and/or sell copies of the Software, and to permit persons to whom the
+ * Software is furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included
+ * in all copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL
+ * THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+ * OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+ * ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+ * OTHER DEALINGS IN THE SOFTWARE.
+ */
+
+package domain
+
+import (
+	"context"
+	"time"
+
+	"github.com/Cray-HPE/hms-firmware-action/internal/storage"
+	"github.com/Masterminds/semver/v3"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+)
+
+// SetPrerequisiteBlockers -> follows the prerequisites declared by the ToImage of every configured operation.  A
+// prerequisite met by another operation of the action on the same xname makes that operation a blocker (BlockedBy and
+// DependsOn); one met by what is already on the device needs nothing.  Operations whose prerequisites cannot be met,
+// directly or through a chain, or that wait on each other in a circle, are set to noSolution with the reason, along
+// with the rest of their upgrade path.
+func SetPrerequisiteBlockers(candidateOperations *map[uuid.UUID]storage.Operation, imageMap *map[uuid.UUID]storage.Image, deviceMap *map[string]storage.Device) {
+	for {
+		dependencies := make(map[uuid.UUID][]uuid.UUID)
+		unmet := make(map[uuid.UUID]string)
+		for operationID, operation := range *candidateOperations {
+			if !willRun(operation) {
+				continue
+			}
+			for _, prerequisite := range (*imageMap)[operation.ToImageID].Prerequisites {
+				dependency, reason := resolvePrerequisite(operation, prerequisite, candidateOperations, imageMap, deviceMap)
+				if reason != "" {
+					unmet[operationID] = reason
+					break
+				}
+				if dependency != uuid.Nil {
+					dependencies[operationID] = append(dependencies[operationID], dependency)
+				}
+			}
+		}
+		if len(unmet) == 0 {
+			//upgrade path steps already wait on each other, and can close a circle too
+			graph := make(map[uuid.UUID][]uuid.UUID)
+			for operationID, operation := range *candidateOperations {
+				if willRun(operation) {
+					graph[operationID] = append(append([]uuid.UUID{}, operation.DependsOn...), dependencies[operationID]...)
+				}
+			}
+			for _, operationID := range findPrerequisiteCycles(graph) {
+				unmet[operationID] = "prerequisites are circular"
+			}
+		}
+
+		if len(unmet) == 0 {
+			for operationID, dependsOn := range dependencies {
+				operation := (*candidateOperations)[operationID]
+				operation.DependsOn = append(operation.DependsOn, dependsOn...)
+				operation.BlockedBy = append(operation.BlockedBy, dependsOn...)
+				if operation.State.Can("block") {
+					operation.State.Event(context.Background(), "block")
+					operation.StateHelper = "blocked by prerequisite"
+				}
+				(*candidateOperations)[operationID] = operation
+			}
+			return
+		}
+
+		//anything that depended on these gets re-evaluated against their new state on the next pass
+		for operationID, reason := range unmet {
+			logrus.WithFields(logrus.Fields{"operationID": operationID, "reason": reason}).Debug("prerequisite not met")
+			setNoSolution(candidateOperations, operationID, storage.FailurePrerequisiteNotMet, "prerequisite not met: "+reason)
+		}
+	}
+}
+
+// willRun -> configured, or blocked on another operation of the same action
+func willRun(operation storage.Operation) bool {
+	return operation.State.Is("configured") || operation.State.Is("blocked")
+}
+
+// setNoSolution -> ends an operation that will not be performed, along with the rest of its upgrade path
+func setNoSolution(candidateOperations *map[uuid.UUID]storage.Operation, operationID uuid.UUID, failureCode string, stateHelper string) {
+	chain := (*candidateOperations)[operationID].UpgradePath
+	if len(chain) == 0 {
+		chain = []uuid.UUID{operationID}
+	}
+	for _, chainID := range chain {
+		operation := (*candidateOperations)[chainID]
+		if !willRun(operation) {
+			continue
+		}
+		operation.State.Event(context.Background(), "nosol")
+		operation.EndTime.Scan(time.Now())
+		operation.StateHelper = stateHelper
+		operation.FailureCode = failureCode
+		(*candidateOperations)[chainID] = operation
+	}
+}
+
+// resolvePrerequisite -> returns the operation that has to run first, or nothing if the device already satisfies the
+// prerequisite, or why it cannot be satisfied
+func resolvePrerequisite(operation storage.Operation, prerequisite storage.ImagePrerequisite, candidateOperations *map[uuid.UUID]storage.Operation,
+	imageMap *map[uuid.UUID]storage.Image, deviceMap *map[string]storage.Device) (dependency uuid.UUID, reason string) {
+	for _, other := range *candidateOperations {
+		if other.Xname != operation.Xname || (other.Target != prerequisite.Target && other.TargetName != prerequisite.Target) {
+			continue
+		}
+		//only the last step of an upgrade path says where the target ends up
+		if len(other.UpgradePath) > 0 && other.UpgradePath[len(other.UpgradePath)-1] != other.OperationID {
+			continue
+		}
+		if willRun(other) {
+			toVersion := (*imageMap)[other.ToImageID].SemanticFirmwareVersion
+			if !prerequisite.SatisfiedBy(toVersion) {
+				return uuid.Nil, prerequisite.Target + " will only be updated to " + versionString(toVersion) +
+					"; " + prerequisite.MinimumVersion + " or later is required"
+			}
+			return other.OperationID, ""
+		}
+		//not going to be updated, so it has to be good enough already
+		current := currentSemanticVersion(other.Manufacturer, prerequisite.Target, other.FromFirmwareVersion, imageMap)
+		if fromImage, ok := (*imageMap)[other.FromImageID]; ok {
+			current = fromImage.SemanticFirmwareVersion
+		}
+		if !prerequisite.SatisfiedBy(current) {
+			return uuid.Nil, prerequisite.Target + " is at " + versionString(current) + " and is " + other.State.Current() +
+				"; " + prerequisite.MinimumVersion + " or later is required"
+		}
+		return uuid.Nil, ""
+	}
+
+	//the target is not part of this action; go by what the device reported, if it was scanned at all
+	if device, ok := (*deviceMap)[operation.Xname]; ok {
+		for _, target := range device.Targets {
+			if target.Name == prerequisite.Target || target.TargetName == prerequisite.Target {
+				current := currentSemanticVersion(operation.Manufacturer, prerequisite.Target, target.FirmwareVersion, imageMap)
+				if !prerequisite.SatisfiedBy(current) {
+					return uuid.Nil, prerequisite.Target + " is at " + versionString(current) + "; " +
+						prerequisite.MinimumVersion + " or later is required"
+				}
+				return uuid.Nil, ""
+			}
+		}
+	}
+	return uuid.Nil, "the version of " + prerequisite.Target + " is unknown; include it in the action"
+}
+
+// currentSemanticVersion -> the semantic version of the image matching what the device reports, falling back to
+// reading the reported version itself with the rules of the vendor
+func currentSemanticVersion(manufacturer string, target string, firmwareVersion string, imageMap *map[uuid.UUID]storage.Image) *semver.Version {
+	if firmwareVersion == "" {
+		return nil
+	}
+	for _, image := range *imageMap {
+		if image.Target == target && image.FirmwareVersion == firmwareVersion {
+			return image.SemanticFirmwareVersion
+		}
+	}
+	return ParseFirmwareVersion(manufacturer, target, firmwareVersion)
+}
+
+func versionString(version *semver.Version) string {
+	if version == nil {
+		return "an unknown version"
+	}
+	return version.String()
+}
+
+// findPrerequisiteCycles -> peels off every operation whose dependencies can all run first; whatever is left waits on
+// a circle, directly or through one
+func findPrerequisiteCycles(dependencies map[uuid.UUID][]uuid.UUID) (cyclic []uuid.UUID) {
+	remaining := make(map[uuid.UUID][]uuid.UUID)
+	for operationID, dependsOn := range dependencies {
+		remaining[operationID] = dependsOn
+	}
+	for peeled := true; peeled; {
+		peeled = false
+		for operationID, dependsOn := range remaining {
+			ready := true
+			for _, dependency := range dependsOn {
+				if _, waiting := remaining[dependency]; waiting {
+					ready = false
+					break
+				}
+			}
+			if ready {
+				delete(remaining, operationID)
+				peeled = true
+			}
+		}
+	}
+	for operationID := range remaining {
+		cyclic = append(cyclic, operationID)
+	}
+	return cyclic
+}
@@ -0,0 +1,156 @@
+/*
+ * MIT License
+ *
+ * (C) Copyright [2026] Hewlett Packard Enterprise Development LP
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a
+ * copy of this software and associated documentation files (the "Software"),
+ * to deal in the Software without restriction, including without limitation
+ * the rights to use, copy, modify, merge, publish, distribute, sublicense,
+ * and/or sell copies of the Software, and to permit persons to whom the
+ * Software is furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included
+ * in all copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL
+ * THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+ * OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+ * ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+ * OTHER DEALINGS IN THE SOFTWARE.
+ */
+
+package domain
+
+import (
+	"testing"
+
+	"github.com/Cray-HPE/hms-firmware-action/internal/storage"
+	"github.com/Masterminds/semver/v3"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/suite"
+)
+
+type Prerequisites_TS struct {
+	suite.Suite
+}
+
+func helperPrerequisiteImage(target string, version string, prerequisites ...storage.ImagePrerequisite) storage.Image {
+	image := storage.Image{
+		ImageID:                 uuid.New(),
+		Target:                  target,
+		FirmwareVersion:         "fw-" + version,
+		SemanticFirmwareVersion: semver.MustParse(version),
+		Prerequisites:           prerequisites,
+	}
+	return image
+}
+
+func helperPrerequisiteOperation(xname string, target string, toImage storage.Image) storage.Operation {
+	operation := storage.HelperGetStockOperation()
+	operation.Xname = xname
+	operation.Target = target
+	operation.ToImageID = toImage.ImageID
+	operation.State.SetState("configured")
+	return operation
+}
+
+func (suite *Prerequisites_TS) Test_SetPrerequisiteBlockers() {
+	bmc := helperPrerequisiteImage("BMC", "2.0.0")
+	bios := helperPrerequisiteImage("BIOS", "1.4.0", storage.ImagePrerequisite{Target: "BMC", MinimumVersion: "1.5.0"})
+	cpld := helperPrerequisiteImage("CPLD", "1.0.0", storage.ImagePrerequisite{Target: "BMC", MinimumVersion: "3.0.0"})
+	imageMap := map[uuid.UUID]storage.Image{bmc.ImageID: bmc, bios.ImageID: bios, cpld.ImageID: cpld}
+
+	bmcOp := helperPrerequisiteOperation("x0c0s1b0", "BMC", bmc)
+	biosOp := helperPrerequisiteOperation("x0c0s1b0", "BIOS", bios)
+	cpldOp := helperPrerequisiteOperation("x0c0s1b0", "CPLD", cpld)
+	//the BMC on this one is not part of the action and was found at a version that is too old
+	otherBiosOp := helperPrerequisiteOperation("x0c0s2b0", "BIOS", bios)
+	candidates := map[uuid.UUID]storage.Operation{bmcOp.OperationID: bmcOp, biosOp.OperationID: biosOp,
+		cpldOp.OperationID: cpldOp, otherBiosOp.OperationID: otherBiosOp}
+	deviceMap := map[string]storage.Device{
+		"x0c0s2b0": {Xname: "x0c0s2b0", Targets: []storage.Target{{Name: "BMC", FirmwareVersion: "1.0.0"}}},
+	}
+
+	SetPrerequisiteBlockers(&candidates, &imageMap, &deviceMap)
+
+	suite.True(candidates[bmcOp.OperationID].State.Is("configured"))
+	suite.Empty(candidates[bmcOp.OperationID].FailureCode)
+	suite.True(candidates[biosOp.OperationID].State.Is("blocked"))
+	suite.Equal([]uuid.UUID{bmcOp.OperationID}, candidates[biosOp.OperationID].DependsOn)
+	suite.Equal([]uuid.UUID{bmcOp.OperationID}, candidates[biosOp.OperationID].BlockedBy)
+	suite.True(candidates[cpldOp.OperationID].State.Is("noSolution"))
+	suite.Contains(candidates[cpldOp.OperationID].StateHelper, "3.0.0 or later is required")
+	suite.Equal(storage.FailurePrerequisiteNotMet, candidates[cpldOp.OperationID].FailureCode)
+	suite.True(candidates[otherBiosOp.OperationID].State.Is("noSolution"))
+	suite.Contains(candidates[otherBiosOp.OperationID].StateHelper, "BMC is at 1.0.0")
+	suite.Equal(storage.FailurePrerequisiteNotMet, candidates[otherBiosOp.OperationID].FailureCode)
+}
+
+func (suite *Prerequisites_TS) Test_SetPrerequisiteBlockers_Chain() {
+	//the BMC has no image, so the BIOS cannot go, so the NIC that needs the BIOS cannot go either
+	bmc := helperPrerequisiteImage("BMC", "2.0.0")
+	bios := helperPrerequisiteImage("BIOS", "1.4.0", storage.ImagePrerequisite{Target: "BMC", MinimumVersion: "2.0.0"})
+	nic := helperPrerequisiteImage("NIC", "1.0.0", storage.ImagePrerequisite{Target: "BIOS", MinimumVersion: "1.4.0"})
+	imageMap := map[uuid.UUID]storage.Image{bios.ImageID: bios, nic.ImageID: nic}
+
+	bmcOp := helperPrerequisiteOperation("x0c0s1b0", "BMC", bmc)
+	bmcOp.State.SetState("noSolution")
+	biosOp := helperPrerequisiteOperation("x0c0s1b0", "BIOS", bios)
+	nicOp := helperPrerequisiteOperation("x0c0s1b0", "NIC", nic)
+	candidates := map[uuid.UUID]storage.Operation{bmcOp.OperationID: bmcOp, biosOp.OperationID: biosOp, nicOp.OperationID: nicOp}
+	deviceMap := map[string]storage.Device{}
+
+	SetPrerequisiteBlockers(&candidates, &imageMap, &deviceMap)
+	suite.True(candidates[biosOp.OperationID].State.Is("noSolution"))
+	suite.True(candidates[nicOp.OperationID].State.Is("noSolution"))
+	suite.Contains(candidates[nicOp.OperationID].StateHelper, "BIOS")
+}
+
+func (suite *Prerequisites_TS) Test_SetPrerequisiteBlockers_Circular() {
+	bmc := helperPrerequisiteImage("BMC", "2.0.0", storage.ImagePrerequisite{Target: "BIOS", MinimumVersion: "1.0.0"})
+	bios := helperPrerequisiteImage("BIOS", "1.4.0", storage.ImagePrerequisite{Target: "BMC", MinimumVersion: "1.0.0"})
+	imageMap := map[uuid.UUID]storage.Image{bmc.ImageID: bmc, bios.ImageID: bios}
+
+	bmcOp := helperPrerequisiteOperation("x0c0s1b0", "BMC", bmc)
+	biosOp := helperPrerequisiteOperation("x0c0s1b0", "BIOS", bios)
+	candidates := map[uuid.UUID]storage.Operation{bmcOp.OperationID: bmcOp, biosOp.OperationID: biosOp}
+	deviceMap := map[string]storage.Device{}
+
+	SetPrerequisiteBlockers(&candidates, &imageMap, &deviceMap)
+	suite.True(candidates[bmcOp.OperationID].State.Is("noSolution"))
+	suite.True(candidates[biosOp.OperationID].State.Is("noSolution"))
+	suite.Contains(candidates[biosOp.OperationID].StateHelper, "circular")
+}
+
+func (suite *Prerequisites_TS) Test_CheckBlockage_FailedPrerequisite() {
+	bmcOp := storage.HelperGetStockOperation()
+	bmcOp.Target = "BMC"
+	bmcOp.State.SetState("failed")
+	biosOp := storage.HelperGetStockOperation()
+	biosOp.State.SetState("blocked")
+	biosOp.BlockedBy = []uuid.UUID{bmcOp.OperationID}
+	biosOp.DependsOn = []uuid.UUID{bmcOp.OperationID}
+	//a sibling blocker that fails does not take the operation down with it
+	nicOp := storage.HelperGetStockOperation()
+	nicOp.State.SetState("blocked")
+	nicOp.BlockedBy = []uuid.UUID{bmcOp.OperationID}
+
+	operations := []storage.Operation{bmcOp, biosOp, nicOp}
+	CheckBlockage(&operations)
+
+	biosRet, err := GetStoredOperation(biosOp.OperationID)
+	suite.True(err == nil)
+	suite.True(biosRet.State.Is("noSolution"))
+	suite.Equal("prerequisite not met: BMC failed", biosRet.StateHelper)
+	nicRet, err := GetStoredOperation(nicOp.OperationID)
+	suite.True(err == nil)
+	suite.True(nicRet.State.Is("configured"))
+}
+
+func Test_Domain_Prerequisites(t *testing.T) {
+	ConfigureSystemForUnitTesting()
+	suite.Run(t, new(Prerequisites_TS))
+}
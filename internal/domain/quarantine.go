@@ -0,0 +1,184 @@
+/*
+ * MIT License
+ *
+ * (C) Copyright [2026] Hewlett Packard Enterprise Development LP
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a
+ * copy of this software and associated documentation files (the "Software"),
+ * to deal in the Software without restriction, including without limitation
+ * the rights to use, copy, modify, merge, publish, distribute, sublicense,
+ * and/or sell copies of the Software, and to permit persons to whom the
+ * Software is furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included
+ * in all copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL
+ * THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+ * OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+ * ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+ * OTHER DEALINGS IN THE SOFTWARE.
+ */
+
+package domain
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/Cray-HPE/hms-firmware-action/internal/model"
+	"github.com/Cray-HPE/hms-firmware-action/internal/presentation"
+	"github.com/Cray-HPE/hms-firmware-action/internal/storage"
+	"github.com/Cray-HPE/hms-xname/xnametypes"
+	"github.com/sirupsen/logrus"
+)
+
+// DefaultQuarantineThreshold -> consecutive failures of a device before it is quarantined
+const DefaultQuarantineThreshold = 3
+
+// the streak of a device is read, changed and written back; two operations on it must not interleave
+var quarantineLock sync.Mutex
+
+func GetStoredQuarantineEntries() (entries []storage.QuarantineEntry, err error) {
+	entries, err = (*GLOB.DSP).GetQuarantineEntries()
+	return
+}
+
+// RecordQuarantineOutcome -> extends or ends the failure streak of the device of an operation doLaunch or doVerify
+// just failed or succeeded; any other store of a finished operation, e.g. a restore, is no new outcome.  Dry runs
+// never touch the device, so they do not count either way.
+func RecordQuarantineOutcome(operation storage.Operation) {
+	action, err := GetStoredAction(operation.ActionID)
+	if err != nil || !action.Command.OverrideDryrun {
+		return
+	}
+
+	quarantineLock.Lock()
+	defer quarantineLock.Unlock()
+	entry, err := (*GLOB.DSP).GetQuarantineEntry(operation.Xname, operation.Target)
+	exists := err == nil
+
+	if operation.State.Is("succeeded") {
+		if exists {
+			if err = (*GLOB.DSP).DeleteQuarantineEntry(operation.Xname, operation.Target); err != nil {
+				logrus.WithFields(logrus.Fields{"ERROR": err, "xname": operation.Xname, "target": operation.Target}).Error("Could not end the failure streak")
+			}
+		}
+		return
+	}
+	if !storage.FailureCountsTowardsQuarantine(operation.FailureCode) {
+		return
+	}
+
+	entry.Xname = operation.Xname
+	entry.Target = operation.Target
+	entry.ConsecutiveFailures++
+	entry.LastFailureTime = time.Now()
+	entry.LastFailureCode = operation.FailureCode
+	entry.LastStateHelper = operation.StateHelper
+	entry.LastOperationID = operation.OperationID
+	if !entry.Quarantined && GLOB.QuarantineThreshold > 0 && entry.ConsecutiveFailures >= GLOB.QuarantineThreshold {
+		entry.Quarantined = true
+		entry.QuarantineTime = entry.LastFailureTime
+		logrus.WithFields(logrus.Fields{"xname": entry.Xname, "target": entry.Target,
+			"failures": entry.ConsecutiveFailures}).Warn("Quarantined device after repeated failures")
+	}
+	if err = (*GLOB.DSP).StoreQuarantineEntry(entry); err != nil {
+		logrus.WithFields(logrus.Fields{"ERROR": err, "xname": operation.Xname, "target": operation.Target}).Error("Could not record the failure streak")
+	}
+}
+
+// quarantinedDevices -> xname -> target -> entry, of the devices new actions skip
+func quarantinedDevices() (quarantined map[string]map[string]storage.QuarantineEntry) {
+	quarantined = make(map[string]map[string]storage.QuarantineEntry)
+	entries, err := GetStoredQuarantineEntries()
+	if err != nil {
+		logrus.WithField("ERROR", err).Error("Could not get the quarantine, no device is skipped")
+		return
+	}
+	for _, entry := range entries {
+		if !entry.Quarantined {
+			continue
+		}
+		if quarantined[entry.Xname] == nil {
+			quarantined[entry.Xname] = make(map[string]storage.QuarantineEntry)
+		}
+		quarantined[entry.Xname][entry.Target] = entry
+	}
+	return
+}
+
+// SetQuarantinedOp -> ends an operation on a quarantined device before it is launched
+func SetQuarantinedOp(candidateOperation *storage.Operation, entry storage.QuarantineEntry) {
+	candidateOperation.State.Event(context.Background(), "nosol")
+	candidateOperation.EndTime.Scan(time.Now())
+	candidateOperation.StateHelper = "quarantined after " + strconv.Itoa(entry.ConsecutiveFailures) +
+		" consecutive failures, last: " + entry.LastStateHelper
+	candidateOperation.FailureCode = storage.FailureQuarantined
+}
+
+// GetQuarantine - returns the failure streaks, or only the quarantined devices
+func GetQuarantine(quarantinedOnly bool) (pb model.Passback) {
+	entries, err := GetStoredQuarantineEntries()
+	if err != nil {
+		pb = model.BuildErrorPassback(http.StatusInternalServerError, err)
+		return
+	}
+	list := presentation.QuarantineList{Threshold: GLOB.QuarantineThreshold, Entries: []storage.QuarantineEntry{}}
+	for _, entry := range entries {
+		if quarantinedOnly && !entry.Quarantined {
+			continue
+		}
+		list.Entries = append(list.Entries, entry)
+	}
+	sort.Slice(list.Entries, func(i, j int) bool {
+		if list.Entries[i].Xname != list.Entries[j].Xname {
+			return list.Entries[i].Xname < list.Entries[j].Xname
+		}
+		return list.Entries[i].Target < list.Entries[j].Target
+	})
+	pb = model.BuildSuccessPassback(http.StatusOK, list)
+	return
+}
+
+// ClearQuarantine - forgets the failure streak of one target of an xname, or of all its targets
+func ClearQuarantine(xname string, target string) (pb model.Passback) {
+	xname = xnametypes.NormalizeHMSCompID(xname)
+	if !xnametypes.IsHMSCompIDValid(xname) {
+		pb = model.BuildErrorPassback(http.StatusBadRequest, errors.New("invalid xname: "+xname))
+		return
+	}
+
+	quarantineLock.Lock()
+	defer quarantineLock.Unlock()
+	entries, err := GetStoredQuarantineEntries()
+	if err != nil {
+		pb = model.BuildErrorPassback(http.StatusInternalServerError, err)
+		return
+	}
+	cleared := 0
+	for _, entry := range entries {
+		if entry.Xname != xname || (target != "" && entry.Target != target) {
+			continue
+		}
+		if err = (*GLOB.DSP).DeleteQuarantineEntry(entry.Xname, entry.Target); err != nil {
+			pb = model.BuildErrorPassback(http.StatusInternalServerError, err)
+			return
+		}
+		cleared++
+	}
+	if cleared == 0 {
+		pb = model.BuildErrorPassback(http.StatusNotFound, errors.New("no quarantine entry for "+xname+" "+target))
+		return
+	}
+	logrus.WithFields(logrus.Fields{"xname": xname, "target": target, "entries": cleared}).Info("Cleared quarantine")
+	pb = model.BuildSuccessPassback(http.StatusNoContent, nil)
+	return
+}
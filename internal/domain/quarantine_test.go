@@ -0,0 +1,155 @@
+/*
+ * MIT License
+ *
+ * (C) Copyright [2026] Hewlett Packard Enterprise Development LP
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a
+ * copy of this software and associated documentation files (the "Software"),
+ * to deal in the Software without restriction, including without limitation
+ * the rights to use, copy, modify, merge, publish, distribute, sublicense,
+ * and/or sell copies of the Software, and to permit persons to whom the
+ * Software is furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included
+ * in all copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL
+ * THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+ * OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+ * ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+ * OTHER DEALINGS IN THE SOFTWARE.
+ */
+
+package domain
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/Cray-HPE/hms-firmware-action/internal/presentation"
+	"github.com/Cray-HPE/hms-firmware-action/internal/storage"
+	"github.com/stretchr/testify/suite"
+)
+
+type Quarantine_TS struct {
+	suite.Suite
+}
+
+// endedOperation -> an operation of the action that ended in state
+func (suite *Quarantine_TS) endedOperation(action storage.Action, state string, failureCode string) storage.Operation {
+	operation := storage.HelperGetStockOperation()
+	operation.ActionID = action.ActionID
+	operation.Xname = "x0c0s3b0"
+	operation.Target = "BIOS"
+	operation.State.SetState(state)
+	operation.FailureCode = failureCode
+	operation.StateHelper = "update task failed"
+	return operation
+}
+
+// finishOperation -> stores an operation of the action as ended in state, the way doVerify does
+func (suite *Quarantine_TS) finishOperation(action storage.Action, state string, failureCode string) storage.Operation {
+	operation := suite.endedOperation(action, state, failureCode)
+	suite.True(StoreOperation(operation) == nil)
+	RecordQuarantineOutcome(operation)
+	return operation
+}
+
+func (suite *Quarantine_TS) Test_FailureStreak() {
+	defer func(threshold int) { GLOB.QuarantineThreshold = threshold }(GLOB.QuarantineThreshold)
+	GLOB.QuarantineThreshold = 2
+
+	action := storage.HelperGetStockAction()
+	action.Command.OverrideDryrun = true
+	suite.True(StoreAction(action) == nil)
+	defer DeleteStoredAction(action.ActionID)
+
+	//a failure that is not the fault of the device does not count
+	suite.finishOperation(action, "failed", storage.FailureLockFailed)
+	_, err := (*GLOB.DSP).GetQuarantineEntry("x0c0s3b0", "BIOS")
+	suite.NotNil(err)
+
+	suite.finishOperation(action, "failed", storage.FailureBMCTaskFailed)
+	entry, err := (*GLOB.DSP).GetQuarantineEntry("x0c0s3b0", "BIOS")
+	suite.Nil(err)
+	suite.Equal(1, entry.ConsecutiveFailures)
+	suite.False(entry.Quarantined)
+
+	last := suite.finishOperation(action, "failed", storage.FailureVerifyTimeout)
+	entry, err = (*GLOB.DSP).GetQuarantineEntry("x0c0s3b0", "BIOS")
+	suite.Nil(err)
+	suite.Equal(2, entry.ConsecutiveFailures)
+	suite.True(entry.Quarantined)
+	suite.Equal(storage.FailureVerifyTimeout, entry.LastFailureCode)
+	suite.Equal(last.OperationID, entry.LastOperationID)
+
+	pb := GetQuarantine(true)
+	suite.False(pb.IsError)
+	list := pb.Obj.(presentation.QuarantineList)
+	suite.Equal(2, list.Threshold)
+	suite.Equal(1, len(list.Entries))
+
+	//a success ends the streak
+	suite.finishOperation(action, "succeeded", "")
+	_, err = (*GLOB.DSP).GetQuarantineEntry("x0c0s3b0", "BIOS")
+	suite.NotNil(err)
+}
+
+func (suite *Quarantine_TS) Test_StoreIsNoOutcome() {
+	action := storage.HelperGetStockAction()
+	action.Command.OverrideDryrun = true
+	suite.True(StoreAction(action) == nil)
+	defer DeleteStoredAction(action.ActionID)
+
+	//e.g. a restore of a failed operation
+	operation := suite.endedOperation(action, "failed", storage.FailureBMCTaskFailed)
+	suite.True(StoreOperation(operation) == nil)
+	_, err := (*GLOB.DSP).GetQuarantineEntry("x0c0s3b0", "BIOS")
+	suite.NotNil(err)
+}
+
+func (suite *Quarantine_TS) Test_DryRunIgnored() {
+	action := storage.HelperGetStockAction()
+	suite.True(StoreAction(action) == nil)
+	defer DeleteStoredAction(action.ActionID)
+
+	suite.finishOperation(action, "failed", storage.FailureBMCTaskFailed)
+	_, err := (*GLOB.DSP).GetQuarantineEntry("x0c0s3b0", "BIOS")
+	suite.NotNil(err)
+}
+
+func (suite *Quarantine_TS) Test_SetQuarantinedOp() {
+	operation := storage.HelperGetStockOperation()
+	SetQuarantinedOp(&operation, storage.QuarantineEntry{ConsecutiveFailures: 3, LastStateHelper: "verify timed out"})
+	suite.True(operation.State.Is("noSolution"))
+	suite.Equal(storage.FailureQuarantined, operation.FailureCode)
+	suite.True(strings.Contains(operation.StateHelper, "3 consecutive failures"))
+	suite.True(operation.EndTime.Valid)
+}
+
+func (suite *Quarantine_TS) Test_ClearQuarantine() {
+	for _, target := range []string{"BMC", "BIOS"} {
+		suite.Nil((*GLOB.DSP).StoreQuarantineEntry(storage.QuarantineEntry{Xname: "x0c0s4b0", Target: target,
+			ConsecutiveFailures: 3, Quarantined: true}))
+	}
+
+	pb := ClearQuarantine("x0c0s4b0", "BMC")
+	suite.Equal(http.StatusNoContent, pb.StatusCode)
+	_, err := (*GLOB.DSP).GetQuarantineEntry("x0c0s4b0", "BIOS")
+	suite.Nil(err)
+
+	pb = ClearQuarantine("x0c0s4b0", "")
+	suite.Equal(http.StatusNoContent, pb.StatusCode)
+	pb = ClearQuarantine("x0c0s4b0", "")
+	suite.Equal(http.StatusNotFound, pb.StatusCode)
+	pb = ClearQuarantine("badXname", "")
+	suite.Equal(http.StatusBadRequest, pb.StatusCode)
+}
+
+func Test_Domain_Quarantine(t *testing.T) {
+	ConfigureSystemForUnitTesting()
+	suite.Run(t, new(Quarantine_TS))
+}
@@ -0,0 +1,288 @@
+/*
+ * MIT License
+ *
+ * (C) Copyright [2026] Hewlett Packard Enterprise Development LP
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a
+ * copy of this software and associated documentation files (the "Software"),
+ * to deal in the Software without restriction, including without limitation
+ * the rights to use, copy, modify, merge, publish, distribute, sublicense,
+ * and/or sell copies of the Software, and to permit persons to whom the
+ * Software is furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included
+ * in all copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL
+ * THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+ * OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+ * ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+ * OTHER DEALINGS IN THE SOFTWARE.
+ */
+
+package domain
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Cray-HPE/hms-firmware-action/internal/hsm"
+	"github.com/Cray-HPE/hms-firmware-action/internal/model"
+	"github.com/Cray-HPE/hms-firmware-action/internal/storage"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+)
+
+const redfishEventService = "/redfish/v1/EventService"
+const redfishEventSubscriptions = redfishEventService + "/Subscriptions"
+
+// EventFallbackPollingFactor -> how much less often the version or task of an operation is polled while its BMC posts events
+const EventFallbackPollingFactor = 5
+
+// EventSubscription -> a Redfish EventService subscription made on the BMC of an operation
+type EventSubscription struct {
+	OperationID uuid.UUID
+	URI         string
+	hsmData     hsm.HsmData
+	events      chan model.RedfishEvent
+}
+
+var eventSubscriptionsLock sync.Mutex
+var eventSubscriptions = make(map[uuid.UUID]*EventSubscription)
+
+// Events -> the events the BMC posted for the operation
+func (sub *EventSubscription) Events() <-chan model.RedfishEvent {
+	return sub.events
+}
+
+// SubscribeToEvents -> asks the BMC of the operation to post its events to FAS.  Returns nil when FAS has no event
+// destination configured or the BMC cannot do it; the caller then relies on polling.
+func SubscribeToEvents(operation storage.Operation) (sub *EventSubscription, err error) {
+	if GLOB == nil || GLOB.EventDestination == "" || operation.HsmData.FQDN == "" {
+		return nil, nil
+	}
+	hd := operation.HsmData
+
+	status, body, _, err := sendRedfishRequest(&hd, "GET", redfishEventService, nil)
+	if err != nil {
+		return nil, err
+	}
+	var service struct {
+		ServiceEnabled *bool `json:"ServiceEnabled"`
+	}
+	if status >= 400 || json.Unmarshal(body, &service) != nil || (service.ServiceEnabled != nil && !*service.ServiceEnabled) {
+		return nil, fmt.Errorf("event service not available on %s", operation.Xname)
+	}
+
+	removeStaleEventSubscriptions(&hd)
+
+	subscription, _ := json.Marshal(map[string]string{
+		"Destination": strings.TrimSuffix(GLOB.EventDestination, "/") + "/events/" + operation.OperationID.String(),
+		"Protocol":    "Redfish",
+		"Context":     operation.OperationID.String(),
+	})
+	status, body, header, err := sendRedfishRequest(&hd, "POST", redfishEventSubscriptions, subscription)
+	if err != nil {
+		return nil, err
+	}
+	if status >= 400 {
+		return nil, fmt.Errorf("event subscription on %s returned %d", operation.Xname, status)
+	}
+
+	//the location of the subscription is in the header, or in the body for some BMCs
+	uri := header.Get("Location")
+	if uri == "" {
+		var created model.TaskLink
+		if json.Unmarshal(body, &created) == nil {
+			uri = created.Link
+		}
+	}
+	if i := strings.Index(uri, "/redfish/"); i > 0 {
+		uri = uri[i:]
+	}
+
+	sub = &EventSubscription{
+		OperationID: operation.OperationID,
+		URI:         uri,
+		hsmData:     hd,
+		events:      make(chan model.RedfishEvent, 16),
+	}
+	eventSubscriptionsLock.Lock()
+	eventSubscriptions[operation.OperationID] = sub
+	eventSubscriptionsLock.Unlock()
+	logrus.WithFields(logrus.Fields{"operationID": operation.OperationID, "subscription": uri}).Debug("subscribed to events")
+	return sub, nil
+}
+
+// UnsubscribeFromEvents -> removes the subscription from the BMC and stops handing out its events
+func UnsubscribeFromEvents(sub *EventSubscription) {
+	if sub == nil {
+		return
+	}
+	eventSubscriptionsLock.Lock()
+	delete(eventSubscriptions, sub.OperationID)
+	eventSubscriptionsLock.Unlock()
+
+	if sub.URI == "" {
+		return
+	}
+	status, _, _, err := sendRedfishRequest(&sub.hsmData, "DELETE", sub.URI, nil)
+	if err == nil && status >= 400 && status != http.StatusNotFound {
+		err = fmt.Errorf("delete returned %d", status)
+	}
+	if err != nil {
+		logrus.WithFields(logrus.Fields{"operationID": sub.OperationID, "subscription": sub.URI, "err": err}).Warn("could not remove event subscription")
+	}
+}
+
+// RemoveStaleEventSubscriptions -> removes the subscriptions to FAS that no operation waits on from every BMC in HSM,
+// e.g. those left behind when FAS was restarted in the middle of a verify
+func RemoveStaleEventSubscriptions() {
+	if GLOB == nil || GLOB.EventDestination == "" {
+		return
+	}
+	hd, errs := (*GLOB.HSM).FillHSMData(nil, nil, nil, nil)
+	if len(errs) > 0 {
+		logrus.WithField("errs", errs).Warn("could not get every BMC to remove stale event subscriptions from")
+	}
+	for _, v := range hd {
+		if v.FQDN != "" {
+			removeStaleEventSubscriptions(&v)
+		}
+	}
+}
+
+// removeStaleEventSubscriptions -> deletes the subscriptions on the BMC that post to FAS, by destination or by the
+// operation named in their context, unless an operation still waits on them.  BMCs take a handful of subscriptions
+// only, so leftovers would end up refusing new ones.
+func removeStaleEventSubscriptions(hd *hsm.HsmData) {
+	status, body, _, err := sendRedfishRequest(hd, "GET", redfishEventSubscriptions, nil)
+	if err != nil || status >= 400 {
+		return
+	}
+	var collection struct {
+		Members []model.TaskLink `json:"Members"`
+	}
+	if json.Unmarshal(body, &collection) != nil {
+		return
+	}
+	destination := strings.TrimSuffix(GLOB.EventDestination, "/") + "/events/"
+	for _, member := range collection.Members {
+		uri := member.Link
+		if i := strings.Index(uri, "/redfish/"); i > 0 {
+			uri = uri[i:]
+		}
+		status, body, _, err = sendRedfishRequest(hd, "GET", uri, nil)
+		if err != nil || status >= 400 {
+			continue
+		}
+		var existing struct {
+			Destination string `json:"Destination"`
+			Context     string `json:"Context"`
+		}
+		if json.Unmarshal(body, &existing) != nil {
+			continue
+		}
+		operationID, _ := uuid.Parse(existing.Context)
+		if strings.HasPrefix(existing.Destination, destination) {
+			operationID, _ = uuid.Parse(strings.TrimPrefix(existing.Destination, destination))
+		} else if operationID == uuid.Nil {
+			continue
+		} else if _, err := (*GLOB.DSP).GetOperation(operationID); err != nil {
+			continue //somebody else's
+		}
+		eventSubscriptionsLock.Lock()
+		_, live := eventSubscriptions[operationID]
+		eventSubscriptionsLock.Unlock()
+		if live {
+			continue
+		}
+		status, _, _, err = sendRedfishRequest(hd, "DELETE", uri, nil)
+		if err == nil && status >= 400 && status != http.StatusNotFound {
+			err = fmt.Errorf("delete returned %d", status)
+		}
+		if err != nil {
+			logrus.WithFields(logrus.Fields{"xname": hd.ID, "subscription": uri, "err": err}).Warn("could not remove stale event subscription")
+		} else {
+			logrus.WithFields(logrus.Fields{"xname": hd.ID, "subscription": uri}).Debug("removed stale event subscription")
+		}
+	}
+}
+
+// DispatchRedfishEvents -> hands the events posted for an operation to whoever waits on them.  Returns false if
+// nobody does, e.g. the operation already ended.  Events are dropped rather than holding up the listener.
+func DispatchRedfishEvents(operationID uuid.UUID, payload model.RedfishEventPayload) bool {
+	eventSubscriptionsLock.Lock()
+	defer eventSubscriptionsLock.Unlock()
+	sub, ok := eventSubscriptions[operationID]
+	if !ok {
+		return false
+	}
+	for _, event := range payload.Events {
+		select {
+		case sub.events <- event:
+		default:
+			logrus.WithFields(logrus.Fields{"operationID": operationID, "messageID": event.MessageId}).Warn("event dropped")
+		}
+	}
+	return true
+}
+
+// IsUpdateCompletionEvent -> true for the messages that say an update task ended or the firmware changed:
+// TaskCompleted*, TaskAborted, UpdateSuccessful, UpdateFailed and ResourceChanged, whatever registry version
+func IsUpdateCompletionEvent(event model.RedfishEvent) bool {
+	message := event.MessageId
+	if i := strings.LastIndex(message, "."); i >= 0 {
+		message = message[i+1:]
+	}
+	for _, prefix := range []string{"TaskCompleted", "TaskAborted", "UpdateSuccessful", "UpdateFailed", "ResourceChanged"} {
+		if strings.HasPrefix(message, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+func sendRedfishRequest(hd *hsm.HsmData, method string, path string, payload []byte) (status int, body []byte, header http.Header, err error) {
+	if GLOB.RFHttpClient == nil {
+		return 0, nil, nil, errors.New("no redfish client")
+	}
+	req, err := http.NewRequest(method, "https://"+hd.FQDN+path, bytes.NewReader(payload))
+	if err != nil {
+		return
+	}
+	if payload != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if !(hd.User == "" && hd.Password == "") {
+		req.SetBasicAuth(hd.User, hd.Password)
+	}
+	reqContext, reqCtxCancel := context.WithTimeout(context.Background(), time.Second*40)
+	req = req.WithContext(reqContext)
+
+	release, err := GLOB.RFLimiter.Acquire(reqContext, hd.FQDN)
+	if err != nil {
+		reqCtxCancel()
+		return
+	}
+	defer release()
+	(*GLOB).RFClientLock.RLock()
+	resp, err := (*GLOB).RFHttpClient.Do(req)
+	(*GLOB).RFClientLock.RUnlock()
+	defer drainAndCloseBodyWithCtxCancel(resp, reqCtxCancel)
+	if err != nil {
+		return
+	}
+	body, err = ioutil.ReadAll(resp.Body)
+	return resp.StatusCode, body, resp.Header, err
+}
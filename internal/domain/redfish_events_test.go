@@ -0,0 +1,168 @@
+/*
+ * MIT License
+ *
+ * (C) Copyright [2026] Hewlett Packard Enterprise Development LP
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a
+ * copy of this software and associated documentation files (the "Software"),
+ * to deal in the Software without restriction, including without limitation
+ * the rights to use, copy, modify, merge, publish, distribute, sublicense,
+ * and/or sell copies of the Software, and to permit persons to whom the
+ * Software is furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included
+ * in all copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL
+ * THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+ * OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+ * ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+ * OTHER DEALINGS IN THE SOFTWARE.
+ */
+
+package domain
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/Cray-HPE/hms-firmware-action/internal/model"
+	"github.com/Cray-HPE/hms-firmware-action/internal/storage"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/suite"
+)
+
+type Redfish_Events_TS struct {
+	suite.Suite
+	bmc            *httptest.Server
+	lock           sync.Mutex
+	serviceEnabled bool
+	posted         map[string]string
+	deleted        []string
+	existing       map[string]map[string]string
+}
+
+func (suite *Redfish_Events_TS) SetupTest() {
+	suite.serviceEnabled = true
+	suite.posted = nil
+	suite.deleted = nil
+	suite.existing = nil
+	suite.bmc = httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		suite.lock.Lock()
+		defer suite.lock.Unlock()
+		switch {
+		case req.Method == "GET" && req.URL.Path == redfishEventService:
+			json.NewEncoder(w).Encode(map[string]bool{"ServiceEnabled": suite.serviceEnabled})
+		case req.Method == "GET" && req.URL.Path == redfishEventSubscriptions:
+			members := []model.TaskLink{}
+			for uri := range suite.existing {
+				members = append(members, model.TaskLink{Link: uri})
+			}
+			json.NewEncoder(w).Encode(map[string][]model.TaskLink{"Members": members})
+		case req.Method == "GET" && suite.existing[req.URL.Path] != nil:
+			json.NewEncoder(w).Encode(suite.existing[req.URL.Path])
+		case req.Method == "POST" && req.URL.Path == redfishEventSubscriptions:
+			json.NewDecoder(req.Body).Decode(&suite.posted)
+			w.Header().Set("Location", "https://"+req.Host+redfishEventSubscriptions+"/7")
+			w.WriteHeader(http.StatusCreated)
+		case req.Method == "DELETE":
+			suite.deleted = append(suite.deleted, req.URL.Path)
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	GLOB.EventDestination = "https://fas.local:28801/"
+}
+
+func (suite *Redfish_Events_TS) TearDownTest() {
+	GLOB.EventDestination = ""
+	suite.bmc.Close()
+}
+
+func (suite *Redfish_Events_TS) operation() storage.Operation {
+	operation := storage.HelperGetStockOperation()
+	operation.HsmData.FQDN = strings.TrimPrefix(suite.bmc.URL, "https://")
+	return operation
+}
+
+func (suite *Redfish_Events_TS) Test_SubscribeToEvents() {
+	operation := suite.operation()
+	sub, err := SubscribeToEvents(operation)
+	suite.True(err == nil)
+	suite.NotNil(sub)
+	suite.Equal(redfishEventSubscriptions+"/7", sub.URI)
+	suite.Equal("https://fas.local:28801/events/"+operation.OperationID.String(), suite.posted["Destination"])
+	suite.Equal(operation.OperationID.String(), suite.posted["Context"])
+
+	payload := model.RedfishEventPayload{Events: []model.RedfishEvent{{MessageId: "Update.1.0.UpdateSuccessful"}}}
+	suite.True(DispatchRedfishEvents(operation.OperationID, payload))
+	event := <-sub.Events()
+	suite.True(IsUpdateCompletionEvent(event))
+
+	UnsubscribeFromEvents(sub)
+	suite.Equal([]string{redfishEventSubscriptions + "/7"}, suite.deleted)
+	suite.False(DispatchRedfishEvents(operation.OperationID, payload))
+}
+
+func (suite *Redfish_Events_TS) Test_SubscribeToEvents_RemovesStale() {
+	live := suite.operation()
+	liveSub, err := SubscribeToEvents(live)
+	suite.True(err == nil)
+	stored := storage.HelperGetStockOperation()
+	suite.True(StoreOperation(stored) == nil)
+	defer (*GLOB.DSP).DeleteOperation(stored.OperationID)
+	suite.existing = map[string]map[string]string{
+		redfishEventSubscriptions + "/1": {"Destination": "https://fas.local:28801/events/" + uuid.New().String()},
+		redfishEventSubscriptions + "/2": {"Destination": "https://monitor.local/redfish", "Context": stored.OperationID.String()},
+		redfishEventSubscriptions + "/3": {"Destination": "https://monitor.local/redfish", "Context": uuid.New().String()},
+		redfishEventSubscriptions + "/4": {"Destination": "https://monitor.local/redfish", "Context": "telemetry"},
+		redfishEventSubscriptions + "/7": {"Destination": "https://fas.local:28801/events/" + live.OperationID.String()},
+	}
+	suite.deleted = nil
+
+	operation := suite.operation()
+	sub, err := SubscribeToEvents(operation)
+	suite.True(err == nil)
+	suite.NotNil(sub)
+	suite.ElementsMatch([]string{redfishEventSubscriptions + "/1", redfishEventSubscriptions + "/2"}, suite.deleted)
+	eventSubscriptionsLock.Lock()
+	delete(eventSubscriptions, sub.OperationID)
+	delete(eventSubscriptions, liveSub.OperationID)
+	eventSubscriptionsLock.Unlock()
+}
+
+func (suite *Redfish_Events_TS) Test_SubscribeToEvents_ServiceDisabled() {
+	suite.serviceEnabled = false
+	sub, err := SubscribeToEvents(suite.operation())
+	suite.NotNil(err)
+	suite.Nil(sub)
+	suite.Nil(suite.posted)
+}
+
+func (suite *Redfish_Events_TS) Test_SubscribeToEvents_NoDestination() {
+	GLOB.EventDestination = ""
+	sub, err := SubscribeToEvents(suite.operation())
+	suite.True(err == nil)
+	suite.Nil(sub)
+}
+
+func (suite *Redfish_Events_TS) Test_IsUpdateCompletionEvent() {
+	for _, id := range []string{"TaskEvent.1.0.3.TaskCompletedOK", "TaskEvent.1.0.TaskAborted", "Update.1.0.UpdateFailed", "ResourceEvent.1.0.ResourceChanged"} {
+		suite.True(IsUpdateCompletionEvent(model.RedfishEvent{MessageId: id}), id)
+	}
+	for _, id := range []string{"TaskEvent.1.0.TaskStarted", "Update.1.0.TransferringToComponent", ""} {
+		suite.False(IsUpdateCompletionEvent(model.RedfishEvent{MessageId: id}), id)
+	}
+}
+
+func Test_Domain_Redfish_Events(t *testing.T) {
+	ConfigureSystemForUnitTesting()
+	suite.Run(t, new(Redfish_Events_TS))
+}
@@ -68,6 +68,10 @@ func DeleteExpiredSnapshots() {
 	for _, snapshot := range snapshots {
 		if snapshot.ExpirationTime.Valid && snapshot.ExpirationTime.Time.Before(time.Now()) {
 			//expiredSnapshots.Snapshots = append(expiredSnapshots.Snapshots, s)
+			if err := ArchiveSnapshot(snapshot.Name); err != nil {
+				logrus.WithFields(logrus.Fields{"ERROR": err, "snapshot": snapshot.Name}).Error("Could not archive snapshot, not deleting")
+				continue
+			}
 			pb := DeleteSnapshot(snapshot.Name)
 			if pb.IsError {
 				logrus.Error(pb.Error.Detail)
@@ -184,6 +188,7 @@ func StartRestoreSnapshot(name string, overrideDryrun bool, timeLimit int) (pb m
 	actionParams.Command = storage.Command{
 		OverrideDryrun:             overrideDryrun,
 		RestoreNotPossibleOverride: true,
+		AllowDowngrade:             true,
 		TimeLimit_Seconds:          timeLimit,
 		Version:                    "explicit",
 		Description:                "restore snapshot " + snapshot.Name,
@@ -320,6 +325,9 @@ func RestoreSnapshot(action storage.Action, snapshot storage.Snapshot) {
 	//6b -> get all images
 	imageMap := GetImageMap()
 
+	//devices an exclusion policy covers are not restored either
+	action.Errors = append(action.Errors, ApplyExclusionPolicies(&candidateOperations, &deviceMap)...)
+
 	for operationID, _ := range candidateOperations {
 		operation := candidateOperations[operationID]
 		if operation.State.Can("configure") {
@@ -341,11 +349,21 @@ func RestoreSnapshot(action storage.Action, snapshot storage.Snapshot) {
 			//SetNoOperationOperations!
 			SetNoOpOp(&operation, false)
 
+			//going back is the point of a restore, but not below what the installed image allows
+			if operation.State.Can("configure") {
+				if err = CheckDowngrade(operation, &imageMap, action.Command.AllowDowngrade); err != nil {
+					operation.State.Event(context.Background(), "nosol")
+					operation.EndTime.Scan(time.Now())
+					operation.StateHelper = err.Error()
+					operation.FailureCode = storage.FailureDowngradeNotAllowed
+				}
+			}
+
 			//Not a NoSOl nor a NoOP
 			if operation.State.Can("configure") {
-				//TODO here is a good place to add in DependencyManagment -> see develop @ tag: with-dependency to see what we had.
 				operation.State.Event(context.Background(), "configure")
 			}
+			candidateOperations[operationID] = operation
 		}
 	}
 
@@ -359,6 +377,12 @@ func RestoreSnapshot(action storage.Action, snapshot storage.Snapshot) {
 		}
 	}
 
+	//order operations on the same xname by the prerequisites of their images
+	SetPrerequisiteBlockers(&candidateOperations, &imageMap, &deviceMap)
+
+	//hold the plan against the compatibility rules
+	action.Errors = append(action.Errors, ApplyCompatibilityRules(&candidateOperations, &imageMap, &deviceMap)...)
+
 	//Figure out if there are any sibling blockers (xname == xname)
 	//store the operations and load the OperationIDs into the action
 	xnameOps := make(map[string][]uuid.UUID)
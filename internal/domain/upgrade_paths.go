@@ -0,0 +1,162 @@
+/*
+ * MIT License
+ *
+ * (C) Copyright [2026] Hewlett Packard Enterprise Development LP
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a
+ * copy of this software and associated documentation files (the "Software"),
+ * to deal in the Software without restriction, including without limitation
+ * the rights to use, copy, modify, merge, publish, distribute, sublicense,
+ * and/or sell copies of the Software, and to permit persons to whom the
+ * Software is furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included
+ * in all copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL
+ * THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+ * OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+ * ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+ * OTHER DEALINGS IN THE SOFTWARE.
+ */
+
+package domain
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"strconv"
+
+	"github.com/Cray-HPE/hms-firmware-action/internal/model"
+	"github.com/Cray-HPE/hms-firmware-action/internal/storage"
+	"github.com/Masterminds/semver/v3"
+	"github.com/google/uuid"
+)
+
+// PlanUpgradePath -> the images to apply, in order, to take the operation from what is on the device to its ToImage.
+// Usually that is just the ToImage; when the ToImage cannot be applied on top of the current version (its
+// RequiresFromVersion) it looks for the shortest chain of intermediate images, each one newer than the last, that
+// carry one of the action's tags.
+func PlanUpgradePath(operation storage.Operation, imageMap *map[uuid.UUID]storage.Image, tags []string) (path []storage.Image, err error) {
+	toImage := (*imageMap)[operation.ToImageID]
+	current := currentSemanticVersion(operation.Manufacturer, toImage.Target, operation.FromFirmwareVersion, imageMap)
+	if fromImage, ok := (*imageMap)[operation.FromImageID]; ok {
+		current = fromImage.SemanticFirmwareVersion
+	}
+	if toImage.AcceptsFromVersion(current) {
+		return []storage.Image{toImage}, nil
+	}
+	if current == nil || toImage.SemanticFirmwareVersion == nil {
+		return nil, errors.New("no upgrade path: " + toImage.FirmwareVersion + " requires " + toImage.RequiresFromVersion +
+			" and the current version is unknown")
+	}
+
+	//one image per version, newest first so the search prefers the biggest steps
+	var hops []storage.Image
+	seen := make(map[string]bool)
+	for _, image := range *imageMap {
+		if !hasAnyTag(image, tags) || !ImageAppliesTo(image, operation) ||
+			image.SemanticFirmwareVersion == nil || seen[image.SemanticFirmwareVersion.String()] ||
+			!image.SemanticFirmwareVersion.GreaterThan(current) || !image.SemanticFirmwareVersion.LessThan(toImage.SemanticFirmwareVersion) {
+			continue
+		}
+		seen[image.SemanticFirmwareVersion.String()] = true
+		hops = append(hops, image)
+	}
+	sort.Slice(hops, func(i, j int) bool {
+		return hops[i].SemanticFirmwareVersion.GreaterThan(hops[j].SemanticFirmwareVersion)
+	})
+
+	//breadth first, so the first chain that reaches the ToImage has the fewest steps
+	type step struct {
+		version *semver.Version
+		path    []storage.Image
+	}
+	queue := []step{{version: current}}
+	visited := make(map[string]bool)
+	for len(queue) > 0 {
+		next := queue[0]
+		queue = queue[1:]
+		if toImage.AcceptsFromVersion(next.version) {
+			return append(next.path, toImage), nil
+		}
+		for _, hop := range hops {
+			if visited[hop.SemanticFirmwareVersion.String()] || !hop.SemanticFirmwareVersion.GreaterThan(next.version) ||
+				!hop.AcceptsFromVersion(next.version) {
+				continue
+			}
+			visited[hop.SemanticFirmwareVersion.String()] = true
+			path := append(append([]storage.Image{}, next.path...), hop)
+			queue = append(queue, step{version: hop.SemanticFirmwareVersion, path: path})
+		}
+	}
+	return nil, errors.New("no upgrade path from " + current.String() + " to " + toImage.SemanticFirmwareVersion.String() +
+		"; it requires " + toImage.RequiresFromVersion)
+}
+
+// BuildUpgradePathOperations -> one automatically generated operation for every intermediate image of the path, each
+// blocked on the one before it; the operation itself becomes the last step.  Returns the new operations.
+func BuildUpgradePathOperations(operation *storage.Operation, path []storage.Image) (steps []storage.Operation) {
+	if len(path) < 2 {
+		return nil
+	}
+	fromImageID, fromFirmwareVersion := operation.FromImageID, operation.FromFirmwareVersion
+	var previous uuid.UUID
+	for i, image := range path[:len(path)-1] {
+		step := storage.NewOperation()
+		step.ActionID = operation.ActionID
+		step.AutomaticallyGenerated = true
+		step.StartTime = operation.StartTime
+		step.ExpirationTime = operation.ExpirationTime
+		step.Xname = operation.Xname
+		step.DeviceType = operation.DeviceType
+		step.Target = operation.Target
+		step.TargetName = operation.TargetName
+		step.Manufacturer = operation.Manufacturer
+		step.Model = operation.Model
+		step.SoftwareId = operation.SoftwareId
+		step.HsmData = operation.HsmData
+		step.FromImageID = fromImageID
+		step.FromFirmwareVersion = fromFirmwareVersion
+		step.ToImageID = image.ImageID
+		step.State.Event(context.Background(), "configure")
+		step.StateHelper = "upgrade path step " + strconv.Itoa(i+1) + " of " + strconv.Itoa(len(path))
+		if previous != uuid.Nil {
+			step.BlockedBy = append(step.BlockedBy, previous)
+			step.DependsOn = append(step.DependsOn, previous)
+			step.State.Event(context.Background(), "block")
+		}
+		steps = append(steps, *step)
+		previous = step.OperationID
+		fromImageID, fromFirmwareVersion = image.ImageID, image.FirmwareVersion
+	}
+	operation.FromImageID = fromImageID
+	operation.FromFirmwareVersion = fromFirmwareVersion
+	operation.BlockedBy = append(operation.BlockedBy, previous)
+	operation.DependsOn = append(operation.DependsOn, previous)
+	operation.State.Event(context.Background(), "block")
+	operation.StateHelper = "upgrade path step " + strconv.Itoa(len(path)) + " of " + strconv.Itoa(len(path))
+
+	var upgradePath []uuid.UUID
+	for _, step := range steps {
+		upgradePath = append(upgradePath, step.OperationID)
+	}
+	upgradePath = append(upgradePath, operation.OperationID)
+	operation.UpgradePath = upgradePath
+	for i := range steps {
+		steps[i].UpgradePath = upgradePath
+	}
+	return steps
+}
+
+func hasAnyTag(image storage.Image, tags []string) bool {
+	for _, tag := range tags {
+		if _, found := model.Find(image.Tags, tag); found {
+			return true
+		}
+	}
+	return false
+}
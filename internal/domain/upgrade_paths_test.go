@@ -0,0 +1,146 @@
+/*
+ * MIT License
+ *
+ * (C) Copyright [2026] Hewlett Packard Enterprise Development LP
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a
+ * copy of this software and associated documentation files (the "Software"),
+ * to deal in the Software without restriction, including without limitation
+ * the rights to use, copy, modify, merge, publish, distribute, sublicense,
+ * and/or sell copies of the Software, and to permit persons to whom the
+ * Software is furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included
+ * in all copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL
+ * THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+ * OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+ * ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+ * OTHER DEALINGS IN THE SOFTWARE.
+ */
+
+package domain
+
+import (
+	"testing"
+
+	"github.com/Cray-HPE/hms-firmware-action/internal/storage"
+	"github.com/Masterminds/semver/v3"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/suite"
+)
+
+type Upgrade_Paths_TS struct {
+	suite.Suite
+}
+
+func helperPathImage(version string, requiresFromVersion string) storage.Image {
+	return storage.Image{
+		ImageID:                 uuid.New(),
+		DeviceType:              "NodeBMC",
+		Manufacturer:            "cray",
+		Models:                  []string{"WNC"},
+		Target:                  "BMC",
+		Tags:                    []string{"default"},
+		FirmwareVersion:         "nc." + version,
+		SemanticFirmwareVersion: semver.MustParse(version),
+		RequiresFromVersion:     requiresFromVersion,
+	}
+}
+
+func helperPathOperation(from storage.Image, to storage.Image) storage.Operation {
+	operation := storage.HelperGetStockOperation()
+	operation.Xname = "x0c0s1b0"
+	operation.DeviceType = "NodeBMC"
+	operation.Manufacturer = "cray"
+	operation.Model = "WNC"
+	operation.Target = "BMC"
+	operation.FromImageID = from.ImageID
+	operation.FromFirmwareVersion = from.FirmwareVersion
+	operation.ToImageID = to.ImageID
+	return operation
+}
+
+func (suite *Upgrade_Paths_TS) Test_PlanUpgradePath_Direct() {
+	from := helperPathImage("1.0.0", "")
+	to := helperPathImage("3.1.0", ">=1.0.0")
+	imageMap := map[uuid.UUID]storage.Image{from.ImageID: from, to.ImageID: to}
+
+	path, err := PlanUpgradePath(helperPathOperation(from, to), &imageMap, []string{"default"})
+	suite.True(err == nil)
+	suite.Equal(1, len(path))
+	suite.Equal(to.ImageID, path[0].ImageID)
+}
+
+func (suite *Upgrade_Paths_TS) Test_PlanUpgradePath_MultiHop() {
+	from := helperPathImage("1.0.0", "")
+	two := helperPathImage("2.0.0", ">=1.0.0 <2.0.0")
+	twoFive := helperPathImage("2.5.0", ">=2.0.0")
+	three := helperPathImage("3.0.0", ">=2.0.0")
+	to := helperPathImage("3.1.0", ">=3.0.0")
+	//newer than the target, so never a step
+	four := helperPathImage("4.0.0", "")
+	imageMap := map[uuid.UUID]storage.Image{from.ImageID: from, two.ImageID: two, twoFive.ImageID: twoFive,
+		three.ImageID: three, to.ImageID: to, four.ImageID: four}
+
+	path, err := PlanUpgradePath(helperPathOperation(from, to), &imageMap, []string{"default"})
+	suite.True(err == nil)
+	suite.Equal([]uuid.UUID{two.ImageID, three.ImageID, to.ImageID}, []uuid.UUID{path[0].ImageID, path[1].ImageID, path[2].ImageID})
+	suite.Equal(3, len(path))
+}
+
+func (suite *Upgrade_Paths_TS) Test_PlanUpgradePath_NoPath() {
+	from := helperPathImage("1.0.0", "")
+	two := helperPathImage("2.0.0", ">=1.5.0")
+	to := helperPathImage("3.1.0", ">=2.0.0")
+	imageMap := map[uuid.UUID]storage.Image{from.ImageID: from, two.ImageID: two, to.ImageID: to}
+
+	_, err := PlanUpgradePath(helperPathOperation(from, to), &imageMap, []string{"default"})
+	suite.True(err != nil)
+	suite.Contains(err.Error(), "no upgrade path from 1.0.0 to 3.1.0")
+
+	//an intermediate image with another tag is not a step
+	two.RequiresFromVersion = ""
+	two.Tags = []string{"recovery"}
+	imageMap[two.ImageID] = two
+	_, err = PlanUpgradePath(helperPathOperation(from, to), &imageMap, []string{"default"})
+	suite.True(err != nil)
+}
+
+func (suite *Upgrade_Paths_TS) Test_BuildUpgradePathOperations() {
+	from := helperPathImage("1.0.0", "")
+	two := helperPathImage("2.0.0", "")
+	three := helperPathImage("3.0.0", ">=2.0.0")
+	to := helperPathImage("3.1.0", ">=3.0.0")
+
+	operation := helperPathOperation(from, to)
+	operation.State.SetState("configured")
+	suite.Nil(BuildUpgradePathOperations(&operation, []storage.Image{to}))
+
+	steps := BuildUpgradePathOperations(&operation, []storage.Image{two, three, to})
+	suite.Equal(2, len(steps))
+	suite.True(steps[0].State.Is("configured"))
+	suite.True(steps[0].AutomaticallyGenerated)
+	suite.Equal(from.ImageID, steps[0].FromImageID)
+	suite.Equal(two.ImageID, steps[0].ToImageID)
+	suite.True(steps[1].State.Is("blocked"))
+	suite.Equal([]uuid.UUID{steps[0].OperationID}, steps[1].DependsOn)
+	suite.Equal(two.ImageID, steps[1].FromImageID)
+	suite.Equal(three.ImageID, steps[1].ToImageID)
+
+	suite.True(operation.State.Is("blocked"))
+	suite.Equal([]uuid.UUID{steps[1].OperationID}, operation.DependsOn)
+	suite.Equal(three.ImageID, operation.FromImageID)
+	suite.Equal(to.ImageID, operation.ToImageID)
+	upgradePath := []uuid.UUID{steps[0].OperationID, steps[1].OperationID, operation.OperationID}
+	suite.Equal(upgradePath, operation.UpgradePath)
+	suite.Equal(upgradePath, steps[0].UpgradePath)
+	suite.Equal("upgrade path step 3 of 3", operation.StateHelper)
+}
+
+func Test_Domain_Upgrade_Paths(t *testing.T) {
+	suite.Run(t, new(Upgrade_Paths_TS))
+}
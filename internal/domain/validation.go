@@ -26,10 +26,13 @@ package domain
 
 import (
 	"errors"
+	"path"
+	"strings"
 
 	"github.com/Cray-HPE/hms-firmware-action/internal/model"
 	"github.com/Cray-HPE/hms-firmware-action/internal/storage"
 	"github.com/Cray-HPE/hms-xname/xnametypes"
+	"github.com/Masterminds/semver/v3"
 	"github.com/google/uuid"
 	"github.com/sirupsen/logrus"
 )
@@ -58,6 +61,40 @@ func ValidateActionParameters(l *storage.ActionParameters) (err error) {
 		return err
 	}
 
+	if err = l.RetryPolicy.Validate(); err != nil {
+		logrus.Error(err)
+		return err
+	}
+
+	if err = ValidateLaunchPolicies(l.LaunchPolicies); err != nil {
+		logrus.Error(err)
+		return err
+	}
+
+	return nil
+}
+
+// ValidateLaunchPolicies -> normalizes the group and unit HMS types; each policy needs exactly one limit
+func ValidateLaunchPolicies(policies []storage.LaunchPolicy) (err error) {
+	for i := range policies {
+		p := &policies[i]
+		for _, hmsType := range []*string{&p.Group, &p.Unit} {
+			if *hmsType == "" {
+				continue
+			}
+			normalized := xnametypes.VerifyNormalizeType(*hmsType)
+			if normalized == "" {
+				return errors.New("launchPolicies: " + *hmsType + " is not a valid HMS type")
+			}
+			*hmsType = normalized
+		}
+		if p.MaxUnits < 0 || p.MaxPercent < 0 || p.MaxPercent > 100 {
+			return errors.New("launchPolicies: maxUnits cannot be negative and maxPercent must be between 1 and 100")
+		}
+		if (p.MaxUnits > 0) == (p.MaxPercent > 0) {
+			return errors.New("launchPolicies: set exactly one of maxUnits and maxPercent")
+		}
+	}
 	return nil
 }
 
@@ -106,12 +143,22 @@ func ValidateCommandParameter(c *storage.Command) (err error) {
 		c.Version = "latest"
 	}
 
+	c.Tag = strings.Join(c.TagPreference(), ",")
 	if c.Tag == "" {
 		c.Tag = "default"
 	}
 
 	if c.Version != "earliest" && c.Version != "latest" && c.Version != "explicit" {
-		err = errors.New("version must be 'earliest' or 'latest'; or you must supply an ImageID")
+		if _, cerr := semver.NewConstraint(c.Version); cerr != nil {
+			err = errors.New("version must be 'earliest', 'latest' or a semantic version constraint; or you must supply an ImageID")
+			logrus.Error(err)
+		}
+	}
+
+	switch c.ApplyTime {
+	case "", storage.ApplyTimeImmediate, storage.ApplyTimeOnReset, storage.ApplyTimeAtMaintenanceWindowStart:
+	default:
+		err = errors.New("applyTime must be '" + storage.ApplyTimeImmediate + "', '" + storage.ApplyTimeOnReset + "' or '" + storage.ApplyTimeAtMaintenanceWindowStart + "'")
 		logrus.Error(err)
 	}
 	// at this point there is nothing else to really validate... strings are ""; ints a 0; and bools are (false?)
@@ -133,6 +180,7 @@ func ValidateImageFilter(i *storage.ImageFilter) (err error) {
 }
 
 // Require:
+//
 //	ImageID - Non-nil
 //	CreateTime -
 //	DeviceType - Required
@@ -149,6 +197,10 @@ func ValidateImageFilter(i *storage.ImageFilter) (err error) {
 //	AllowableDeviceStates -
 //	DependsOn -
 //	tftpURL
+//	RetryPolicy -
+//	Prerequisites -
+//	RequiresFromVersion -
+//	NoDowngradeBelow -
 func ValidateImageParameters(i *storage.Image) (err error) {
 	err = nil
 	if i.ImageID == uuid.Nil {
@@ -174,14 +226,91 @@ func ValidateImageParameters(i *storage.Image) (err error) {
 	if len(i.S3URL) == 0 {
 		return errors.New("S3URL is required")
 	}
-	if len(i.SemanticFirmwareVersion.String()) == 0 {
+	if i.SemanticFirmwareVersion == nil || len(i.SemanticFirmwareVersion.String()) == 0 {
 		return errors.New("semanticFirmwareVersion is required")
 	}
 	if len(i.Tags) == 0 {
 		return errors.New("tags cannot be empty")
 	}
+	if err = i.RetryPolicy.Validate(); err != nil {
+		return err
+	}
+	if i.RequiresFromVersion != "" {
+		if _, err = semver.NewConstraint(i.RequiresFromVersion); err != nil {
+			return errors.New("requiresFromVersion is not a semantic version constraint: " + err.Error())
+		}
+	}
+	if i.NoDowngradeBelow != "" {
+		if _, err = semver.NewVersion(i.NoDowngradeBelow); err != nil {
+			return errors.New("noDowngradeBelow is not a semantic version: " + err.Error())
+		}
+	}
+	for _, prerequisite := range i.Prerequisites {
+		if prerequisite.Target == i.Target {
+			return errors.New("an image cannot be its own prerequisite")
+		}
+		if err = prerequisite.Validate(); err != nil {
+			return err
+		}
+	}
 
 	// TODO: Do we need to check for polling speed?
 
 	return
 }
+
+// ValidateCompatibilityRule -> checks a rule and fills in the default enforcement and scope
+func ValidateCompatibilityRule(r *storage.CompatibilityRule) (err error) {
+	if r.RuleID == uuid.Nil {
+		return errors.New("ruleID cannot be Nil")
+	}
+	if len(r.Target) == 0 {
+		return errors.New("target is required")
+	}
+	if r.Enforcement == "" {
+		r.Enforcement = storage.CompatibilityEnforcementNoSolution
+	} else if r.Enforcement != storage.CompatibilityEnforcementNoSolution && r.Enforcement != storage.CompatibilityEnforcementReject {
+		return errors.New("enforcement must be " + storage.CompatibilityEnforcementNoSolution + " or " + storage.CompatibilityEnforcementReject)
+	}
+	switch r.Type {
+	case storage.CompatibilityRuleRequires:
+		if len(r.RequiredTarget) == 0 || len(r.RequiredVersions) == 0 {
+			return errors.New("requiredTarget and requiredVersions are required")
+		}
+		for _, constraint := range []string{r.Versions, r.RequiredVersions} {
+			if constraint == "" {
+				continue
+			}
+			if _, err = semver.NewConstraint(constraint); err != nil {
+				return errors.New(constraint + " is not a semantic version constraint: " + err.Error())
+			}
+		}
+	case storage.CompatibilityRuleUniform:
+		if r.Scope == "" {
+			r.Scope = xnametypes.Chassis.String()
+		}
+		scope := xnametypes.VerifyNormalizeType(r.Scope)
+		if scope == "" {
+			return errors.New("scope " + r.Scope + " is not a valid HMS type")
+		}
+		r.Scope = scope
+	default:
+		return errors.New("type must be " + storage.CompatibilityRuleRequires + " or " + storage.CompatibilityRuleUniform)
+	}
+	return
+}
+
+func ValidateExclusionPolicy(p *storage.ExclusionPolicy) (err error) {
+	if p.PolicyID == uuid.Nil {
+		return errors.New("policyID cannot be Nil")
+	}
+	if !p.HasCriteria() {
+		return errors.New("at least one of roles, subRoles, groups, partitions, xnames, manufacturer, models or targets is required")
+	}
+	for _, pattern := range p.Xnames {
+		if _, err = path.Match(pattern, ""); err != nil {
+			return errors.New(pattern + " is not a valid xname pattern: " + err.Error())
+		}
+	}
+	return
+}
@@ -125,7 +125,27 @@ func (suite *Validation_TS) Test_ValidateImage() {
 }
 
 func (suite *Validation_TS) Test_ValidateCommandParameter() {
-	// TODO:
+	c := storage.Command{}
+	err := ValidateCommandParameter(&c)
+	suite.True(err == nil)
+	suite.Equal("latest", c.Version)
+	suite.Equal("default", c.Tag)
+
+	c = storage.Command{Version: ">=1.8 <2.0", Tag: " site-approved , default,"}
+	err = ValidateCommandParameter(&c)
+	suite.True(err == nil)
+	suite.Equal("site-approved,default", c.Tag)
+	suite.Equal([]string{"site-approved", "default"}, c.TagPreference())
+
+	c.Version = "newest"
+	err = ValidateCommandParameter(&c)
+	suite.True(err != nil)
+
+	c = storage.Command{ApplyTime: storage.ApplyTimeOnReset}
+	suite.True(ValidateCommandParameter(&c) == nil)
+	suite.True(c.Stages())
+	c.ApplyTime = "Tomorrow"
+	suite.True(ValidateCommandParameter(&c) != nil)
 }
 func (suite *Validation_TS) Test_ValidateImageFilter() {
 	// TODO:
@@ -194,6 +214,91 @@ func (suite *Validation_TS) Test_ValidateImage_Missings3URL() {
 	suite.True(err != nil)
 }
 
+func (suite *Validation_TS) Test_ValidateImage_BadRetryPolicy() {
+	Image := Helper_ValidImage()
+	Image.RetryPolicy = &storage.RetryPolicy{MaxAttempts: 0}
+	err := ValidateImageParameters(&Image)
+	suite.True(err != nil)
+	Image.RetryPolicy = &storage.RetryPolicy{MaxAttempts: 3, InitialBackoffSeconds: 10}
+	err = ValidateImageParameters(&Image)
+	suite.True(err == nil)
+}
+
+func (suite *Validation_TS) Test_ValidateImage_BadPrerequisites() {
+	Image := Helper_ValidImage()
+	Image.Prerequisites = []storage.ImagePrerequisite{{Target: "BMC", MinimumVersion: "not-a-version"}}
+	err := ValidateImageParameters(&Image)
+	suite.True(err != nil)
+	Image.Prerequisites = []storage.ImagePrerequisite{{Target: Image.Target, MinimumVersion: "1.0.0"}}
+	err = ValidateImageParameters(&Image)
+	suite.True(err != nil)
+	Image.Prerequisites = []storage.ImagePrerequisite{{Target: Image.Target + "X", MinimumVersion: "1.0.0"}}
+	err = ValidateImageParameters(&Image)
+	suite.True(err == nil)
+}
+
+func (suite *Validation_TS) Test_ValidateImage_BadRequiresFromVersion() {
+	Image := Helper_ValidImage()
+	Image.RequiresFromVersion = "from two onwards"
+	err := ValidateImageParameters(&Image)
+	suite.True(err != nil)
+	Image.RequiresFromVersion = ">=2.0.0 <3.0.0"
+	err = ValidateImageParameters(&Image)
+	suite.True(err == nil)
+}
+
+func (suite *Validation_TS) Test_ValidateImage_BadNoDowngradeBelow() {
+	Image := Helper_ValidImage()
+	Image.NoDowngradeBelow = "two"
+	err := ValidateImageParameters(&Image)
+	suite.True(err != nil)
+	Image.NoDowngradeBelow = "1.5.0"
+	err = ValidateImageParameters(&Image)
+	suite.True(err == nil)
+}
+
+func (suite *Validation_TS) Test_ValidateCompatibilityRule() {
+	rule := storage.CompatibilityRule{RuleID: uuid.New(), Type: storage.CompatibilityRuleRequires, Target: "BIOS"}
+	err := ValidateCompatibilityRule(&rule)
+	suite.True(err != nil)
+	rule.RequiredTarget = "BMC"
+	rule.RequiredVersions = "1.5 or so"
+	err = ValidateCompatibilityRule(&rule)
+	suite.True(err != nil)
+	rule.RequiredVersions = ">=1.5.0"
+	err = ValidateCompatibilityRule(&rule)
+	suite.True(err == nil)
+	suite.Equal(storage.CompatibilityEnforcementNoSolution, rule.Enforcement)
+	rule.Enforcement = "sometimes"
+	err = ValidateCompatibilityRule(&rule)
+	suite.True(err != nil)
+
+	rule = storage.CompatibilityRule{RuleID: uuid.New(), Type: storage.CompatibilityRuleUniform, Target: "BMC", Scope: "cabinet"}
+	err = ValidateCompatibilityRule(&rule)
+	suite.True(err == nil)
+	suite.Equal("Cabinet", rule.Scope)
+	rule.Scope = "building"
+	err = ValidateCompatibilityRule(&rule)
+	suite.True(err != nil)
+}
+
+func (suite *Validation_TS) Test_ValidateLaunchPolicies() {
+	policies := []storage.LaunchPolicy{{Group: "chassis", Unit: "computemodule", MaxUnits: 1}, {Group: "cabinet", MaxPercent: 25}}
+	err := ValidateLaunchPolicies(policies)
+	suite.True(err == nil)
+	suite.Equal("Chassis", policies[0].Group)
+	suite.Equal("ComputeModule", policies[0].Unit)
+
+	err = ValidateLaunchPolicies([]storage.LaunchPolicy{{Group: "building", MaxUnits: 1}})
+	suite.True(err != nil)
+	err = ValidateLaunchPolicies([]storage.LaunchPolicy{{Group: "Chassis"}})
+	suite.True(err != nil)
+	err = ValidateLaunchPolicies([]storage.LaunchPolicy{{Group: "Chassis", MaxUnits: 1, MaxPercent: 50}})
+	suite.True(err != nil)
+	err = ValidateLaunchPolicies([]storage.LaunchPolicy{{Group: "Chassis", MaxPercent: 150}})
+	suite.True(err != nil)
+}
+
 func Test_Domain_Validation(t *testing.T) {
 	//This setups the production routs and handler
 	suite.Run(t, new(Validation_TS))
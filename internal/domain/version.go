@@ -40,6 +40,7 @@ import (
 	"github.com/Cray-HPE/hms-firmware-action/internal/hsm"
 	"github.com/Cray-HPE/hms-firmware-action/internal/model"
 	"github.com/Cray-HPE/hms-firmware-action/internal/storage"
+	trs_http_api "github.com/Cray-HPE/hms-trs-app-api/v3/pkg/trs_http_api"
 	rf "github.com/Cray-HPE/hms-smd/v2/pkg/redfish"
 	"github.com/google/uuid"
 	"github.com/sirupsen/logrus"
@@ -149,7 +150,7 @@ func FillInImageIDForDevices(deviceMap *map[string]storage.Device, hsmData *map[
 						strings.EqualFold(image.DeviceType, devData.Type) &&
 						strings.EqualFold(image.Manufacturer, devData.Manufacturer) &&
 						image.Target == target.Name &&
-						image.FirmwareVersion == target.FirmwareVersion {
+						FirmwareVersionMatchesImage(devData.Manufacturer, target.Name, target.FirmwareVersion, image) {
 						device.Targets[targetnum].ImageID = image.ImageID
 					}
 				}
@@ -293,75 +294,122 @@ func RetrieveFirmwareVersionFromTargets(hd *map[hsm.XnameTarget]hsm.HsmData) (de
 
 	// Only execute tasklist if we have items, otherwise we get errors back
 	if len(taskList) > 0 {
-		(*GLOB.RFClientLock).RLock()	// TODO: Do we really need locks?
-		defer (*GLOB.RFClientLock).RUnlock()
-		rchan, err := (*GLOB.RFTloc).Launch(&taskList)
-		if err != nil {
-			logrus.Error(err)
-		}
-
-		for _, _ = range taskList {
-			tdone := <-rchan
-			var theErr error
-			var body []byte
-			var updateVer model.DeviceFirmwareVersion
-			xnameTarget := taskMap[tdone.GetID()]
-
-			for i := 0; i < 1; i++ { //artificial scope -> DO NOT DELETE THIS; IM NOT KIDDING!
-				// I am doing this because I want to BREAK out and handle storing the 'error' into a Target 1 time instead of Copying the 20 lines of code 5 times.
-				// the alternative design was a GOTO; with a continue in the happy case to NOT rewrite the success with an error; this is simpler and easier to read.
-				// FOR REAL though, if you delete this, may you be haunted by cobol programmers & may your next job involve writing software on windows 2000
-
-				if *tdone.Err != nil {
-					theErr = *tdone.Err
-					logrus.Error(theErr)
-					break
-				}
-				if tdone.Request.Response.StatusCode < 200 && tdone.Request.Response.StatusCode >= 300 {
-					theErr = errors.New("bad status code: " + strconv.Itoa(tdone.Request.Response.StatusCode))
-					logrus.Error(theErr)
-					break
-				}
-				if tdone.Request.Response.Body == nil {
-					theErr = errors.New("empty body")
-					logrus.Error(theErr)
-					break
+		//the tasks go out in waves of what the limiter lets through, so no BMC or chassis gets all of its targets at once.
+		//Like every other request, a wave takes its slots before the client lock, and holds the lock for that wave only.
+		pending := taskList
+		for len(pending) > 0 {
+			var wave []trs_http_api.HttpTask
+			var release func()
+			var waitErr error
+			wave, pending, release, waitErr = nextLimitedWave(pending, taskMap, *hd)
+			if waitErr != nil {
+				logrus.Error(waitErr)
+				for _, task := range pending {
+					updateDeviceMap(deviceMap, model.DeviceFirmwareVersion{}, taskMap[task.GetID()], waitErr)
 				}
-				body, err = ioutil.ReadAll(tdone.Request.Response.Body)
-				if err != nil {
-					theErr = err
-					logrus.Error(theErr)
-					break
-				}
-				err = json.Unmarshal(body, &updateVer)
-				if err != nil {
-					theErr = err
-					logrus.Error(theErr)
-					break
-				}
-				// FINALLY!!!! ok; it should be good data!
-				//Its possible that OLD cray bmc code may exist that corrupts that makes this struct empty...
-				// its because a wrapping set of {} may be missing...
-				// im taking the logic out that checks for that, b/c its too confusing!  we think this is no longer an issue;
-				//so if this fails we know we have to put it back!
-				if updateVer.Version == "" {
-					if updateVer.BiosVersion != "" {
-						updateVer.Version = updateVer.BiosVersion
-					} else if updateVer.FirmwareVersion != "" {
-						updateVer.Version = updateVer.FirmwareVersion
+				break
+			}
+			(*GLOB.RFClientLock).RLock()	// TODO: Do we really need locks?
+			rchan, err := (*GLOB.RFTloc).Launch(&wave)
+			if err != nil {
+				logrus.Error(err)
+			}
+
+			for _, _ = range wave {
+				tdone := <-rchan
+				var theErr error
+				var body []byte
+				var updateVer model.DeviceFirmwareVersion
+				xnameTarget := taskMap[tdone.GetID()]
+
+				for i := 0; i < 1; i++ { //artificial scope -> DO NOT DELETE THIS; IM NOT KIDDING!
+					// I am doing this because I want to BREAK out and handle storing the 'error' into a Target 1 time instead of Copying the 20 lines of code 5 times.
+					// the alternative design was a GOTO; with a continue in the happy case to NOT rewrite the success with an error; this is simpler and easier to read.
+					// FOR REAL though, if you delete this, may you be haunted by cobol programmers & may your next job involve writing software on windows 2000
+
+					if *tdone.Err != nil {
+						theErr = *tdone.Err
+						logrus.Error(theErr)
+						break
 					}
-				}
-			} // END OF ARTIFICAL SCOPE  -> Still not kidding about deleting this.
-			updateDeviceMap(deviceMap, updateVer, xnameTarget, theErr)
+					if tdone.Request.Response.StatusCode < 200 && tdone.Request.Response.StatusCode >= 300 {
+						theErr = errors.New("bad status code: " + strconv.Itoa(tdone.Request.Response.StatusCode))
+						logrus.Error(theErr)
+						break
+					}
+					if tdone.Request.Response.Body == nil {
+						theErr = errors.New("empty body")
+						logrus.Error(theErr)
+						break
+					}
+					body, err = ioutil.ReadAll(tdone.Request.Response.Body)
+					if err != nil {
+						theErr = err
+						logrus.Error(theErr)
+						break
+					}
+					err = json.Unmarshal(body, &updateVer)
+					if err != nil {
+						theErr = err
+						logrus.Error(theErr)
+						break
+					}
+					// FINALLY!!!! ok; it should be good data!
+					//Its possible that OLD cray bmc code may exist that corrupts that makes this struct empty...
+					// its because a wrapping set of {} may be missing...
+					// im taking the logic out that checks for that, b/c its too confusing!  we think this is no longer an issue;
+					//so if this fails we know we have to put it back!
+					if updateVer.Version == "" {
+						if updateVer.BiosVersion != "" {
+							updateVer.Version = updateVer.BiosVersion
+						} else if updateVer.FirmwareVersion != "" {
+							updateVer.Version = updateVer.FirmwareVersion
+						}
+					}
+				} // END OF ARTIFICAL SCOPE  -> Still not kidding about deleting this.
+				updateDeviceMap(deviceMap, updateVer, xnameTarget, theErr)
 
-			drainAndCloseBodyWithCtxCancel(tdone.Request.Response, nil)
+				drainAndCloseBodyWithCtxCancel(tdone.Request.Response, nil)
+			}
+			(*GLOB.RFTloc).Close(&wave)
+			close(rchan)
+			(*GLOB.RFClientLock).RUnlock()
+			release()
 		}
-		(*GLOB.RFTloc).Close(&taskList)
-		close(rchan)
 	}
 	return
 }
 
+// nextLimitedWave -> the pending tasks the limiter has room for right now, and the func that frees their slots.  When
+// there is no room for any, it waits for the first one, up to LimiterWaitTimeout; then every task is left in rest.
+func nextLimitedWave(pending []trs_http_api.HttpTask, taskMap map[uuid.UUID]hsm.XnameTarget, hd map[hsm.XnameTarget]hsm.HsmData) (wave []trs_http_api.HttpTask, rest []trs_http_api.HttpTask, release func(), err error) {
+	var releases []func()
+	for _, task := range pending {
+		if slot, ok := GLOB.RFLimiter.TryAcquire(hd[taskMap[task.GetID()]].FQDN); ok {
+			wave = append(wave, task)
+			releases = append(releases, slot)
+		} else {
+			rest = append(rest, task)
+		}
+	}
+	if len(wave) == 0 {
+		ctx, cancel := context.WithTimeout(context.Background(), LimiterWaitTimeout)
+		defer cancel()
+		slot, err := GLOB.RFLimiter.Acquire(ctx, hd[taskMap[rest[0].GetID()]].FQDN)
+		if err != nil {
+			return nil, rest, func() {}, err
+		}
+		releases = append(releases, slot)
+		wave, rest = rest[:1], rest[1:]
+	}
+	release = func() {
+		for _, slot := range releases {
+			slot()
+		}
+	}
+	return wave, rest, release, nil
+}
+
 func updateDeviceMap(deviceMap map[string]storage.Device, updateVer model.DeviceFirmwareVersion, xnameTarget hsm.XnameTarget, theErr error) {
 	target := storage.Target{
 		Name: xnameTarget.Target,
@@ -450,6 +498,13 @@ func RetrieveUpdateInfo(hd *hsm.HsmData, updateinfolink string) (updateInfo mode
 	reqContext, reqCtxCancel := context.WithTimeout(context.Background(), time.Second*40)
 	req = req.WithContext(reqContext)
 
+	release, err := GLOB.RFLimiter.Acquire(reqContext, hd.FQDN)
+	if err != nil {
+		reqCtxCancel()
+		logrus.Error(err)
+		return
+	}
+	defer release()
 	(*GLOB).RFClientLock.RLock()	// TODO: Do we really need locks?
 	resp, err := (*GLOB).RFHttpClient.Do(req)
 	(*GLOB).RFClientLock.RUnlock()
@@ -493,6 +548,13 @@ func RetrieveTaskStatus(hd *hsm.HsmData, tasklink string) (stateStatus model.Tas
 	reqContext, reqCtxCancel := context.WithTimeout(context.Background(), time.Second*40)
 	req = req.WithContext(reqContext)
 
+	release, err := GLOB.RFLimiter.Acquire(reqContext, hd.FQDN)
+	if err != nil {
+		reqCtxCancel()
+		logrus.Error(err)
+		return
+	}
+	defer release()
 	(*GLOB).RFClientLock.RLock()	// TODO: Do we really need locks?
 	resp, err := (*GLOB).RFHttpClient.Do(req)
 	(*GLOB).RFClientLock.RUnlock()
@@ -538,6 +600,13 @@ func RetrieveFirmwareVersion(hd *hsm.HsmData, target string) (firmwareVersion st
 	reqContext, reqCtxCancel := context.WithTimeout(context.Background(), time.Second*40)
 	req = req.WithContext(reqContext)
 
+	release, err := GLOB.RFLimiter.Acquire(reqContext, hd.FQDN)
+	if err != nil {
+		reqCtxCancel()
+		logrus.Error(err)
+		return
+	}
+	defer release()
 	(*GLOB).RFClientLock.RLock()	// TODO: Do we really need locks?
 	resp, err := (*GLOB).RFHttpClient.Do(req)
 	(*GLOB).RFClientLock.RUnlock()
@@ -0,0 +1,194 @@
+/*
+ * MIT License
+ *
+ * (C) Copyright [2026] Hewlett Packard Enterprise Development LP
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a
+ * copy of this software and associated documentation files (the "Software"),
+ * to deal in the Software without restriction, including without limitation
+ * the rights to use, copy, modify, merge, publish, distribute, sublicense,
+ * and/or sell copies of the Software, and to permit persons to whom the
+ * Software is furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included
+ * in all copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL
+ * THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+ * OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+ * ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+ * OTHER DEALINGS IN THE SOFTWARE.
+ */
+
+package domain
+
+import (
+	"errors"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/Cray-HPE/hms-firmware-action/internal/storage"
+	"github.com/Masterminds/semver/v3"
+)
+
+// VersionParser -> turns a firmware version string, as reported by Redfish or written in an image record, into a
+// semantic version
+type VersionParser func(firmwareVersion string) (*semver.Version, error)
+
+var (
+	versionParsersLock sync.RWMutex
+	//keyed by manufacturer, or manufacturer/target; both lower case
+	versionParsers = map[string]VersionParser{
+		"hpe":      ParseHPEVersion,
+		"gigabyte": ParseNumericVersion,
+	}
+
+	hpeVersionRegex     = regexp.MustCompile(`(?i)(?:^|\s)(?:([a-z]\d+)\s+)?v(\d+(?:\.\d+)*)`)
+	dottedVersionRegex  = regexp.MustCompile(`\d+(?:\.\d+)+`)
+	numericVersionRegex = regexp.MustCompile(`\d+(?:\.\d+)*`)
+)
+
+// RegisterVersionParser -> makes parser the one used for firmware of the manufacturer, or only for one of its
+// targets when target is not empty.  A nil parser removes the registration.
+func RegisterVersionParser(manufacturer string, target string, parser VersionParser) {
+	key := versionParserKey(manufacturer, target)
+	versionParsersLock.Lock()
+	defer versionParsersLock.Unlock()
+	if parser == nil {
+		delete(versionParsers, key)
+		return
+	}
+	versionParsers[key] = parser
+}
+
+func versionParserKey(manufacturer string, target string) string {
+	key := strings.ToLower(manufacturer)
+	if target != "" {
+		key += "/" + strings.ToLower(target)
+	}
+	return key
+}
+
+// getVersionParser -> the parser registered for the target of the manufacturer, else the one for the manufacturer;
+// nil if there is none
+func getVersionParser(manufacturer string, target string) VersionParser {
+	versionParsersLock.RLock()
+	defer versionParsersLock.RUnlock()
+	if parser, ok := versionParsers[versionParserKey(manufacturer, target)]; ok && target != "" {
+		return parser
+	}
+	return versionParsers[versionParserKey(manufacturer, "")]
+}
+
+// ParseFirmwareVersion -> the semantic version of a firmware version string, by the rules of the vendor if it has
+// any; nil if it cannot be read
+func ParseFirmwareVersion(manufacturer string, target string, firmwareVersion string) *semver.Version {
+	if firmwareVersion == "" {
+		return nil
+	}
+	parse := VersionParser(semver.NewVersion)
+	if parser := getVersionParser(manufacturer, target); parser != nil {
+		parse = parser
+	}
+	version, err := parse(firmwareVersion)
+	if err != nil {
+		return nil
+	}
+	return version
+}
+
+// FirmwareVersionsEqual -> true if both strings are the same version: literally, or by the rules of the vendor.
+// Strings the vendor rules cannot read are only compared literally.
+func FirmwareVersionsEqual(manufacturer string, target string, a string, b string) bool {
+	if a == b {
+		return true
+	}
+	parser := getVersionParser(manufacturer, target)
+	if parser == nil {
+		return false
+	}
+	versionA, errA := parser(a)
+	versionB, errB := parser(b)
+	if errA != nil || errB != nil {
+		return false //they differ as strings
+	}
+	return sameVersion(versionA, versionB)
+}
+
+// FirmwareVersionMatchesImage -> true if the version a device reports is the version of the image: its firmware
+// version string, or by the rules of the vendor its semantic version
+func FirmwareVersionMatchesImage(manufacturer string, target string, reported string, image storage.Image) bool {
+	if reported == image.FirmwareVersion {
+		return true
+	}
+	if manufacturer == "" {
+		manufacturer = image.Manufacturer
+	}
+	parser := getVersionParser(manufacturer, target)
+	if parser == nil || image.SemanticFirmwareVersion == nil {
+		return false
+	}
+	version, err := parser(reported)
+	if err != nil {
+		return false //it differs from the firmware version string of the image
+	}
+	return sameVersion(version, image.SemanticFirmwareVersion)
+}
+
+// sameVersion -> true if the versions are equal and of the same family, e.g. HPE ROM U30; a version without a family
+// matches any
+func sameVersion(a *semver.Version, b *semver.Version) bool {
+	if a.Metadata() != "" && b.Metadata() != "" && a.Metadata() != b.Metadata() {
+		return false
+	}
+	return a.Equal(b)
+}
+
+// ParseHPEVersion -> reads HPE ROM strings like "U30 v2.42 (10/30/2023)" and iLO strings like "2.78 Jan 10 2023".
+// The ROM family is kept as build metadata ("2.42.0+U30"), as the same version of another family is other firmware.
+func ParseHPEVersion(firmwareVersion string) (*semver.Version, error) {
+	if m := hpeVersionRegex.FindStringSubmatch(firmwareVersion); m != nil {
+		version, err := numericVersion(m[2])
+		if err != nil || m[1] == "" {
+			return version, err
+		}
+		withFamily, err := version.SetMetadata(strings.ToUpper(m[1]))
+		if err != nil {
+			return nil, err
+		}
+		return &withFamily, nil
+	}
+	if m := dottedVersionRegex.FindString(firmwareVersion); m != "" {
+		return numericVersion(m)
+	}
+	return nil, errors.New("no version in " + firmwareVersion)
+}
+
+// ParseNumericVersion -> reads the first run of dot separated numbers, leading zeros and all, as in Gigabyte's
+// "12.84.09"; more than three of them is an error
+func ParseNumericVersion(firmwareVersion string) (*semver.Version, error) {
+	if m := numericVersionRegex.FindString(firmwareVersion); m != "" {
+		return numericVersion(m)
+	}
+	return nil, errors.New("no version in " + firmwareVersion)
+}
+
+func numericVersion(dotted string) (*semver.Version, error) {
+	var parts [3]uint64
+	split := strings.Split(dotted, ".")
+	if len(split) > len(parts) {
+		return nil, errors.New("more than three parts in " + dotted)
+	}
+	for i, part := range split {
+		n, err := strconv.ParseUint(part, 10, 64)
+		if err != nil {
+			return nil, err
+		}
+		parts[i] = n
+	}
+	return semver.New(parts[0], parts[1], parts[2], "", ""), nil
+}
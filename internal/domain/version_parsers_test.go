@@ -0,0 +1,108 @@
+/*
+ * MIT License
+ *
+ * (C) Copyright [2026] Hewlett Packard Enterprise Development LP
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a
+ * copy of this software and associated documentation files (the "Software"),
+ * to deal in the Software without restriction, including without limitation
+ * the rights to use, copy, modify, merge, publish, distribute, sublicense,
+ * and/or sell copies of the Software, and to permit persons to whom the
+ * Software is furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included
+ * in all copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL
+ * THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+ * OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+ * ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+ * OTHER DEALINGS IN THE SOFTWARE.
+ */
+
+package domain
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/Cray-HPE/hms-firmware-action/internal/storage"
+	"github.com/Masterminds/semver/v3"
+	"github.com/stretchr/testify/suite"
+)
+
+type Version_Parsers_TS struct {
+	suite.Suite
+}
+
+func (suite *Version_Parsers_TS) Test_ParseFirmwareVersion() {
+	for _, tc := range []struct {
+		manufacturer string
+		version      string
+		expected     string
+	}{
+		{"HPE", "U30 v2.42 (10/30/2023)", "2.42.0+U30"},
+		{"hpe", "v1.62", "1.62.0"},
+		{"hpe", "2.78 Jan 10 2023", "2.78.0"},
+		{"hpe", "1.2.3.4 Jan 10 2023", ""},
+		{"gigabyte", "12.84.09", "12.84.9"},
+		{"gigabyte", "12.84.09.1", ""},
+		{"cray", "1.4.2", "1.4.2"},
+		{"cray", "nc.1.4.2-shasta", ""},
+		{"hpe", "", ""},
+	} {
+		version := ParseFirmwareVersion(tc.manufacturer, "BMC", tc.version)
+		if tc.expected == "" {
+			suite.Nil(version, tc.version)
+		} else if suite.NotNil(version, tc.version) {
+			suite.Equal(tc.expected, version.String())
+		}
+	}
+}
+
+func (suite *Version_Parsers_TS) Test_RegisterVersionParser() {
+	parser := func(firmwareVersion string) (*semver.Version, error) {
+		if firmwareVersion == "gold" {
+			return semver.New(9, 9, 9, "", ""), nil
+		}
+		return nil, errors.New("not gold")
+	}
+	RegisterVersionParser("Acme", "BIOS", parser)
+	defer RegisterVersionParser("Acme", "BIOS", nil)
+
+	suite.Equal("9.9.9", ParseFirmwareVersion("acme", "bios", "gold").String())
+	//only the BIOS is registered
+	suite.Nil(ParseFirmwareVersion("acme", "BMC", "gold"))
+	suite.True(FirmwareVersionsEqual("acme", "BIOS", "gold", "gold"))
+	suite.False(FirmwareVersionsEqual("acme", "BMC", "gold", "GOLD"))
+}
+
+func (suite *Version_Parsers_TS) Test_FirmwareVersionMatchesImage() {
+	image := storage.Image{Manufacturer: "hpe", Target: "BIOS", FirmwareVersion: "2.42_10-30-2023",
+		SemanticFirmwareVersion: semver.MustParse("2.42.0")}
+	suite.True(FirmwareVersionMatchesImage("hpe", "BIOS", "2.42_10-30-2023", image))
+	suite.True(FirmwareVersionMatchesImage("", "BIOS", "U30 v2.42 (10/30/2023)", image))
+	suite.False(FirmwareVersionMatchesImage("hpe", "BIOS", "U30 v2.40 (01/12/2023)", image))
+	suite.True(FirmwareVersionsEqual("gigabyte", "BMC", "12.84.09", "12.84.9"))
+
+	//the same version of another ROM family is other firmware
+	suite.False(FirmwareVersionsEqual("hpe", "BIOS", "U30 v2.42 (10/30/2023)", "U32 v2.42 (10/30/2023)"))
+	suite.True(FirmwareVersionsEqual("hpe", "BIOS", "U30 v2.42 (10/30/2023)", "u30 v2.42 (11/02/2023)"))
+	image.SemanticFirmwareVersion = semver.MustParse("2.42.0+U30")
+	suite.True(FirmwareVersionMatchesImage("hpe", "BIOS", "U30 v2.42 (10/30/2023)", image))
+	suite.False(FirmwareVersionMatchesImage("hpe", "BIOS", "U32 v2.42 (10/30/2023)", image))
+
+	//more than three parts cannot be read, so only the strings are compared
+	suite.False(FirmwareVersionsEqual("gigabyte", "BMC", "12.84.09.1", "12.84.09.2"))
+	suite.True(FirmwareVersionsEqual("gigabyte", "BMC", "12.84.09.1", "12.84.09.1"))
+
+	//without vendor rules only the string counts
+	image.Manufacturer = "cray"
+	suite.False(FirmwareVersionMatchesImage("cray", "BIOS", "2.42.0", image))
+}
+
+func Test_Domain_Version_Parsers(t *testing.T) {
+	suite.Run(t, new(Version_Parsers_TS))
+}
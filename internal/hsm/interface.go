@@ -26,6 +26,7 @@ package hsm
 
 import (
 	"github.com/Cray-HPE/hms-base/v2"
+	"github.com/Cray-HPE/hms-smd/v2/pkg/sm"
 )
 
 type HSMv0 struct {
@@ -45,6 +46,7 @@ type HSMProvider interface {
 	//OtherStuff -> GOOD
 	ClearLock(xnames []string) error
 	SetLock(xnames []string) error
+	LockStatus(xnames []string) (locks map[string]sm.CompLockV2, err error)
 	Ping() (err error)
 	Init(globals *HSM_GLOBALS) (err error)
 }
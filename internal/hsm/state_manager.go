@@ -24,6 +24,7 @@
 package hsm
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
 	"errors"
@@ -64,6 +65,7 @@ const hsmRedfishUpdateServicePath = "/hsm/v2/Inventory/ServiceEndpoints/UpdateSe
 const hsmStateComponentsPath = "/hsm/v2/State/Components"
 const hsmComponentEndpointsPath = "/hsm/v2/Inventory/ComponentEndpoints"
 const hsmInventoryHardwarePath = "/hsm/v2/Inventory/Hardware"
+const hsmLockStatusPath = "/hsm/v2/locks/status"
 const defaultSMSServer = "https://api-gw-service-nmn/apis/smd"
 
 type RedfishModel struct {
@@ -911,6 +913,54 @@ func (b *HSMv0) SetLock(xnames []string) (error error) {
 	return error
 }
 
+// LockStatus -> the HSM locks and reservations of the xnames, without taking any.  locks is nil when locking is
+// disabled; an xname HSM does not know is missing from it.
+func (b *HSMv0) LockStatus(xnames []string) (locks map[string]sm.CompLockV2, err error) {
+	if !b.HSMGlobals.LockEnabled {
+		return nil, nil
+	}
+
+	payload, err := json.Marshal(map[string][]string{"ComponentIDs": xnames})
+	if err != nil {
+		return
+	}
+	req, err := http.NewRequest("POST", b.HSMGlobals.StateManagerServer+hsmLockStatusPath, bytes.NewReader(payload))
+	if err != nil {
+		b.HSMGlobals.Logger.Error(err)
+		return
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	reqContext, reqCtxCancel := context.WithTimeout(context.Background(), time.Second*40)
+	req = req.WithContext(reqContext)
+
+	resp, err := b.HSMGlobals.SVCHttpClient.Do(req)
+	defer drainAndCloseBodyWithCtxCancel(resp, reqCtxCancel)
+	if err != nil {
+		b.HSMGlobals.Logger.Error(err)
+		return
+	}
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		b.HSMGlobals.Logger.Error(err)
+		return
+	}
+	if resp.StatusCode >= 400 {
+		err = errors.New("lock status returned " + strconv.Itoa(resp.StatusCode) + ": " + string(body))
+		return
+	}
+
+	var status sm.CompLockV2Status
+	if err = json.Unmarshal(body, &status); err != nil {
+		return
+	}
+	locks = make(map[string]sm.CompLockV2)
+	for _, lock := range status.Components {
+		locks[lock.ID] = lock
+	}
+	return
+}
+
 func (b *HSMv0) ClearLock(xnames []string) (error error) {
 	if !b.HSMGlobals.LockEnabled {
 		return nil
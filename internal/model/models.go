@@ -98,6 +98,29 @@ type TaskLink struct {
 type TaskStateStatus struct {
 	TaskState  string `json:"TaskState"`
 	TaskStatus string `json:"TaskStatus"`
+	// not every BMC reports progress
+	PercentComplete int              `json:"PercentComplete,omitempty"`
+	Messages        []RedfishMessage `json:"Messages,omitempty"`
+}
+
+// Message from a Task or from @Message.ExtendedInfo; older BMCs only fill in Severity
+type RedfishMessage struct {
+	MessageId       string   `json:"MessageId"`
+	Message         string   `json:"Message,omitempty"`
+	MessageArgs     []string `json:"MessageArgs,omitempty"`
+	Severity        string   `json:"Severity,omitempty"`
+	MessageSeverity string   `json:"MessageSeverity,omitempty"`
+	Resolution      string   `json:"Resolution,omitempty"`
+}
+
+// Redfish error response, and the extended info some BMCs attach to an accepted request
+type RedfishError struct {
+	Error struct {
+		Code         string           `json:"code"`
+		Message      string           `json:"message"`
+		ExtendedInfo []RedfishMessage `json:"@Message.ExtendedInfo"`
+	} `json:"error"`
+	ExtendedInfo []RedfishMessage `json:"@Message.ExtendedInfo"`
 }
 
 // Update Information from a Gigabyte update
@@ -120,6 +143,21 @@ type UpdateInfo struct {
 	UpdateTarget    string `json:UpdateTarget`
 }
 
+// Redfish event as posted by a BMC to an EventService subscription
+type RedfishEvent struct {
+	EventType         string   `json:"EventType,omitempty"`
+	MessageId         string   `json:"MessageId"`
+	Message           string   `json:"Message,omitempty"`
+	MessageArgs       []string `json:"MessageArgs,omitempty"`
+	OriginOfCondition TaskLink `json:"OriginOfCondition,omitempty"`
+}
+
+// Redfish event payload; a BMC may post several events at once
+type RedfishEventPayload struct {
+	Context string         `json:"Context,omitempty"`
+	Events  []RedfishEvent `json:"Events"`
+}
+
 type DeviceFirmwareVersion struct {
 	Version         string `json:"Version"`
 	BiosVersion     string `json:"BiosVersion"`
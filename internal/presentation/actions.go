@@ -40,19 +40,25 @@ type OperationKey struct {
 	TargetName          string    `json:"targetName"`
 	FromFirmwareVersion string    `json:"fromFirmwareVersion"`
 	StateHelper         string    `json:"stateHelper"`
+	FailureCode         string    `json:"failureCode,omitempty"`
 	Error               string    `json:"error,omitempty"`
 }
 
 type ActionSummary struct {
-	ActionID        uuid.UUID       `json:"actionID"`
-	SnapshotID      uuid.UUID       `json:"snapshotID,omitempty"`
-	Command         storage.Command `json:"command"`
-	StartTime       string          `json:"startTime"`
-	EndTime         string          `json:"endTime,omitempty"`
-	State           string          `json:"state"`
-	OperationCounts OperationCounts `json:"operationCounts"`
-	BlockedBy       []uuid.UUID     `json:"blockedBy"`
-	Errors          []string        `json:"errors"`
+	ActionID        uuid.UUID           `json:"actionID"`
+	SnapshotID      uuid.UUID           `json:"snapshotID,omitempty"`
+	Command         storage.Command     `json:"command"`
+	StartTime       string              `json:"startTime"`
+	EndTime         string              `json:"endTime,omitempty"`
+	State           string              `json:"state"`
+	OperationCounts OperationCounts     `json:"operationCounts"`
+	FailureCodes    map[string]int      `json:"failureCodes,omitempty"` //failureCode -> number of operations
+	BlockedBy       []uuid.UUID         `json:"blockedBy"`
+	Errors          []string            `json:"errors"`
+	ParentActionID  uuid.UUID           `json:"parentActionID,omitempty"`
+	RetryActionIDs  []uuid.UUID         `json:"retryActionIDs,omitempty"`
+	PauseReason     string              `json:"pauseReason,omitempty"`
+	Activation      *storage.Activation `json:"activation,omitempty"`
 }
 
 type OperationCounts struct {
@@ -68,6 +74,7 @@ type OperationCounts struct {
 	NoOperation   int `json:"noOperation"`
 	NoSolution    int `json:"noSolution"`
 	Aborted       int `json:"aborted"`
+	Staged        int `json:"staged"`
 	Unknown       int `json:"unknown"`
 }
 
@@ -88,6 +95,7 @@ type OperationSummary struct {
 	NoOperation   OperationKeys `json:"noOperation"`   //Nothing done
 	NoSolution    OperationKeys `json:"noSolution"`    //nothing CAN be done
 	Aborted       OperationKeys `json:"aborted"`       //IT was aborted
+	Staged        OperationKeys `json:"staged"`        //the image waits on the device for activation
 	Unknown       OperationKeys `json:"unknown"`       //the state isnt set, but an op exists
 }
 
@@ -108,6 +116,7 @@ type OperationDetail struct {
 	NoOperation   OperationKeysDetail `json:"noOperation"`   //Nothing done
 	NoSolution    OperationKeysDetail `json:"noSolution"`    //nothing CAN be done
 	Aborted       OperationKeysDetail `json:"aborted"`       //IT was aborted
+	Staged        OperationKeysDetail `json:"staged"`        //the image waits on the device for activation
 	Unknown       OperationKeysDetail `json:"unknown"`       //the state isnt set, but an op exists
 }
 
@@ -126,6 +135,11 @@ type ActionMarshaled struct {
 	OperationSummary OperationSummary         `json:"operationSummary"`
 	BlockedBy        []uuid.UUID              `json:"blockedBy"`
 	Errors           []string                 `json:"errors"`
+	ParentActionID   uuid.UUID                `json:"parentActionID,omitempty"`
+	RetryActionIDs   []uuid.UUID              `json:"retryActionIDs,omitempty"`
+	PauseReason      string                   `json:"pauseReason,omitempty"`
+	Activation       *storage.Activation      `json:"activation,omitempty"`
+	RetryChain       []uuid.UUID              `json:"retryChain,omitempty"` //the original action and every retry of it
 }
 
 type ActionOperationsDetail struct {
@@ -139,6 +153,30 @@ type ActionOperationsDetail struct {
 	OperationDetails OperationDetail          `json:"operationDetails"`
 	BlockedBy        []uuid.UUID              `json:"blockedBy"`
 	Errors           []string                 `json:"errors"`
+	ParentActionID   uuid.UUID                `json:"parentActionID,omitempty"`
+	RetryActionIDs   []uuid.UUID              `json:"retryActionIDs,omitempty"`
+	PauseReason      string                   `json:"pauseReason,omitempty"`
+	Activation       *storage.Activation      `json:"activation,omitempty"`
+	RetryChain       []uuid.UUID              `json:"retryChain,omitempty"`
+	PhaseDurations   []PhaseDuration          `json:"phaseDurations,omitempty"`
+}
+
+// PhaseDuration -> how long the operations of an action spent in one state
+type PhaseDuration struct {
+	State          string  `json:"state"`
+	Operations     int     `json:"operations"` //that have been in the state
+	AverageSeconds float64 `json:"averageSeconds"`
+	MaxSeconds     float64 `json:"maxSeconds"`
+}
+
+// OperationHistory -> everything that happened to an operation, oldest first
+type OperationHistory struct {
+	OperationID uuid.UUID                `json:"operationID"`
+	ActionID    uuid.UUID                `json:"actionID"`
+	Xname       string                   `json:"xname"`
+	Target      string                   `json:"target"`
+	State       string                   `json:"state"`
+	History     []storage.OperationEvent `json:"history"`
 }
 
 type OperationPlusImages struct {
@@ -148,33 +186,40 @@ type OperationPlusImages struct {
 }
 
 type OperationMarshaled struct {
-	OperationID                 uuid.UUID   `json:"operationID"`
-	ActionID                    uuid.UUID   `json:"actionID"`
-	State                       string      `json:"state"`
-	StateHelper                 string      `json:"stateHelper"`
-	StartTime                   string      `json:"startTime"`
-	EndTime                     string      `json:"endTime,omitempty"`
-	RefreshTime                 string      `json:"refreshTime"`
-	ExpirationTime              string      `json:"expirationTime"`
-	Xname                       string      `json:"xname"`
-	DeviceType                  string      `json:"deviceType"`
-	Target                      string      `json:"target"`
-	TargetName                  string      `json:"targetName"`
-	Manufacturer                string      `json:"manufacturer"`
-	Model                       string      `json:"model"`
-	SoftwareId                  string      `json:"softwareId"`
-	FromImageID                 uuid.UUID   `json:"fromImageID"`
-	FromSemanticFirmwareVersion string      `json:"fromSemanticFirmwareVersion"` //versionCurrent
-	FromFirmwareVersion         string      `json:"fromFirmwareVersion"`         //mVersionCurrent
-	FromImageURL                string      `json:"fromImageURL"`
-	FromTag                     string      `json:"fromTag"`
-	ToImageID                   uuid.UUID   `json:"toImageID"`
-	ToSemanticFirmwareVersion   string      `json:"toSemanticFirmwareVersion"` //versionUpdate
-	ToFirmwareVersion           string      `json:"toFirmwareVersion"`         //mVersionUpdate
-	ToImageURL                  string      `json:"toImageURL"`
-	ToTag                       string      `json:"toTag"`
-	BlockedBy                   []uuid.UUID `json:"blockedBy"`
-	Error                       string      `json:"error"`
+	OperationID                 uuid.UUID                  `json:"operationID"`
+	ActionID                    uuid.UUID                  `json:"actionID"`
+	State                       string                     `json:"state"`
+	StateHelper                 string                     `json:"stateHelper"`
+	FailureCode                 string                     `json:"failureCode,omitempty"`
+	StartTime                   string                     `json:"startTime"`
+	EndTime                     string                     `json:"endTime,omitempty"`
+	RefreshTime                 string                     `json:"refreshTime"`
+	ExpirationTime              string                     `json:"expirationTime"`
+	Xname                       string                     `json:"xname"`
+	DeviceType                  string                     `json:"deviceType"`
+	Target                      string                     `json:"target"`
+	TargetName                  string                     `json:"targetName"`
+	Manufacturer                string                     `json:"manufacturer"`
+	Model                       string                     `json:"model"`
+	SoftwareId                  string                     `json:"softwareId"`
+	FromImageID                 uuid.UUID                  `json:"fromImageID"`
+	FromSemanticFirmwareVersion string                     `json:"fromSemanticFirmwareVersion"` //versionCurrent
+	FromFirmwareVersion         string                     `json:"fromFirmwareVersion"`         //mVersionCurrent
+	FromImageURL                string                     `json:"fromImageURL"`
+	FromTag                     string                     `json:"fromTag"`
+	ToImageID                   uuid.UUID                  `json:"toImageID"`
+	ToSemanticFirmwareVersion   string                     `json:"toSemanticFirmwareVersion"` //versionUpdate
+	ToFirmwareVersion           string                     `json:"toFirmwareVersion"`         //mVersionUpdate
+	ToImageURL                  string                     `json:"toImageURL"`
+	ToTag                       string                     `json:"toTag"`
+	BlockedBy                   []uuid.UUID                `json:"blockedBy"`
+	Error                       string                     `json:"error"`
+	Attempts                    []storage.OperationAttempt `json:"attempts,omitempty"`
+	HookResults                 []storage.HookResult       `json:"hookResults,omitempty"`
+	BMCMessages                 []storage.BMCMessage       `json:"bmcMessages,omitempty"`
+	Signal                      string                     `json:"signal,omitempty"`
+	DependsOn                   []uuid.UUID                `json:"dependsOn,omitempty"`
+	UpgradePath                 []uuid.UUID                `json:"upgradePath,omitempty"`
 }
 
 func (obj *ActionSummaries) Equals(other ActionSummaries) (equals bool) {
@@ -299,6 +344,7 @@ func (obj *OperationKey) Equals(other OperationKey) bool {
 		obj.OperationID != other.OperationID ||
 		obj.Target != other.Target ||
 		obj.Error != other.Error ||
+		obj.FailureCode != other.FailureCode ||
 		obj.TargetName != other.TargetName {
 		return false
 	}
@@ -312,6 +358,10 @@ func ToActionSummaryFromAction(a storage.Action) (s ActionSummary, err error) {
 	s.State = a.State.Current()
 	s.Errors = []string{}
 	s.Errors = append(s.Errors, a.Errors...)
+	s.ParentActionID = a.ParentActionID
+	s.RetryActionIDs = a.RetryActionIDs
+	s.PauseReason = a.PauseReason
+	s.Activation = a.Activation
 
 	if len(a.BlockedBy) == 0 {
 		s.BlockedBy = []uuid.UUID{}
@@ -355,6 +405,8 @@ func ToOperationCountsFromOperations(o []storage.Operation) (c OperationCounts,
 				c.NoSolution++
 			} else if op.State.Is("aborted") {
 				c.Aborted++
+			} else if op.State.Is("staged") {
+				c.Staged++
 			}
 		} else {
 			c.Unknown++
@@ -365,14 +417,32 @@ func ToOperationCountsFromOperations(o []storage.Operation) (c OperationCounts,
 	return
 }
 
+// ToFailureCodeCountsFromOperations -> counts the operations per failure code, nil when none carry one
+func ToFailureCodeCountsFromOperations(o []storage.Operation) (c map[string]int) {
+	for _, op := range o {
+		if op.FailureCode == "" {
+			continue
+		}
+		if c == nil {
+			c = make(map[string]int)
+		}
+		c[op.FailureCode]++
+	}
+	return
+}
+
 func ToActionMarshaledFromAction(a storage.Action) (m ActionMarshaled, err error) {
 	m = ActionMarshaled{
-		ActionID:   a.ActionID,
-		SnapshotID: a.SnapshotID,
-		Command:    a.Command,
-		State:      a.State.Current(),
-		Parameters: a.Parameters,
-		Errors:     []string{},
+		ActionID:       a.ActionID,
+		SnapshotID:     a.SnapshotID,
+		Command:        a.Command,
+		State:          a.State.Current(),
+		Parameters:     a.Parameters,
+		Errors:         []string{},
+		ParentActionID: a.ParentActionID,
+		RetryActionIDs: a.RetryActionIDs,
+		PauseReason:    a.PauseReason,
+		Activation:     a.Activation,
 	}
 	m.Errors = append(m.Errors, a.Errors...)
 
@@ -393,12 +463,16 @@ func ToActionMarshaledFromAction(a storage.Action) (m ActionMarshaled, err error
 
 func ToActionOperationsDetailFromAction(a storage.Action) (m ActionOperationsDetail, err error) {
 	m = ActionOperationsDetail{
-		ActionID:   a.ActionID,
-		SnapshotID: a.SnapshotID,
-		Command:    a.Command,
-		State:      a.State.Current(),
-		Parameters: a.Parameters,
-		Errors:     []string{},
+		ActionID:       a.ActionID,
+		SnapshotID:     a.SnapshotID,
+		Command:        a.Command,
+		State:          a.State.Current(),
+		Parameters:     a.Parameters,
+		Errors:         []string{},
+		ParentActionID: a.ParentActionID,
+		RetryActionIDs: a.RetryActionIDs,
+		PauseReason:    a.PauseReason,
+		Activation:     a.Activation,
 	}
 	m.Errors = append(m.Errors, a.Errors...)
 
@@ -423,6 +497,7 @@ func ToOperationMarshaledFromOperation(o storage.Operation) (m OperationMarshale
 		ActionID:            o.ActionID,
 		State:               o.State.Current(),
 		StateHelper:         o.StateHelper,
+		FailureCode:         o.FailureCode,
 		Xname:               o.Xname,
 		DeviceType:          o.DeviceType,
 		Target:              o.Target,
@@ -433,6 +508,12 @@ func ToOperationMarshaledFromOperation(o storage.Operation) (m OperationMarshale
 		FromFirmwareVersion: o.FromFirmwareVersion,
 		FromImageID:         o.FromImageID,
 		ToImageID:           o.ToImageID,
+		Attempts:            o.Attempts,
+		HookResults:         o.HookResults,
+		BMCMessages:         o.BMCMessages,
+		Signal:              o.Signal,
+		DependsOn:           o.DependsOn,
+		UpgradePath:         o.UpgradePath,
 	}
 	if o.Error != nil {
 		m.Error = o.Error.Error()
@@ -471,6 +552,7 @@ func ToOperationSummaryFromOperations(o []storage.Operation) (c OperationSummary
 	c.NoOperation = OperationKeys{OperationsKeys: []OperationKey{}}
 	c.NoSolution = OperationKeys{OperationsKeys: []OperationKey{}}
 	c.Aborted = OperationKeys{OperationsKeys: []OperationKey{}}
+	c.Staged = OperationKeys{OperationsKeys: []OperationKey{}}
 	c.Unknown = OperationKeys{OperationsKeys: []OperationKey{}}
 
 	for _, op := range o {
@@ -481,6 +563,7 @@ func ToOperationSummaryFromOperations(o []storage.Operation) (c OperationSummary
 			TargetName:          op.TargetName,
 			FromFirmwareVersion: op.FromFirmwareVersion,
 			StateHelper:         op.StateHelper,
+			FailureCode:         op.FailureCode,
 		}
 		if op.Error != nil {
 			opkey.Error = op.Error.Error()
@@ -508,6 +591,8 @@ func ToOperationSummaryFromOperations(o []storage.Operation) (c OperationSummary
 				c.NoSolution.OperationsKeys = append(c.NoSolution.OperationsKeys, opkey)
 			} else if op.State.Is("aborted") {
 				c.Aborted.OperationsKeys = append(c.Aborted.OperationsKeys, opkey)
+			} else if op.State.Is("staged") {
+				c.Staged.OperationsKeys = append(c.Staged.OperationsKeys, opkey)
 			}
 		} else {
 			c.Unknown.OperationsKeys = append(c.Unknown.OperationsKeys, opkey)
@@ -530,6 +615,7 @@ func ToOperationDetailFromOperations(o []OperationPlusImages) (c OperationDetail
 	c.NoOperation = OperationKeysDetail{OperationsKeys: []OperationMarshaled{}}
 	c.NoSolution = OperationKeysDetail{OperationsKeys: []OperationMarshaled{}}
 	c.Aborted = OperationKeysDetail{OperationsKeys: []OperationMarshaled{}}
+	c.Staged = OperationKeysDetail{OperationsKeys: []OperationMarshaled{}}
 	c.Unknown = OperationKeysDetail{OperationsKeys: []OperationMarshaled{}}
 
 	for _, opi := range o {
@@ -575,6 +661,8 @@ func ToOperationDetailFromOperations(o []OperationPlusImages) (c OperationDetail
 				c.NoSolution.OperationsKeys = append(c.NoSolution.OperationsKeys, opkey)
 			} else if op.State.Is("aborted") {
 				c.Aborted.OperationsKeys = append(c.Aborted.OperationsKeys, opkey)
+			} else if op.State.Is("staged") {
+				c.Staged.OperationsKeys = append(c.Staged.OperationsKeys, opkey)
 			}
 		} else {
 			c.Unknown.OperationsKeys = append(c.Unknown.OperationsKeys, opkey)
@@ -584,6 +672,22 @@ func ToOperationDetailFromOperations(o []OperationPlusImages) (c OperationDetail
 	return
 }
 
+// PauseActionParameters - why the action was paused, shown until it is resumed
+type PauseActionParameters struct {
+	Reason string `json:"reason,omitempty"`
+}
+
+// ActivateActionParameters - how the images a staging action left on the devices are reset in
+type ActivateActionParameters struct {
+	BatchSize int    `json:"batchSize,omitempty"`
+	ResetType string `json:"resetType,omitempty"`
+}
+
+// RetryActionParameters - which operations of the parent action to retry; empty means failed, aborted and noSolution
+type RetryActionParameters struct {
+	States []string `json:"states,omitempty"`
+}
+
 type CreateActionPayload struct {
 	ActionID       uuid.UUID `json:"actionID"`
 	OverrideDryrun bool      `json:"overrideDryrun"`
@@ -0,0 +1,71 @@
+/*
+ * MIT License
+ *
+ * (C) Copyright [2026] Hewlett Packard Enterprise Development LP
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a
+ * copy of this software and associated documentation files (the "Software"),
+ * to deal in the Software without restriction, including without limitation
+ * the rights to use, copy, modify, merge, publish, distribute, sublicense,
+ * and/or sell copies of the Software, and to permit persons to whom the
+ * Software is furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included
+ * in all copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL
+ * THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+ * OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+ * ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+ * OTHER DEALINGS IN THE SOFTWARE.
+ */
+
+package presentation
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Cray-HPE/hms-firmware-action/internal/storage"
+	"github.com/stretchr/testify/suite"
+)
+
+type Actions_TS struct {
+	suite.Suite
+}
+
+func (suite *Actions_TS) Test_ToFailureCodeCountsFromOperations() {
+	succeeded := storage.HelperGetStockOperation()
+	succeeded.State.Event(context.Background(), "configure")
+
+	noop := storage.HelperGetStockOperation()
+	noop.State.Event(context.Background(), "noop")
+	noop.FailureCode = storage.FailureSameVersion
+
+	rejected := storage.HelperGetStockOperation()
+	rejected.State.Event(context.Background(), "fail")
+	rejected.FailureCode = storage.FailureBMCRejected
+
+	rejectedToo := storage.HelperGetStockOperation()
+	rejectedToo.State.Event(context.Background(), "fail")
+	rejectedToo.FailureCode = storage.FailureBMCRejected
+
+	suite.Nil(ToFailureCodeCountsFromOperations([]storage.Operation{succeeded}))
+	counts := ToFailureCodeCountsFromOperations([]storage.Operation{succeeded, noop, rejected, rejectedToo})
+	suite.Equal(map[string]int{storage.FailureSameVersion: 1, storage.FailureBMCRejected: 2}, counts)
+
+	summary, err := ToOperationSummaryFromOperations([]storage.Operation{rejected})
+	suite.NoError(err)
+	suite.Len(summary.Failed.OperationsKeys, 1)
+	suite.Equal(storage.FailureBMCRejected, summary.Failed.OperationsKeys[0].FailureCode)
+
+	marshaled, err := ToOperationMarshaledFromOperation(noop)
+	suite.NoError(err)
+	suite.Equal(storage.FailureSameVersion, marshaled.FailureCode)
+}
+
+func Test_Presentation_Actions(t *testing.T) {
+	suite.Run(t, new(Actions_TS))
+}
@@ -0,0 +1,55 @@
+/*
+ * MIT License
+ *
+ * (C) Copyright [2026] Hewlett Packard Enterprise Development LP
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a
+ * copy of this software and associated documentation files (the "Software"),
+ * to deal in the Software without restriction, including without limitation
+ * the rights to use, copy, modify, merge, publish, distribute, sublicense,
+ * and/or sell copies of the Software, and to permit persons to whom the
+ * Software is furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included
+ * in all copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL
+ * THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+ * OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+ * ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+ * OTHER DEALINGS IN THE SOFTWARE.
+ */
+
+package presentation
+
+import (
+	"github.com/Cray-HPE/hms-firmware-action/internal/storage"
+)
+
+type ArchivedActionSummary struct {
+	ActionSummary
+	ArchiveTime string `json:"archiveTime"`
+}
+
+type ArchivedActionSummaries struct {
+	Actions []ArchivedActionSummary `json:"actions"`
+}
+
+type ArchivedActionDetail struct {
+	ActionOperationsDetail
+	ArchiveTime string `json:"archiveTime"`
+}
+
+func ToArchivedActionSummary(rec storage.ArchivedAction) (s ArchivedActionSummary, err error) {
+	action, operations := storage.ToActionFromArchived(rec)
+	s.ActionSummary, err = ToActionSummaryFromAction(action)
+	if err != nil {
+		return s, err
+	}
+	s.OperationCounts, err = ToOperationCountsFromOperations(operations)
+	s.FailureCodes = ToFailureCodeCountsFromOperations(operations)
+	s.ArchiveTime = rec.ArchiveTime.String()
+	return s, err
+}
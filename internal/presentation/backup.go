@@ -0,0 +1,66 @@
+/*
+ * MIT License
+ *
+ * (C) Copyright [2026] Hewlett Packard Enterprise Development LP
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a
+ * copy of this software and associated documentation files (the "Software"),
+ * to deal in the Software without restriction, including without limitation
+ * the rights to use, copy, modify, merge, publish, distribute, sublicense,
+ * and/or sell copies of the Software, and to permit persons to whom the
+ * Software is furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included
+ * in all copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL
+ * THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+ * OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+ * ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+ * OTHER DEALINGS IN THE SOFTWARE.
+ */
+
+package presentation
+
+import (
+	"github.com/Cray-HPE/hms-firmware-action/internal/storage"
+)
+
+type BackupRestoreCounts struct {
+	Restored    int `json:"restored"`
+	Overwritten int `json:"overwritten"`
+	Skipped     int `json:"skipped"`
+}
+
+type BackupRestoreSummary struct {
+	Images     BackupRestoreCounts `json:"images"`
+	Snapshots  BackupRestoreCounts `json:"snapshots"`
+	Actions    BackupRestoreCounts `json:"actions"`
+	Operations BackupRestoreCounts `json:"operations"`
+	Rules      BackupRestoreCounts `json:"compatibilityRules"`
+	Exclusions BackupRestoreCounts `json:"exclusionPolicies"`
+	Quarantine BackupRestoreCounts `json:"quarantine"`
+}
+
+// CountsFor - returns the counts for a storage.BackupKind* record kind
+func (obj *BackupRestoreSummary) CountsFor(kind string) *BackupRestoreCounts {
+	switch kind {
+	case storage.BackupKindImage:
+		return &obj.Images
+	case storage.BackupKindSnapshot:
+		return &obj.Snapshots
+	case storage.BackupKindAction:
+		return &obj.Actions
+	case storage.BackupKindOperation:
+		return &obj.Operations
+	case storage.BackupKindRule:
+		return &obj.Rules
+	case storage.BackupKindExclusion:
+		return &obj.Exclusions
+	case storage.BackupKindQuarantine:
+		return &obj.Quarantine
+	}
+	return &BackupRestoreCounts{}
+}
@@ -0,0 +1,99 @@
+/*
+ * MIT License
+ *
+ * (C) Copyright [2026] Hewlett Packard Enterprise Development LP
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a
+ * copy of this software and associated documentation files (the "Software"),
+ * to deal in the Software without restriction, including without limitation
+ * the rights to use, copy, modify, merge, publish, distribute, sublicense,
+ * and/or sell copies of the Software, and to permit persons to whom the
+ * Software is furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included
+ * in all copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL
+ * THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+ * OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+ * ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+ * OTHER DEALINGS IN THE SOFTWARE.
+ */
+
+package presentation
+
+import (
+	"time"
+
+	"github.com/Cray-HPE/hms-firmware-action/internal/storage"
+	"github.com/google/uuid"
+)
+
+type CompatibilityRules struct {
+	CompatibilityRules []CompatibilityRuleMarshaled `json:"compatibilityRules"`
+}
+
+// RawCompatibilityRule - what a client sends to create or replace a rule
+type RawCompatibilityRule struct {
+	Description      string   `json:"description,omitempty"`
+	Type             string   `json:"type"`
+	Enforcement      string   `json:"enforcement,omitempty"`
+	Manufacturer     string   `json:"manufacturer,omitempty"`
+	Models           []string `json:"models,omitempty"`
+	Target           string   `json:"target"`
+	Versions         string   `json:"versions,omitempty"`
+	RequiredTarget   string   `json:"requiredTarget,omitempty"`
+	RequiredVersions string   `json:"requiredVersions,omitempty"`
+	Scope            string   `json:"scope,omitempty"`
+}
+
+func (other *RawCompatibilityRule) NewCompatibilityRule() (obj storage.CompatibilityRule) {
+	obj.RuleID = uuid.New()
+	obj.CreateTime.Scan(time.Now())
+	obj.Description = other.Description
+	obj.Type = other.Type
+	obj.Enforcement = other.Enforcement
+	obj.Manufacturer = other.Manufacturer
+	obj.Models = append(obj.Models, other.Models...)
+	obj.Target = other.Target
+	obj.Versions = other.Versions
+	obj.RequiredTarget = other.RequiredTarget
+	obj.RequiredVersions = other.RequiredVersions
+	obj.Scope = other.Scope
+	return obj
+}
+
+type CompatibilityRuleMarshaled struct {
+	RuleID           uuid.UUID `json:"ruleID"`
+	CreateTime       string    `json:"createTime,omitempty"`
+	Description      string    `json:"description,omitempty"`
+	Type             string    `json:"type"`
+	Enforcement      string    `json:"enforcement"`
+	Manufacturer     string    `json:"manufacturer,omitempty"`
+	Models           []string  `json:"models,omitempty"`
+	Target           string    `json:"target"`
+	Versions         string    `json:"versions,omitempty"`
+	RequiredTarget   string    `json:"requiredTarget,omitempty"`
+	RequiredVersions string    `json:"requiredVersions,omitempty"`
+	Scope            string    `json:"scope,omitempty"`
+}
+
+func ToCompatibilityRuleMarshaled(from storage.CompatibilityRule) (to CompatibilityRuleMarshaled) {
+	to = CompatibilityRuleMarshaled{
+		RuleID:           from.RuleID,
+		CreateTime:       from.CreateTime.Time.Format(time.RFC3339),
+		Description:      from.Description,
+		Type:             from.Type,
+		Enforcement:      from.Enforcement,
+		Manufacturer:     from.Manufacturer,
+		Models:           from.Models,
+		Target:           from.Target,
+		Versions:         from.Versions,
+		RequiredTarget:   from.RequiredTarget,
+		RequiredVersions: from.RequiredVersions,
+		Scope:            from.Scope,
+	}
+	return to
+}
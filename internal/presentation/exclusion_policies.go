@@ -0,0 +1,95 @@
+/*
+ * MIT License
+ *
+ * (C) Copyright [2026] Hewlett Packard Enterprise Development LP
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a
+ * copy of this software and associated documentation files (the "Software"),
+ * to deal in the Software without restriction, including without limitation
+ * the rights to use, copy, modify, merge, publish, distribute, sublicense,
+ * and/or sell copies of the Software, and to permit persons to whom the
+ * Software is furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included
+ * in all copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL
+ * THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+ * OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+ * ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+ * OTHER DEALINGS IN THE SOFTWARE.
+ */
+
+package presentation
+
+import (
+	"time"
+
+	"github.com/Cray-HPE/hms-firmware-action/internal/storage"
+	"github.com/google/uuid"
+)
+
+type ExclusionPolicies struct {
+	ExclusionPolicies []ExclusionPolicyMarshaled `json:"exclusionPolicies"`
+}
+
+// RawExclusionPolicy - what a client sends to create or replace a policy
+type RawExclusionPolicy struct {
+	Description  string   `json:"description,omitempty"`
+	Roles        []string `json:"roles,omitempty"`
+	SubRoles     []string `json:"subRoles,omitempty"`
+	Groups       []string `json:"groups,omitempty"`
+	Partitions   []string `json:"partitions,omitempty"`
+	Xnames       []string `json:"xnames,omitempty"`
+	Manufacturer string   `json:"manufacturer,omitempty"`
+	Models       []string `json:"models,omitempty"`
+	Targets      []string `json:"targets,omitempty"`
+}
+
+func (other *RawExclusionPolicy) NewExclusionPolicy() (obj storage.ExclusionPolicy) {
+	obj.PolicyID = uuid.New()
+	obj.CreateTime.Scan(time.Now())
+	obj.Description = other.Description
+	obj.Roles = append(obj.Roles, other.Roles...)
+	obj.SubRoles = append(obj.SubRoles, other.SubRoles...)
+	obj.Groups = append(obj.Groups, other.Groups...)
+	obj.Partitions = append(obj.Partitions, other.Partitions...)
+	obj.Xnames = append(obj.Xnames, other.Xnames...)
+	obj.Manufacturer = other.Manufacturer
+	obj.Models = append(obj.Models, other.Models...)
+	obj.Targets = append(obj.Targets, other.Targets...)
+	return obj
+}
+
+type ExclusionPolicyMarshaled struct {
+	PolicyID     uuid.UUID `json:"policyID"`
+	CreateTime   string    `json:"createTime,omitempty"`
+	Description  string    `json:"description,omitempty"`
+	Roles        []string  `json:"roles,omitempty"`
+	SubRoles     []string  `json:"subRoles,omitempty"`
+	Groups       []string  `json:"groups,omitempty"`
+	Partitions   []string  `json:"partitions,omitempty"`
+	Xnames       []string  `json:"xnames,omitempty"`
+	Manufacturer string    `json:"manufacturer,omitempty"`
+	Models       []string  `json:"models,omitempty"`
+	Targets      []string  `json:"targets,omitempty"`
+}
+
+func ToExclusionPolicyMarshaled(from storage.ExclusionPolicy) (to ExclusionPolicyMarshaled) {
+	to = ExclusionPolicyMarshaled{
+		PolicyID:     from.PolicyID,
+		CreateTime:   from.CreateTime.Time.Format(time.RFC3339),
+		Description:  from.Description,
+		Roles:        from.Roles,
+		SubRoles:     from.SubRoles,
+		Groups:       from.Groups,
+		Partitions:   from.Partitions,
+		Xnames:       from.Xnames,
+		Manufacturer: from.Manufacturer,
+		Models:       from.Models,
+		Targets:      from.Targets,
+	}
+	return to
+}
@@ -39,23 +39,27 @@ type Images struct {
 }
 
 type RawImage struct {
-	DeviceType                        string   `json:"deviceType"`
-	Manufacturer                      string   `json:"manufacturer,omitempty"`
-	Models                            []string `json:"models,omitempty"`
-	SoftwareIds                       []string `json:"softwareIds,omitempty"`
-	Target                            string   `json:"target,omitempty"`
-	Tags                              []string `json:"tags,omitempty"`
-	FirmwareVersion                   string   `json:"firmwareVersion"`
-	SemanticFirmwareVersion           string   `json:"semanticFirmwareVersion,omitempty"`
-	UpdateURI                         string   `json:"updateURI"`
-	NeedManualReboot                  bool     `json:"needManualReboot,omitempty"`
-	WaitTimeBeforeManualRebootSeconds int      `json:"waitTimeBeforeManualRebootSeconds"`
-	WaitTimeAfterRebootSeconds        int      `json:"waitTimeAfterRebootSeconds"`
-	PollingSpeedSeconds               int      `json:"pollingSpeedSeconds"`
-	ForceResetType                    string   `json:"forceResetType"`
-	S3URL                             string   `json:"s3URL"`
-	TftpURL                           string   `json:"tftpURL"`
-	AllowableDeviceStates             []string `json:"allowableDeviceStates,omitempty"`
+	DeviceType                        string                      `json:"deviceType"`
+	Manufacturer                      string                      `json:"manufacturer,omitempty"`
+	Models                            []string                    `json:"models,omitempty"`
+	SoftwareIds                       []string                    `json:"softwareIds,omitempty"`
+	Target                            string                      `json:"target,omitempty"`
+	Tags                              []string                    `json:"tags,omitempty"`
+	FirmwareVersion                   string                      `json:"firmwareVersion"`
+	SemanticFirmwareVersion           string                      `json:"semanticFirmwareVersion,omitempty"`
+	UpdateURI                         string                      `json:"updateURI"`
+	NeedManualReboot                  bool                        `json:"needManualReboot,omitempty"`
+	WaitTimeBeforeManualRebootSeconds int                         `json:"waitTimeBeforeManualRebootSeconds"`
+	WaitTimeAfterRebootSeconds        int                         `json:"waitTimeAfterRebootSeconds"`
+	PollingSpeedSeconds               int                         `json:"pollingSpeedSeconds"`
+	ForceResetType                    string                      `json:"forceResetType"`
+	S3URL                             string                      `json:"s3URL"`
+	TftpURL                           string                      `json:"tftpURL"`
+	AllowableDeviceStates             []string                    `json:"allowableDeviceStates,omitempty"`
+	RetryPolicy                       *storage.RetryPolicy        `json:"retryPolicy,omitempty"`
+	Prerequisites                     []storage.ImagePrerequisite `json:"prerequisites,omitempty"`
+	RequiresFromVersion               string                      `json:"requiresFromVersion,omitempty"`
+	NoDowngradeBelow                  string                      `json:"noDowngradeBelow,omitempty"`
 }
 
 func (obj *RawImage) Equals(other RawImage) bool {
@@ -75,7 +79,11 @@ func (obj *RawImage) Equals(other RawImage) bool {
 		obj.WaitTimeAfterRebootSeconds != other.WaitTimeAfterRebootSeconds ||
 		obj.S3URL != other.S3URL ||
 		obj.TftpURL != other.TftpURL ||
-		model.StringSliceEquals(obj.AllowableDeviceStates, other.AllowableDeviceStates) == false {
+		model.StringSliceEquals(obj.AllowableDeviceStates, other.AllowableDeviceStates) == false ||
+		obj.RetryPolicy.Equals(other.RetryPolicy) == false ||
+		storage.ImagePrerequisitesEquals(obj.Prerequisites, other.Prerequisites) == false ||
+		obj.RequiresFromVersion != other.RequiresFromVersion ||
+		obj.NoDowngradeBelow != other.NoDowngradeBelow {
 		return false
 	}
 	return true
@@ -91,12 +99,15 @@ func (other *RawImage) NewImage() (obj storage.Image, err error) {
 	obj.Target = other.Target
 	obj.FirmwareVersion = other.FirmwareVersion
 	obj.Tags = append(obj.Tags, other.Tags...)
-	v, err := semver.NewVersion(other.SemanticFirmwareVersion)
-	if err != nil {
-		logrus.Error(err)
-		return obj, err
-	} else {
-		obj.SemanticFirmwareVersion = v
+	//left empty it is derived from the firmware version
+	if other.SemanticFirmwareVersion != "" {
+		v, err := semver.NewVersion(other.SemanticFirmwareVersion)
+		if err != nil {
+			logrus.Error(err)
+			return obj, err
+		} else {
+			obj.SemanticFirmwareVersion = v
+		}
 	}
 
 	obj.UpdateURI = other.UpdateURI
@@ -108,30 +119,38 @@ func (other *RawImage) NewImage() (obj storage.Image, err error) {
 	obj.S3URL = other.S3URL
 	obj.TftpURL = other.TftpURL
 	obj.AllowableDeviceStates = append(obj.AllowableDeviceStates, other.AllowableDeviceStates...)
+	obj.RetryPolicy = other.RetryPolicy
+	obj.Prerequisites = append(obj.Prerequisites, other.Prerequisites...)
+	obj.RequiresFromVersion = other.RequiresFromVersion
+	obj.NoDowngradeBelow = other.NoDowngradeBelow
 
 	return obj, nil
 }
 
 type ImageMarshaled struct {
-	ImageID                           uuid.UUID `json:"imageID"`
-	CreateTime                        string    `json:"createTime,omitempty"`
-	DeviceType                        string    `json:"deviceType,omitempty"`
-	Manufacturer                      string    `json:"manufacturer,omitempty"`
-	Models                            []string  `json:"models,omitempty"`
-	SoftwareIds                       []string  `json:"softwareIds,omitempty"`
-	Target                            string    `json:"target,omitempty"`
-	Tags                              []string  `json:"tags,omitempty"`
-	FirmwareVersion                   string    `json:"firmwareVersion,omitempty"`
-	SemanticFirmwareVersion           string    `json:"semanticFirmwareVersion,omitempty"`
-	UpdateURI                         string    `json:"updateURI,omitempty"`
-	NeedManualReboot                  bool      `json:"needManualReboot,omitempty"`
-	WaitTimeBeforeManualRebootSeconds int       `json:"waitTimeBeforeManualRebootSeconds,omitempty"`
-	WaitTimeAfterRebootSeconds        int       `json:"waitTimeAfterRebootSeconds,omitempty"`
-	PollingSpeedSeconds               int       `json:"pollingSpeedSeconds,omitempty"`
-	ForceResetType                    string    `json:"forceResetType,omitempty"`
-	S3URL                             string    `json:"s3URL,omitempty"`
-	TftpURL                           string    `json:"tftpURL,omitempty"`
-	AllowableDeviceStates             []string  `json:"allowableDeviceStates,omitempty"`
+	ImageID                           uuid.UUID                   `json:"imageID"`
+	CreateTime                        string                      `json:"createTime,omitempty"`
+	DeviceType                        string                      `json:"deviceType,omitempty"`
+	Manufacturer                      string                      `json:"manufacturer,omitempty"`
+	Models                            []string                    `json:"models,omitempty"`
+	SoftwareIds                       []string                    `json:"softwareIds,omitempty"`
+	Target                            string                      `json:"target,omitempty"`
+	Tags                              []string                    `json:"tags,omitempty"`
+	FirmwareVersion                   string                      `json:"firmwareVersion,omitempty"`
+	SemanticFirmwareVersion           string                      `json:"semanticFirmwareVersion,omitempty"`
+	UpdateURI                         string                      `json:"updateURI,omitempty"`
+	NeedManualReboot                  bool                        `json:"needManualReboot,omitempty"`
+	WaitTimeBeforeManualRebootSeconds int                         `json:"waitTimeBeforeManualRebootSeconds,omitempty"`
+	WaitTimeAfterRebootSeconds        int                         `json:"waitTimeAfterRebootSeconds,omitempty"`
+	PollingSpeedSeconds               int                         `json:"pollingSpeedSeconds,omitempty"`
+	ForceResetType                    string                      `json:"forceResetType,omitempty"`
+	S3URL                             string                      `json:"s3URL,omitempty"`
+	TftpURL                           string                      `json:"tftpURL,omitempty"`
+	AllowableDeviceStates             []string                    `json:"allowableDeviceStates,omitempty"`
+	RetryPolicy                       *storage.RetryPolicy        `json:"retryPolicy,omitempty"`
+	Prerequisites                     []storage.ImagePrerequisite `json:"prerequisites,omitempty"`
+	RequiresFromVersion               string                      `json:"requiresFromVersion,omitempty"`
+	NoDowngradeBelow                  string                      `json:"noDowngradeBelow,omitempty"`
 }
 
 func (obj ImageMarshaled) Equals(other ImageMarshaled) bool {
@@ -194,6 +213,18 @@ func (obj ImageMarshaled) Equals(other ImageMarshaled) bool {
 	} else if model.StringSliceEquals(obj.AllowableDeviceStates, other.AllowableDeviceStates) == false {
 		logrus.Warn("AllowableDeviceStates is not equal")
 		return false
+	} else if obj.RetryPolicy.Equals(other.RetryPolicy) == false {
+		logrus.Warn("RetryPolicy is not equal")
+		return false
+	} else if storage.ImagePrerequisitesEquals(obj.Prerequisites, other.Prerequisites) == false {
+		logrus.Warn("Prerequisites is not equal")
+		return false
+	} else if obj.RequiresFromVersion != other.RequiresFromVersion {
+		logrus.Warn("RequiresFromVersion is not equal")
+		return false
+	} else if obj.NoDowngradeBelow != other.NoDowngradeBelow {
+		logrus.Warn("NoDowngradeBelow is not equal")
+		return false
 	}
 	return true
 }
@@ -219,6 +250,10 @@ func ToImageMarshaled(from storage.Image) (to ImageMarshaled) {
 		S3URL:                             from.S3URL,
 		TftpURL:                           from.TftpURL,
 		AllowableDeviceStates:             from.AllowableDeviceStates,
+		RetryPolicy:                       from.RetryPolicy,
+		Prerequisites:                     from.Prerequisites,
+		RequiresFromVersion:               from.RequiresFromVersion,
+		NoDowngradeBelow:                  from.NoDowngradeBelow,
 	}
 
 	return to
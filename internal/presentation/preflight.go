@@ -0,0 +1,72 @@
+/*
+ * MIT License
+ *
+ * (C) Copyright [2026] Hewlett Packard Enterprise Development LP
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a
+ * copy of this software and associated documentation files (the "Software"),
+ * to deal in the Software without restriction, including without limitation
+ * the rights to use, copy, modify, merge, publish, distribute, sublicense,
+ * and/or sell copies of the Software, and to permit persons to whom the
+ * Software is furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included
+ * in all copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL
+ * THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+ * OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+ * ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+ * OTHER DEALINGS IN THE SOFTWARE.
+ */
+
+package presentation
+
+import "github.com/google/uuid"
+
+const (
+	PreflightPassed  = "passed"
+	PreflightFailed  = "failed"
+	PreflightSkipped = "skipped" //the check does not apply, or there is nothing to check it with
+)
+
+// PreflightCheck -> one readiness check of one device
+type PreflightCheck struct {
+	Name   string `json:"name"` //bmcReachable, credentials, lock, imageFile or powerState
+	Result string `json:"result"`
+	Detail string `json:"detail,omitempty"`
+}
+
+// PreflightDevice -> an operation the action would create, and whether its device is ready for it.  Only
+// operations that would be launched are checked.
+type PreflightDevice struct {
+	Xname               string           `json:"xname"`
+	Target              string           `json:"target"`
+	TargetName          string           `json:"targetName"`
+	State               string           `json:"state"` //the state the operation would start in
+	StateHelper         string           `json:"stateHelper,omitempty"`
+	FailureCode         string           `json:"failureCode,omitempty"`
+	FromFirmwareVersion string           `json:"fromFirmwareVersion"`
+	ToFirmwareVersion   string           `json:"toFirmwareVersion,omitempty"`
+	ToImageID           uuid.UUID        `json:"toImageID,omitempty"`
+	Ready               bool             `json:"ready"`
+	Checks              []PreflightCheck `json:"checks,omitempty"`
+}
+
+type PreflightCounts struct {
+	Total       int `json:"total"`
+	Ready       int `json:"ready"`
+	NotReady    int `json:"notReady"`
+	NoOperation int `json:"noOperation"`
+	NoSolution  int `json:"noSolution"`
+}
+
+// PreflightReport -> the answer to POST /actions/preflight; nothing is stored
+type PreflightReport struct {
+	Ready   bool              `json:"ready"` //every operation that would be launched is ready
+	Counts  PreflightCounts   `json:"counts"`
+	Devices []PreflightDevice `json:"devices"`
+	Errors  []string          `json:"errors"`
+}
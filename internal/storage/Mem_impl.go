@@ -38,8 +38,19 @@ type MemStorage struct {
 	mutex      sync.Mutex
 	Actions    map[uuid.UUID]Action
 	Operations map[uuid.UUID]Operation
+	Histories  map[uuid.UUID][]OperationEvent //by operation ID
 	Images     map[uuid.UUID]Image
 	Snapshots  map[string]Snapshot
+	Rules      map[uuid.UUID]CompatibilityRule
+	Quarantine map[string]QuarantineEntry //by quarantineKey
+	Exclusions map[uuid.UUID]ExclusionPolicy
+
+	// PersistPath - if set, the store is saved to and reloaded from this file
+	PersistPath     string
+	PersistInterval time.Duration
+	persistMutex    sync.Mutex
+	stopPersist     chan struct{}
+	persistDone     chan struct{}
 }
 
 func (b *MemStorage) Init(Logger *logrus.Logger) (err error) {
@@ -47,9 +58,26 @@ func (b *MemStorage) Init(Logger *logrus.Logger) (err error) {
 
 	b.Actions = make(map[uuid.UUID]Action)
 	b.Operations = make(map[uuid.UUID]Operation)
+	b.Histories = make(map[uuid.UUID][]OperationEvent)
 	b.Images = make(map[uuid.UUID]Image)
 	b.Snapshots = make(map[string]Snapshot)
+	b.Rules = make(map[uuid.UUID]CompatibilityRule)
+	b.Quarantine = make(map[string]QuarantineEntry)
+	b.Exclusions = make(map[uuid.UUID]ExclusionPolicy)
 
+	err = b.initPersistence()
+	return err
+}
+
+// Close - saves a final copy of the store if persistence is enabled
+func (b *MemStorage) Close() (err error) {
+	if b.stopPersist == nil {
+		return nil
+	}
+	close(b.stopPersist)
+	<-b.persistDone
+	b.stopPersist = nil
+	err = b.Persist()
 	return err
 }
 func (b *MemStorage) Ping() (err error) {
@@ -157,6 +185,7 @@ func (b *MemStorage) DeleteOperation(operationID uuid.UUID) (err error) {
 	defer b.mutex.Unlock()
 	if _, ok := b.Operations[operationID]; ok {
 		delete(b.Operations, operationID)
+		delete(b.Histories, operationID)
 	} else {
 		err = errors.New("could not find key")
 		b.Logger.WithField("operationID", operationID.String()).Error(err)
@@ -185,6 +214,31 @@ func (b *MemStorage) GetAllOperations() (o []Operation, err error) {
 	return o, err
 }
 
+// err always nil; an operation without history has an empty one
+func (b *MemStorage) GetOperationHistory(operationID uuid.UUID) (h []OperationEvent, err error) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	h = append(h, b.Histories[operationID]...)
+	return h, err
+}
+
+// err always nil
+func (b *MemStorage) AppendOperationHistory(operationID uuid.UUID, events []OperationEvent) (err error) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	history := append(b.Histories[operationID], events...)
+	b.Histories[operationID] = CapOperationHistory(history)
+	return err
+}
+
+// err always nil
+func (b *MemStorage) DeleteOperationHistory(operationID uuid.UUID) (err error) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	delete(b.Histories, operationID)
+	return err
+}
+
 func (b *MemStorage) GetOperations(actionID uuid.UUID) (o []Operation, err error) {
 	action, err := b.GetAction(actionID)
 	if err != nil {
@@ -243,3 +297,131 @@ func (b *MemStorage) GetImages() (i []Image, err error) {
 	}
 	return i, err
 }
+
+// err is always nil
+func (b *MemStorage) StoreCompatibilityRule(r CompatibilityRule) (err error) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	b.Rules[r.RuleID] = r
+	return err
+}
+
+func (b *MemStorage) DeleteCompatibilityRule(ruleID uuid.UUID) (err error) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	if _, ok := b.Rules[ruleID]; ok {
+		delete(b.Rules, ruleID)
+	} else {
+		err = errors.New("could not find key")
+		b.Logger.WithField("ruleID", ruleID.String()).Error(err)
+	}
+	return err
+}
+
+func (b *MemStorage) GetCompatibilityRule(ruleID uuid.UUID) (r CompatibilityRule, err error) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	if r, ok := b.Rules[ruleID]; ok {
+		return r, nil
+	} else {
+		err = errors.New("could not find key")
+		b.Logger.WithField("ruleID", ruleID.String()).Error(err)
+	}
+	return r, err
+}
+
+// err always nil
+func (b *MemStorage) GetCompatibilityRules() (r []CompatibilityRule, err error) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	for _, val := range b.Rules {
+		r = append(r, val)
+	}
+	return r, err
+}
+
+// err is always nil
+func (b *MemStorage) StoreExclusionPolicy(p ExclusionPolicy) (err error) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	b.Exclusions[p.PolicyID] = p
+	return err
+}
+
+func (b *MemStorage) DeleteExclusionPolicy(policyID uuid.UUID) (err error) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	if _, ok := b.Exclusions[policyID]; ok {
+		delete(b.Exclusions, policyID)
+	} else {
+		err = errors.New("could not find key")
+		b.Logger.WithField("policyID", policyID.String()).Error(err)
+	}
+	return err
+}
+
+func (b *MemStorage) GetExclusionPolicy(policyID uuid.UUID) (p ExclusionPolicy, err error) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	if p, ok := b.Exclusions[policyID]; ok {
+		return p, nil
+	} else {
+		err = errors.New("could not find key")
+		b.Logger.WithField("policyID", policyID.String()).Error(err)
+	}
+	return p, err
+}
+
+// err always nil
+func (b *MemStorage) GetExclusionPolicies() (p []ExclusionPolicy, err error) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	for _, val := range b.Exclusions {
+		p = append(p, val)
+	}
+	return p, err
+}
+
+func quarantineKey(xname string, target string) string {
+	return xname + "/" + target
+}
+
+// err always nil
+func (b *MemStorage) GetQuarantineEntries() (q []QuarantineEntry, err error) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	for _, val := range b.Quarantine {
+		q = append(q, val)
+	}
+	return q, err
+}
+
+func (b *MemStorage) GetQuarantineEntry(xname string, target string) (q QuarantineEntry, err error) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	if q, ok := b.Quarantine[quarantineKey(xname, target)]; ok {
+		return q, nil
+	}
+	err = errors.New("could not find key")
+	return q, err
+}
+
+// err is always nil
+func (b *MemStorage) StoreQuarantineEntry(q QuarantineEntry) (err error) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	b.Quarantine[quarantineKey(q.Xname, q.Target)] = q
+	return err
+}
+
+func (b *MemStorage) DeleteQuarantineEntry(xname string, target string) (err error) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	if _, ok := b.Quarantine[quarantineKey(xname, target)]; ok {
+		delete(b.Quarantine, quarantineKey(xname, target))
+	} else {
+		err = errors.New("could not find key")
+		b.Logger.WithFields(logrus.Fields{"xname": xname, "target": target}).Error(err)
+	}
+	return err
+}
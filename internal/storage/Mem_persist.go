@@ -0,0 +1,236 @@
+/*
+ * MIT License
+ *
+ * (C) Copyright [2026] Hewlett Packard Enterprise Development LP
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a
+ * copy of this software and associated documentation files (the "Software"),
+ * to deal in the Software without restriction, including without limitation
+ * the rights to use, copy, modify, merge, publish, distribute, sublicense,
+ * and/or sell copies of the Software, and to permit persons to whom the
+ * Software is furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included
+ * in all copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL
+ * THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+ * OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+ * ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+ * OTHER DEALINGS IN THE SOFTWARE.
+ */
+
+package storage
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	memPersistIntervalDefault = 60 * time.Second
+	memPersistFormatVersion   = 1
+)
+
+// memStorageFile - the on disk layout; everything goes through the same
+// Storable conversions the ETCD provider uses.
+type memStorageFile struct {
+	FormatVersion int                            `json:"formatVersion"`
+	SaveTime      time.Time                      `json:"saveTime"`
+	Actions       []ActionStorable               `json:"actions"`
+	Operations    []OperationStorable            `json:"operations"`
+	Histories     map[uuid.UUID][]OperationEvent `json:"operationHistories,omitempty"`
+	Images        []Image                        `json:"images"`
+	Snapshots     []SnapshotStorable             `json:"snapshots"`
+	Rules         []CompatibilityRule            `json:"compatibilityRules,omitempty"`
+	Quarantine    []QuarantineEntry              `json:"quarantine,omitempty"`
+	Exclusions    []ExclusionPolicy              `json:"exclusionPolicies,omitempty"`
+}
+
+func (b *MemStorage) initPersistence() (err error) {
+	if b.PersistPath == "" {
+		b.PersistPath = os.Getenv("STORAGE_PERSIST_PATH")
+	}
+	if b.PersistPath == "" {
+		return nil
+	}
+	if b.PersistInterval == 0 {
+		b.PersistInterval = memPersistIntervalDefault
+		if envstr := os.Getenv("STORAGE_PERSIST_INTERVAL_SECONDS"); envstr != "" {
+			seconds, err := strconv.Atoi(envstr)
+			if err != nil || seconds <= 0 {
+				return fmt.Errorf("invalid STORAGE_PERSIST_INTERVAL_SECONDS: %s", envstr)
+			}
+			b.PersistInterval = time.Duration(seconds) * time.Second
+		}
+	}
+
+	err = b.load()
+	if err != nil {
+		return err
+	}
+
+	b.stopPersist = make(chan struct{})
+	b.persistDone = make(chan struct{})
+	go b.persistLoop(b.stopPersist, b.persistDone)
+	b.Logger.WithField("path", b.PersistPath).Info("Memory storage persistence enabled")
+	return nil
+}
+
+func (b *MemStorage) persistLoop(stop chan struct{}, done chan struct{}) {
+	defer close(done)
+	ticker := time.NewTicker(b.PersistInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-stop:
+			return
+		case <-ticker.C:
+			if err := b.Persist(); err != nil {
+				b.Logger.WithField("ERROR", err).Error("Could not persist memory storage")
+			}
+		}
+	}
+}
+
+// load - a missing file is an empty store, anything unreadable is an error
+// so that a bad file is never silently replaced by an empty one.
+func (b *MemStorage) load() (err error) {
+	data, err := os.ReadFile(b.PersistPath)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	} else if err != nil {
+		return err
+	}
+	var file memStorageFile
+	if err = json.Unmarshal(data, &file); err != nil {
+		return fmt.Errorf("could not read %s: %w", b.PersistPath, err)
+	}
+	if file.FormatVersion != memPersistFormatVersion {
+		return fmt.Errorf("unsupported format version %d in %s", file.FormatVersion, b.PersistPath)
+	}
+
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	for _, a := range file.Actions {
+		action := ToActionFromStorable(a, a.ActionID)
+		b.Actions[action.ActionID] = action
+	}
+	for _, o := range file.Operations {
+		operation := ToOperationFromStorable(o)
+		b.Operations[operation.OperationID] = operation
+	}
+	for id, h := range file.Histories {
+		b.Histories[id] = h
+	}
+	for _, i := range file.Images {
+		b.Images[i.ImageID] = i
+	}
+	for _, s := range file.Snapshots {
+		snapshot := ToSnapshotFromStorable(s)
+		b.Snapshots[snapshot.Name] = snapshot
+	}
+	for _, r := range file.Rules {
+		b.Rules[r.RuleID] = r
+	}
+	for _, q := range file.Quarantine {
+		b.Quarantine[quarantineKey(q.Xname, q.Target)] = q
+	}
+	for _, p := range file.Exclusions {
+		b.Exclusions[p.PolicyID] = p
+	}
+	b.Logger.WithFields(logrus.Fields{"actions": len(b.Actions), "operations": len(b.Operations),
+		"images": len(b.Images), "snapshots": len(b.Snapshots), "compatibilityRules": len(b.Rules)}).Info("Loaded memory storage from file")
+	return nil
+}
+
+// Persist - writes the store to PersistPath; the file is replaced atomically
+// so a crash mid write leaves the previous copy intact.
+func (b *MemStorage) Persist() (err error) {
+	if b.PersistPath == "" {
+		return nil
+	}
+	b.persistMutex.Lock()
+	defer b.persistMutex.Unlock()
+
+	file := memStorageFile{
+		FormatVersion: memPersistFormatVersion,
+		SaveTime:      time.Now(),
+		Actions:       []ActionStorable{},
+		Operations:    []OperationStorable{},
+		Images:        []Image{},
+		Snapshots:     []SnapshotStorable{},
+	}
+	b.mutex.Lock()
+	for _, a := range b.Actions {
+		file.Actions = append(file.Actions, ToActionStorable(a))
+	}
+	for _, o := range b.Operations {
+		file.Operations = append(file.Operations, ToOperationStorable(o))
+	}
+	if len(b.Histories) > 0 {
+		file.Histories = make(map[uuid.UUID][]OperationEvent)
+		for id, h := range b.Histories {
+			file.Histories[id] = h
+		}
+	}
+	for _, i := range b.Images {
+		file.Images = append(file.Images, i)
+	}
+	for _, s := range b.Snapshots {
+		file.Snapshots = append(file.Snapshots, ToSnapshotStorableWithDevices(s))
+	}
+	for _, q := range b.Quarantine {
+		file.Quarantine = append(file.Quarantine, q)
+	}
+	for _, p := range b.Exclusions {
+		file.Exclusions = append(file.Exclusions, p)
+	}
+	for _, r := range b.Rules {
+		file.Rules = append(file.Rules, r)
+	}
+	b.mutex.Unlock()
+
+	data, err := json.Marshal(file)
+	if err != nil {
+		return err
+	}
+
+	dir := filepath.Dir(b.PersistPath)
+	tmp, err := os.CreateTemp(dir, filepath.Base(b.PersistPath)+".tmp*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name()) //no-op once renamed
+	if _, err = tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err = tmp.Sync(); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err = tmp.Close(); err != nil {
+		return err
+	}
+	if err = os.Rename(tmp.Name(), b.PersistPath); err != nil {
+		return err
+	}
+	// make the rename itself durable
+	if d, err := os.Open(dir); err == nil {
+		d.Sync()
+		d.Close()
+	}
+	b.Logger.WithField("path", b.PersistPath).Debug("Persisted memory storage")
+	return nil
+}
@@ -0,0 +1,101 @@
+/*
+ * MIT License
+ *
+ * (C) Copyright [2026] Hewlett Packard Enterprise Development LP
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a
+ * copy of this software and associated documentation files (the "Software"),
+ * to deal in the Software without restriction, including without limitation
+ * the rights to use, copy, modify, merge, publish, distribute, sublicense,
+ * and/or sell copies of the Software, and to permit persons to whom the
+ * Software is furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included
+ * in all copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL
+ * THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+ * OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+ * ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+ * OTHER DEALINGS IN THE SOFTWARE.
+ */
+
+package storage
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/suite"
+)
+
+type Mem_Persist_TS struct {
+	suite.Suite
+}
+
+func (suite *Mem_Persist_TS) Test_Persist_RoundTrip() {
+	path := filepath.Join(suite.T().TempDir(), "fas.json")
+	mem := &MemStorage{PersistPath: path}
+	suite.True(mem.Init(logrus.New()) == nil)
+
+	a := HelperGetStockAction()
+	o := HelperGetStockOperation()
+	o.ActionID = a.ActionID
+	a.OperationIDs = append(a.OperationIDs, o.OperationID)
+	i := HelperGetStockImage()
+	s := HelperGetFilledSnapshot(3)
+	suite.True(mem.StoreAction(a) == nil)
+	suite.True(mem.StoreOperation(o) == nil)
+	suite.True(mem.AppendOperationHistory(o.OperationID, []OperationEvent{{State: "configured"}}) == nil)
+	suite.True(mem.StoreImage(i) == nil)
+	suite.True(mem.StoreSnapshot(s) == nil)
+	o, _ = mem.GetOperation(o.OperationID)
+	suite.True(mem.Close() == nil)
+
+	reloaded := &MemStorage{PersistPath: path}
+	suite.True(reloaded.Init(logrus.New()) == nil)
+	defer reloaded.Close()
+
+	aRet, err := reloaded.GetAction(a.ActionID)
+	suite.True(err == nil)
+	suite.True(a.Equals(aRet))
+	oRet, err := reloaded.GetOperation(o.OperationID)
+	suite.True(err == nil)
+	suite.True(o.Equals(oRet))
+	hRet, err := reloaded.GetOperationHistory(o.OperationID)
+	suite.True(err == nil)
+	suite.Equal(1, len(hRet))
+	iRet, err := reloaded.GetImage(i.ImageID)
+	suite.True(err == nil)
+	suite.True(i.Equals(iRet))
+	sRet, err := reloaded.GetSnapshot(s.Name)
+	suite.True(err == nil)
+	suite.True(s.Equals(sRet))
+
+	// nothing but the store itself is left in the directory
+	entries, _ := os.ReadDir(filepath.Dir(path))
+	suite.Equal(1, len(entries))
+}
+
+func (suite *Mem_Persist_TS) Test_Persist_MissingFile() {
+	mem := &MemStorage{PersistPath: filepath.Join(suite.T().TempDir(), "missing.json")}
+	suite.True(mem.Init(logrus.New()) == nil)
+	a, _ := mem.GetActions()
+	suite.Equal(0, len(a))
+	suite.True(mem.Close() == nil)
+}
+
+func (suite *Mem_Persist_TS) Test_Persist_CorruptFile() {
+	path := filepath.Join(suite.T().TempDir(), "fas.json")
+	suite.True(os.WriteFile(path, []byte("{not json"), 0644) == nil)
+	mem := &MemStorage{PersistPath: path}
+	suite.False(mem.Init(logrus.New()) == nil)
+}
+
+func Test_Storage_Mem_Persist(t *testing.T) {
+	suite.Run(t, new(Mem_Persist_TS))
+}
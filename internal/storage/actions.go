@@ -28,6 +28,7 @@ import (
 	"context"
 	"database/sql"
 	"errors"
+	"strings"
 	"time"
 
 	rf "github.com/Cray-HPE/hms-smd/v2/pkg/redfish"
@@ -44,33 +45,43 @@ type ActionID struct {
 }
 
 type Action struct {
-	ActionID     uuid.UUID        `json:"id"`
-	SnapshotID   uuid.UUID        `json:"snapshotID,omitempty"`
-	Command      Command          `json:"command"`
-	StartTime    sql.NullTime     `json:"startTime"`
-	EndTime      sql.NullTime     `json:"endTime"`
-	State        *fsm.FSM         `json:"state"`
-	RefreshTime  sql.NullTime     `json:"refreshTime"`
-	Parameters   ActionParameters `json:"parameters"`
-	OperationIDs []uuid.UUID      `json:"operationIDs"`
-	BlockedBy    []uuid.UUID      `json:"blockedBy"`
-	Errors       []string         `json:"errors"`
+	ActionID       uuid.UUID        `json:"id"`
+	SnapshotID     uuid.UUID        `json:"snapshotID,omitempty"`
+	Command        Command          `json:"command"`
+	StartTime      sql.NullTime     `json:"startTime"`
+	EndTime        sql.NullTime     `json:"endTime"`
+	State          *fsm.FSM         `json:"state"`
+	RefreshTime    sql.NullTime     `json:"refreshTime"`
+	Parameters     ActionParameters `json:"parameters"`
+	OperationIDs   []uuid.UUID      `json:"operationIDs"`
+	BlockedBy      []uuid.UUID      `json:"blockedBy"`
+	Errors         []string         `json:"errors"`
+	RestoredTime   sql.NullTime     `json:"restoredTime"`             //set when rehydrated from the archive
+	ParentActionID uuid.UUID        `json:"parentActionID,omitempty"` //set when this action retries another one
+	RetryActionIDs []uuid.UUID      `json:"retryActionIDs,omitempty"` //actions created to retry this one
+	PauseReason    string           `json:"pauseReason,omitempty"`
+	Activation     *Activation      `json:"activation,omitempty"` //set once the staged images are being activated
 	//Todo, need to add something like {xname, target} array; but not sure what targets we filter on; do we do it by
 	// images then? WHY? so we can easily tell what we are locking
 }
 
 type ActionStorable struct {
-	ActionID     uuid.UUID
-	SnapshotID   uuid.UUID
-	Command      Command
-	StartTime    sql.NullTime     `json:"startTime"`
-	EndTime      sql.NullTime     `json:"endTime"`
-	State        string           `json:"state"`
-	RefreshTime  sql.NullTime     `json:"refreshTime"`
-	Parameters   ActionParameters `json:"parameters"`
-	OperationIDs []uuid.UUID      `json:"operationIDs"`
-	BlockedBy    []uuid.UUID      `json:"blockedBy"`
-	Errors       []string         `json:"errors"`
+	ActionID       uuid.UUID
+	SnapshotID     uuid.UUID
+	Command        Command
+	StartTime      sql.NullTime     `json:"startTime"`
+	EndTime        sql.NullTime     `json:"endTime"`
+	State          string           `json:"state"`
+	RefreshTime    sql.NullTime     `json:"refreshTime"`
+	Parameters     ActionParameters `json:"parameters"`
+	OperationIDs   []uuid.UUID      `json:"operationIDs"`
+	BlockedBy      []uuid.UUID      `json:"blockedBy"`
+	Errors         []string         `json:"errors"`
+	RestoredTime   sql.NullTime     `json:"restoredTime"`
+	ParentActionID uuid.UUID        `json:"parentActionID,omitempty"`
+	RetryActionIDs []uuid.UUID      `json:"retryActionIDs,omitempty"`
+	PauseReason    string           `json:"pauseReason,omitempty"`
+	Activation     *Activation      `json:"activation,omitempty"`
 }
 
 type ActionStorableID struct {
@@ -79,17 +90,22 @@ type ActionStorableID struct {
 
 func ToActionStorable(from Action) (to ActionStorable) {
 	to = ActionStorable{
-		ActionID:     from.ActionID,
-		SnapshotID:   from.SnapshotID,
-		Command:      from.Command,
-		StartTime:    from.StartTime,
-		EndTime:      from.EndTime,
-		State:        from.State.Current(),
-		RefreshTime:  from.RefreshTime,
-		Parameters:   from.Parameters,
-		OperationIDs: from.OperationIDs,
-		BlockedBy:    from.BlockedBy,
-		Errors:       from.Errors,
+		ActionID:       from.ActionID,
+		SnapshotID:     from.SnapshotID,
+		Command:        from.Command,
+		StartTime:      from.StartTime,
+		EndTime:        from.EndTime,
+		State:          from.State.Current(),
+		RefreshTime:    from.RefreshTime,
+		Parameters:     from.Parameters,
+		OperationIDs:   from.OperationIDs,
+		BlockedBy:      from.BlockedBy,
+		Errors:         from.Errors,
+		RestoredTime:   from.RestoredTime,
+		ParentActionID: from.ParentActionID,
+		RetryActionIDs: from.RetryActionIDs,
+		PauseReason:    from.PauseReason,
+		Activation:     from.Activation,
 	}
 	return
 }
@@ -98,16 +114,21 @@ func ToActionStorable(from Action) (to ActionStorable) {
 // id will overwrite ActionID if ActionID is Nil
 func ToActionFromStorable(from ActionStorable, id uuid.UUID) (to Action) {
 	to = Action{
-		ActionID:     from.ActionID,
-		SnapshotID:   from.SnapshotID,
-		Command:      from.Command,
-		StartTime:    from.StartTime,
-		EndTime:      from.EndTime,
-		RefreshTime:  from.RefreshTime,
-		Parameters:   from.Parameters,
-		OperationIDs: from.OperationIDs,
-		BlockedBy:    from.BlockedBy,
-		Errors:       from.Errors,
+		ActionID:       from.ActionID,
+		SnapshotID:     from.SnapshotID,
+		Command:        from.Command,
+		StartTime:      from.StartTime,
+		EndTime:        from.EndTime,
+		RefreshTime:    from.RefreshTime,
+		Parameters:     from.Parameters,
+		OperationIDs:   from.OperationIDs,
+		BlockedBy:      from.BlockedBy,
+		Errors:         from.Errors,
+		RestoredTime:   from.RestoredTime,
+		ParentActionID: from.ParentActionID,
+		RetryActionIDs: from.RetryActionIDs,
+		PauseReason:    from.PauseReason,
+		Activation:     from.Activation,
 	}
 	if to.ActionID == uuid.Nil {
 		to.ActionID = id
@@ -120,8 +141,11 @@ func ToActionFromStorable(from ActionStorable, id uuid.UUID) (to Action) {
 			{Name: "block", Src: []string{"configured"}, Dst: "blocked"},
 			{Name: "unblock", Src: []string{"blocked"}, Dst: "configured"},
 			{Name: "start", Src: []string{"configured"}, Dst: "running"},
-			{Name: "finish", Src: []string{"new", "configured", "running"}, Dst: "completed"},
-			{Name: "signalAbort", Src: []string{"running", "configured", "new", "blocked"}, Dst: "abortSignaled"},
+			{Name: "finish", Src: []string{"new", "configured", "running", "paused"}, Dst: "completed"},
+			{Name: "activate", Src: []string{"completed"}, Dst: "configured"}, //the staged images get reset in, which runs the action again
+			{Name: "pause", Src: []string{"running"}, Dst: "paused"},          //nothing new is launched, in flight operations finish
+			{Name: "resume", Src: []string{"paused"}, Dst: "running"},
+			{Name: "signalAbort", Src: []string{"running", "configured", "new", "blocked", "paused"}, Dst: "abortSignaled"},
 			{Name: "abort", Src: []string{"abortSignaled"}, Dst: "aborted"},
 		},
 		fsm.Callbacks{
@@ -141,7 +165,7 @@ func (d *Action) enterState(e *fsm.Event) {
 }
 
 func (op *Action) restoreState(state string) (err error) {
-	allowedState := []string{"new", "running", "completed", "blocked", "configured", "abortSignaled", "aborted", "running"}
+	allowedState := []string{"new", "running", "completed", "blocked", "configured", "abortSignaled", "aborted", "running", "paused"}
 	for _, val := range allowedState {
 		if val == state {
 			op.State.SetState(state)
@@ -169,8 +193,11 @@ func NewAction(params ActionParameters) *Action {
 			{Name: "block", Src: []string{"configured"}, Dst: "blocked"},
 			{Name: "unblock", Src: []string{"blocked"}, Dst: "configured"},
 			{Name: "start", Src: []string{"configured"}, Dst: "running"},
-			{Name: "finish", Src: []string{"new", "configured", "running"}, Dst: "completed"},
-			{Name: "signalAbort", Src: []string{"running", "configured", "new", "blocked"}, Dst: "abortSignaled"},
+			{Name: "finish", Src: []string{"new", "configured", "running", "paused"}, Dst: "completed"},
+			{Name: "activate", Src: []string{"completed"}, Dst: "configured"}, //the staged images get reset in, which runs the action again
+			{Name: "pause", Src: []string{"running"}, Dst: "paused"},          //nothing new is launched, in flight operations finish
+			{Name: "resume", Src: []string{"paused"}, Dst: "running"},
+			{Name: "signalAbort", Src: []string{"running", "configured", "new", "blocked", "paused"}, Dst: "abortSignaled"},
 			{Name: "abort", Src: []string{"abortSignaled"}, Dst: "aborted"},
 		},
 		fsm.Callbacks{
@@ -183,10 +210,11 @@ func NewAction(params ActionParameters) *Action {
 func (d *Operation) enterState(e *fsm.Event) {
 	logrus.WithFields(logrus.Fields{"operationID": d.OperationID, "event": e.Event, "destination": e.Dst}).Trace("transition")
 	d.RefreshTime.Scan(time.Now())
+	notePending(e.FSM, OperationEvent{Time: time.Now(), Event: e.Event, State: e.Dst})
 }
 
 func (op *Operation) restoreState(state string) (err error) {
-	allowedState := []string{"initial", "configured", "needsVerified", "verifying", "aborted", "succeeded", "failed", "inProgress", "blocked", "noOperation", "noSolution"}
+	allowedState := []string{"initial", "configured", "needsVerified", "verifying", "aborted", "succeeded", "failed", "inProgress", "blocked", "noOperation", "noSolution", "staged"}
 	for _, val := range allowedState {
 		if val == state {
 			op.State.SetState(state)
@@ -216,12 +244,15 @@ func NewOperation() *Operation {
 			{Name: "restart", Src: []string{"inProgress"}, Dst: "inProgress"},       //FAS is actively performing this op but hasnt sent the command -> it is trying again, b/c the function died
 
 			{Name: "needsVerify", Src: []string{"inProgress"}, Dst: "needsVerified"}, //FAS has launched the op, but need s to make sure it worked
+			{Name: "stage", Src: []string{"inProgress"}, Dst: "staged"},              //the image is on the device, it takes effect on the next reset
+			{Name: "activate", Src: []string{"staged"}, Dst: "needsVerified"},        //FAS resets the device to take the staged image, then verifies it
 			{Name: "verifying", Src: []string{"needsVerified"}, Dst: "verifying"},    //FAS has launched the op, but need s to make sure it worked
 			{Name: "reverifying", Src: []string{"verifying"}, Dst: "verifying"},      //FAS has launched the op, but need s to make sure it worked -> trying it again, function died.
 
-			{Name: "abort", Src: []string{"configured", "initial", "inProgress", "needsVerified", "verifying", "blocked"}, Dst: "aborted"},
+			{Name: "abort", Src: []string{"configured", "initial", "inProgress", "needsVerified", "verifying", "blocked", "staged"}, Dst: "aborted"},
+			{Name: "skip", Src: []string{"configured", "initial", "blocked"}, Dst: "noOperation"},                             //an admin asked FAS to leave it alone before it was launched
 			{Name: "noop", Src: []string{"initial"}, Dst: "noOperation"},                                                      //the versions are equal, nothing to do
-			{Name: "nosol", Src: []string{"configured", "initial", "inProgress"}, Dst: "noSolution"},                          //cant find the  version or its disqualified
+			{Name: "nosol", Src: []string{"configured", "initial", "inProgress", "blocked"}, Dst: "noSolution"},               //cant find the  version or its disqualified
 			{Name: "success", Src: []string{"inProgress", "verifying"}, Dst: "succeeded"},                                     // it worked
 			{Name: "fail", Src: []string{"initial", "configured", "inProgress", "verifying", "needsVerified"}, Dst: "failed"}, // it failed
 		},
@@ -233,57 +264,81 @@ func NewOperation() *Operation {
 }
 
 type Operation struct {
-	OperationID            uuid.UUID    `json:"operationID"`
-	ActionID               uuid.UUID    `json:"actionID"`
-	AutomaticallyGenerated bool         `json:"automaticallyGenerated,omitempty"`
-	StartTime              sql.NullTime `json:"timeStart"`
-	EndTime                sql.NullTime `json:"timeEnd"`
-	ExpirationTime         sql.NullTime `json:"expirationTime"`
-	RefreshTime            sql.NullTime `json:"refreshTime"` //when the record was last updated
-	StateHelper            string       `json:"stateHelper"`
-	State                  *fsm.FSM     `json:"state"`
-	Error                  error        `json:"error"`
-	Xname                  string       `json:"xname"`
-	DeviceType             string       `json:"deviceType"`
-	Target                 string       `json:"target"`
-	TargetName             string       `json:"targetName"`
-	Manufacturer           string       `json:"manufacturer"`
-	Model                  string       `json:"model"`
-	SoftwareId             string       `json:"softwareId"`
-	FromFirmwareVersion    string       `json:"fromFirmwareVersion"` //mVersionCurrent
-	FromImageID            uuid.UUID    `json:"fromImageID"`
-	ToImageID              uuid.UUID    `json:"toImageID"`
-	HsmData                hsm.HsmData  `json:"hsmData"`
-	BlockedBy              []uuid.UUID  `json:"blockedBy"`
-	TaskLink               string       `json:"taskLink"`
-	UpdateInfoLink         string       `json:"updateInfoLink"`
+	OperationID            uuid.UUID          `json:"operationID"`
+	ActionID               uuid.UUID          `json:"actionID"`
+	AutomaticallyGenerated bool               `json:"automaticallyGenerated,omitempty"`
+	StartTime              sql.NullTime       `json:"timeStart"`
+	EndTime                sql.NullTime       `json:"timeEnd"`
+	ExpirationTime         sql.NullTime       `json:"expirationTime"`
+	RefreshTime            sql.NullTime       `json:"refreshTime"` //when the record was last updated
+	StateHelper            string             `json:"stateHelper"`
+	State                  *fsm.FSM           `json:"state"`
+	Error                  error              `json:"error"`
+	Xname                  string             `json:"xname"`
+	DeviceType             string             `json:"deviceType"`
+	Target                 string             `json:"target"`
+	TargetName             string             `json:"targetName"`
+	Manufacturer           string             `json:"manufacturer"`
+	Model                  string             `json:"model"`
+	SoftwareId             string             `json:"softwareId"`
+	FromFirmwareVersion    string             `json:"fromFirmwareVersion"` //mVersionCurrent
+	FromImageID            uuid.UUID          `json:"fromImageID"`
+	ToImageID              uuid.UUID          `json:"toImageID"`
+	HsmData                hsm.HsmData        `json:"hsmData"`
+	BlockedBy              []uuid.UUID        `json:"blockedBy"`
+	TaskLink               string             `json:"taskLink"`
+	UpdateInfoLink         string             `json:"updateInfoLink"`
+	Attempts               []OperationAttempt `json:"attempts,omitempty"`
+	HookResults            []HookResult       `json:"hookResults,omitempty"`
+	BMCMessages            []BMCMessage       `json:"bmcMessages,omitempty"`
+	FailureCode            string             `json:"failureCode,omitempty"` //why it ended failed, noSolution or noOperation
+	Signal                 string             `json:"signal,omitempty"`      //abort or skip requested through the API
+	DependsOn              []uuid.UUID        `json:"dependsOn,omitempty"`   //the BlockedBy entries that must succeed, from image prerequisites
+	UpgradePath            []uuid.UUID        `json:"upgradePath,omitempty"` //every operation of a multi-hop update, in the order they run
+	ResetType              string             `json:"resetType,omitempty"`   //set on activation; the reset that applies the staged image
+	ResetBy                uuid.UUID          `json:"resetBy"`               //set on activation; the operation whose reset of the device applies this staged image too
 }
 
+// Signals an admin can send to a single operation; the control loop acts on them
+const (
+	OperationSignalAbort = "abort"
+	OperationSignalSkip  = "skip"
+)
+
 type OperationStorable struct {
-	OperationID            uuid.UUID       `json:"operationID"`
-	ActionID               uuid.UUID       `json:"actionID"`
-	AutomaticallyGenerated bool            `json:"automaticallyGenerated,omitempty"`
-	StartTime              sql.NullTime    `json:"startTime"`
-	EndTime                sql.NullTime    `json:"endTime"`
-	ExpirationTime         sql.NullTime    `json:"expirationTime"`
-	RefreshTime            sql.NullTime    `json:"refreshTime"` //when the record was last updated
-	StateHelper            string          `json:"stateHelper"`
-	State                  string          `json:"state"`
-	Error                  string          `json:"error"`
-	Xname                  string          `json:"xname"`
-	DeviceType             string          `json:"deviceType"`
-	Target                 string          `json:"target"`
-	TargetName             string          `json:"targetName"`
-	Manufacturer           string          `json:"manufacturer"`
-	Model                  string          `json:"model"`
-	SoftwareId             string          `json:"softwareId"`
-	FromFirmwareVersion    string          `json:"fromFirmwareVersion"` //mVersionCurrent
-	FromImageID            uuid.UUID       `json:"fromImageID"`
-	ToImageID              uuid.UUID       `json:"toImageID"`
-	HsmData                HsmDataStorable `json:"hsmData"`
-	BlockedBy              []uuid.UUID     `json:"blockedBy"`
-	TaskLink               string          `json:"taskLink"`
-	UpdateInfoLink         string          `json:"updateInfoLink"`
+	OperationID            uuid.UUID          `json:"operationID"`
+	ActionID               uuid.UUID          `json:"actionID"`
+	AutomaticallyGenerated bool               `json:"automaticallyGenerated,omitempty"`
+	StartTime              sql.NullTime       `json:"startTime"`
+	EndTime                sql.NullTime       `json:"endTime"`
+	ExpirationTime         sql.NullTime       `json:"expirationTime"`
+	RefreshTime            sql.NullTime       `json:"refreshTime"` //when the record was last updated
+	StateHelper            string             `json:"stateHelper"`
+	State                  string             `json:"state"`
+	Error                  string             `json:"error"`
+	Xname                  string             `json:"xname"`
+	DeviceType             string             `json:"deviceType"`
+	Target                 string             `json:"target"`
+	TargetName             string             `json:"targetName"`
+	Manufacturer           string             `json:"manufacturer"`
+	Model                  string             `json:"model"`
+	SoftwareId             string             `json:"softwareId"`
+	FromFirmwareVersion    string             `json:"fromFirmwareVersion"` //mVersionCurrent
+	FromImageID            uuid.UUID          `json:"fromImageID"`
+	ToImageID              uuid.UUID          `json:"toImageID"`
+	HsmData                HsmDataStorable    `json:"hsmData"`
+	BlockedBy              []uuid.UUID        `json:"blockedBy"`
+	TaskLink               string             `json:"taskLink"`
+	UpdateInfoLink         string             `json:"updateInfoLink"`
+	Attempts               []OperationAttempt `json:"attempts,omitempty"`
+	HookResults            []HookResult       `json:"hookResults,omitempty"`
+	BMCMessages            []BMCMessage       `json:"bmcMessages,omitempty"`
+	FailureCode            string             `json:"failureCode,omitempty"` //why it ended failed, noSolution or noOperation
+	Signal                 string             `json:"signal,omitempty"`      //abort or skip requested through the API
+	DependsOn              []uuid.UUID        `json:"dependsOn,omitempty"`   //the BlockedBy entries that must succeed, from image prerequisites
+	UpgradePath            []uuid.UUID        `json:"upgradePath,omitempty"` //every operation of a multi-hop update, in the order they run
+	ResetType              string             `json:"resetType,omitempty"`   //set on activation; the reset that applies the staged image
+	ResetBy                uuid.UUID          `json:"resetBy"`               //set on activation; the operation whose reset of the device applies this staged image too
 }
 
 func ToOperationStorable(from Operation) (to OperationStorable) {
@@ -311,6 +366,15 @@ func ToOperationStorable(from Operation) (to OperationStorable) {
 		BlockedBy:              from.BlockedBy,
 		TaskLink:               from.TaskLink,
 		UpdateInfoLink:         from.UpdateInfoLink,
+		Attempts:               from.Attempts,
+		HookResults:            from.HookResults,
+		BMCMessages:            from.BMCMessages,
+		FailureCode:            from.FailureCode,
+		Signal:                 from.Signal,
+		DependsOn:              from.DependsOn,
+		UpgradePath:            from.UpgradePath,
+		ResetType:              from.ResetType,
+		ResetBy:                from.ResetBy,
 	}
 	if from.Error != nil {
 		to.Error = from.Error.Error()
@@ -343,6 +407,15 @@ func ToOperationFromStorable(from OperationStorable) (to Operation) {
 		BlockedBy:              from.BlockedBy,
 		TaskLink:               from.TaskLink,
 		UpdateInfoLink:         from.UpdateInfoLink,
+		Attempts:               from.Attempts,
+		HookResults:            from.HookResults,
+		BMCMessages:            from.BMCMessages,
+		FailureCode:            from.FailureCode,
+		Signal:                 from.Signal,
+		DependsOn:              from.DependsOn,
+		UpgradePath:            from.UpgradePath,
+		ResetType:              from.ResetType,
+		ResetBy:                from.ResetBy,
 	}
 	if from.Error != "" {
 		to.Error = errors.New(from.Error)
@@ -357,11 +430,14 @@ func ToOperationFromStorable(from OperationStorable) (to Operation) {
 			{Name: "start", Src: []string{"configured"}, Dst: "inProgress"},          //FAS is actively performing this op but hasnt sent the command
 			{Name: "restart", Src: []string{"inProgress"}, Dst: "inProgress"},        //FAS is actively performing this op but hasnt sent the command -> it is trying again, b/c the function died
 			{Name: "needsVerify", Src: []string{"inProgress"}, Dst: "needsVerified"}, //FAS has launched the op, but need s to make sure it worked
+			{Name: "stage", Src: []string{"inProgress"}, Dst: "staged"},              //the image is on the device, it takes effect on the next reset
+			{Name: "activate", Src: []string{"staged"}, Dst: "needsVerified"},        //FAS resets the device to take the staged image, then verifies it
 			{Name: "verifying", Src: []string{"needsVerified"}, Dst: "verifying"},    //FAS has launched the op, but need s to make sure it worked
 			{Name: "reverifying", Src: []string{"verifying"}, Dst: "verifying"},      //FAS has launched the op, but need s to make sure it worked -> trying it again, function died.
-			{Name: "abort", Src: []string{"configured", "initial", "inProgress", "needsVerified", "verifying", "blocked"}, Dst: "aborted"},
+			{Name: "abort", Src: []string{"configured", "initial", "inProgress", "needsVerified", "verifying", "blocked", "staged"}, Dst: "aborted"},
+			{Name: "skip", Src: []string{"configured", "initial", "blocked"}, Dst: "noOperation"},                             //an admin asked FAS to leave it alone before it was launched
 			{Name: "noop", Src: []string{"initial"}, Dst: "noOperation"},                                                      //the versions are equal, nothing to do
-			{Name: "nosol", Src: []string{"configured", "initial", "inProgress"}, Dst: "noSolution"},                          //cant find the  version or its disqualified
+			{Name: "nosol", Src: []string{"configured", "initial", "inProgress", "blocked"}, Dst: "noSolution"},               //cant find the  version or its disqualified
 			{Name: "success", Src: []string{"inProgress", "verifying"}, Dst: "succeeded"},                                     // it worked
 			{Name: "fail", Src: []string{"initial", "configured", "inProgress", "verifying", "needsVerified"}, Dst: "failed"}, // it failed
 		},
@@ -475,6 +551,15 @@ func (obj *Action) Equals(other Action) bool {
 	} else if !(model.UUIDSliceEquals(obj.OperationIDs, other.OperationIDs)) {
 		logrus.Warn("OperationIDs not equal")
 		return false
+	} else if obj.ParentActionID != other.ParentActionID {
+		logrus.Warn("ParentActionID not equal")
+		return false
+	} else if !(model.UUIDSliceEquals(obj.RetryActionIDs, other.RetryActionIDs)) {
+		logrus.Warn("RetryActionIDs not equal")
+		return false
+	} else if (obj.Activation == nil) != (other.Activation == nil) || (obj.Activation != nil && *obj.Activation != *other.Activation) {
+		logrus.Warn("Activation not equal")
+		return false
 	}
 	return true
 }
@@ -540,6 +625,12 @@ func (obj *Operation) Equals(other Operation) bool {
 	} else if model.UUIDSliceEquals(obj.BlockedBy, other.BlockedBy) == false {
 		logrus.Warn("blockedBy not equal")
 		return false
+	} else if model.UUIDSliceEquals(obj.DependsOn, other.DependsOn) == false {
+		logrus.Warn("dependsOn not equal")
+		return false
+	} else if model.UUIDSliceEquals(obj.UpgradePath, other.UpgradePath) == false {
+		logrus.Warn("upgradePath not equal")
+		return false
 	} else if !(obj.SoftwareId == other.SoftwareId) {
 		logrus.Warn("softwareId not equal")
 		return false
@@ -549,6 +640,12 @@ func (obj *Operation) Equals(other Operation) bool {
 	} else if !(obj.UpdateInfoLink == other.UpdateInfoLink) {
 		logrus.Warn("updateInfoLink not equal")
 		return false
+	} else if obj.ResetType != other.ResetType {
+		logrus.Warn("resetType not equal")
+		return false
+	} else if obj.ResetBy != other.ResetBy {
+		logrus.Warn("resetBy not equal")
+		return false
 	}
 	return true
 }
@@ -564,6 +661,9 @@ type ActionParameters struct {
 	ImageFilter             ImageFilter             `json:"imageFilter,omitempty"`
 	TargetFilter            TargetFilter            `json:"targetFilter,omitempty"`
 	Command                 Command                 `json:"command"`
+	XnameTargetFilter       XnameTargetFilter       `json:"xnameTargetFilter,omitempty"`
+	RetryPolicy             *RetryPolicy            `json:"retryPolicy,omitempty"`    //overrides the retry policy of every image
+	LaunchPolicies          []LaunchPolicy          `json:"launchPolicies,omitempty"` //all of them must allow an operation before it is launched
 }
 
 //this may ONLY resolve to 1 imageID
@@ -593,29 +693,64 @@ type Command struct {
 	RestoreNotPossibleOverride bool `json:"restoreNotPossibleOverride"` // it is probable in many cases that there will NOT be any return
 	// image to go back to. In that case we should NOT update unless the override is set, that way we can always get back
 	OverwriteSameImage bool   `json:"overwriteSameImage"`  // If to and from version are the same, update anyways
+	AllowDowngrade     bool   `json:"allowDowngrade"`      // If the to version is older than the from version, update anyways
 	TimeLimit_Seconds  int    `json:"timeLimit,omitempty"` //IDEA IS THAT IT WILL BE SECONDS
-	Version            string `json:"version"`             //earliest, latest
-	Tag                string `json:"tag"`
-	Description        string `json:"description"` //WHY are you doing this action?
+	Version            string `json:"version"`             //earliest, latest, explicit or a semver constraint like "~2.4"
+	Tag                string `json:"tag"`                 //comma separated, in order of preference
+	ApplyTime          string `json:"applyTime,omitempty"` //@Redfish.OperationApplyTime; anything but Immediate only stages the image
+	Description        string `json:"description"`         //WHY are you doing this action?
+}
+
+// Redfish OperationApplyTime values FAS can ask for
+const (
+	ApplyTimeImmediate                = "Immediate"
+	ApplyTimeOnReset                  = "OnReset"
+	ApplyTimeAtMaintenanceWindowStart = "AtMaintenanceWindowStart"
+)
+
+// Activation -> how the staged images of an action are reset in
+type Activation struct {
+	BatchSize int    `json:"batchSize"`           //how many devices are reset and verified at the same time
+	ResetType string `json:"resetType,omitempty"` //the image forceResetType, or ForceRestart, when empty
 }
 
 func (obj *Command) Equals(other Command) bool {
 	if obj.OverrideDryrun == other.OverrideDryrun &&
 		obj.RestoreNotPossibleOverride == other.RestoreNotPossibleOverride &&
+		obj.AllowDowngrade == other.AllowDowngrade &&
 		obj.TimeLimit_Seconds == other.TimeLimit_Seconds &&
 		obj.Version == other.Version &&
+		obj.ApplyTime == other.ApplyTime &&
 		obj.Description == other.Description {
 		return true
 	}
 	return false
 }
 
+// Stages -> true when the images are only staged and a separate activation resets them in
+func (obj *Command) Stages() bool {
+	return obj.ApplyTime != "" && obj.ApplyTime != ApplyTimeImmediate
+}
+
+// TagPreference -> the tags to pick images from, most preferred first
+func (obj *Command) TagPreference() (tags []string) {
+	for _, tag := range strings.Split(obj.Tag, ",") {
+		if tag = strings.TrimSpace(tag); tag != "" {
+			tags = append(tags, tag)
+		}
+	}
+	return tags
+}
+
 func (obj *ActionParameters) Equals(other ActionParameters) bool {
 	if obj.StateComponentFilter.Equals(other.StateComponentFilter) &&
 		obj.InventoryHardwareFilter.Equals(other.InventoryHardwareFilter) &&
 		obj.TargetFilter.Equals(other.TargetFilter) &&
 		obj.ImageFilter.Equals(other.ImageFilter) &&
-		obj.Command.Equals(other.Command) {
+		obj.Command.Equals(other.Command) &&
+		obj.XnameTargetFilter.Equals(other.XnameTargetFilter) &&
+		obj.RetryPolicy.Equals(other.RetryPolicy) &&
+		LaunchPoliciesEqual(obj.LaunchPolicies, other.LaunchPolicies) {
 		return true
 	}
 	return false
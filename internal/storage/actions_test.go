@@ -25,6 +25,7 @@
 package storage
 
 import (
+	"context"
 	"testing"
 
 	"github.com/google/uuid"
@@ -52,6 +53,13 @@ func (suite *Actions_TS) Test_Action_restoreState() {
 	suite.True(a.State.Current() == "failed")
 }
 
+func (suite *Actions_TS) Test_Action_FinishPaused() {
+	a := HelperGetStockAction()
+	a.restoreState("paused")
+	suite.True(a.State.Event(context.Background(), "finish") == nil)
+	suite.True(a.State.Is("completed"))
+}
+
 func (suite *Actions_TS) Test_Action_NewAction() {
 	ap1 := ActionParameters{}
 	ap1.StateComponentFilter.Groups = HelperRandStringSlice(3)
@@ -0,0 +1,183 @@
+/*
+ * MIT License
+ *
+ * (C) Copyright [2026] Hewlett Packard Enterprise Development LP
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a
+ * copy of this software and associated documentation files (the "Software"),
+ * to deal in the Software without restriction, including without limitation
+ * the rights to use, copy, modify, merge, publish, distribute, sublicense,
+ * and/or sell copies of the Software, and to permit persons to whom the
+ * Software is furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included
+ * in all copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL
+ * THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+ * OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+ * ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+ * OTHER DEALINGS IN THE SOFTWARE.
+ */
+
+package storage
+
+import (
+	"compress/gzip"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	archiveActionsPrefix   = "actions-"
+	archiveSnapshotsPrefix = "snapshots-"
+	archiveFileSuffix      = ".jsonl.gz"
+)
+
+// FileArchive writes one gzip compressed JSON-lines file per day and kind.
+// Every record is appended as its own gzip member; gzip readers treat the
+// concatenated members as a single stream.
+type FileArchive struct {
+	Logger *logrus.Logger
+	Path   string
+	mutex  sync.Mutex
+}
+
+func (f *FileArchive) Init(Logger *logrus.Logger) (err error) {
+	f.Logger = Logger
+	if f.Path == "" {
+		path, pathExists := os.LookupEnv("ARCHIVE_PATH")
+		if !pathExists || path == "" {
+			err = fmt.Errorf("No ARCHIVE_PATH specified, can't open archive.")
+			return
+		}
+		f.Path = path
+	}
+	err = os.MkdirAll(f.Path, 0755)
+	return err
+}
+
+func (f *FileArchive) fileName(prefix string, t time.Time) string {
+	return filepath.Join(f.Path, prefix+t.UTC().Format("20060102")+archiveFileSuffix)
+}
+
+func (f *FileArchive) appendRecord(fileName string, val interface{}) (err error) {
+	data, err := json.Marshal(val)
+	if err != nil {
+		return err
+	}
+	data = append(data, '\n')
+
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	file, err := os.OpenFile(fileName, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0644)
+	if err != nil {
+		return err
+	}
+	zw := gzip.NewWriter(file)
+	if _, err = zw.Write(data); err != nil {
+		file.Close()
+		return err
+	}
+	if err = zw.Close(); err != nil {
+		file.Close()
+		return err
+	}
+	if err = file.Sync(); err != nil {
+		file.Close()
+		return err
+	}
+	return file.Close()
+}
+
+func (f *FileArchive) ArchiveAction(a ArchivedAction) (err error) {
+	return f.appendRecord(f.fileName(archiveActionsPrefix, a.ArchiveTime), a)
+}
+
+func (f *FileArchive) ArchiveSnapshot(s ArchivedSnapshot) (err error) {
+	return f.appendRecord(f.fileName(archiveSnapshotsPrefix, s.ArchiveTime), s)
+}
+
+// readActions - a truncated trailing record (e.g. from a crash mid write) ends
+// the read of that file but does not discard the records before it.
+func (f *FileArchive) readActions(fileName string) (a []ArchivedAction, err error) {
+	file, err := os.Open(fileName)
+	if err != nil {
+		return a, err
+	}
+	defer file.Close()
+	zr, err := gzip.NewReader(file)
+	if err != nil {
+		return a, err
+	}
+	defer zr.Close()
+
+	dec := json.NewDecoder(zr)
+	for {
+		var rec ArchivedAction
+		err = dec.Decode(&rec)
+		if err == io.EOF {
+			return a, nil
+		} else if err != nil {
+			f.Logger.WithFields(logrus.Fields{"ERROR": err, "file": fileName}).Error("Could not read archive record")
+			return a, nil
+		}
+		a = append(a, rec)
+	}
+}
+
+func (f *FileArchive) GetArchivedActions(filter ArchiveFilter) (a []ArchivedAction, err error) {
+	entries, err := os.ReadDir(f.Path)
+	if err != nil {
+		return a, err
+	}
+	var names []string
+	for _, entry := range entries {
+		if strings.HasPrefix(entry.Name(), archiveActionsPrefix) && strings.HasSuffix(entry.Name(), archiveFileSuffix) {
+			names = append(names, entry.Name())
+		}
+	}
+	sort.Strings(names)
+
+	var all []ArchivedAction
+	for _, name := range names {
+		recs, err := f.readActions(filepath.Join(f.Path, name))
+		if err != nil {
+			f.Logger.WithFields(logrus.Fields{"ERROR": err, "file": name}).Error("Could not open archive file")
+			continue
+		}
+		for _, rec := range recs {
+			if filter.Matches(rec) {
+				all = append(all, rec)
+			}
+		}
+	}
+	a = latestArchivedActions(all)
+	return a, nil
+}
+
+func (f *FileArchive) GetArchivedAction(actionID uuid.UUID) (a ArchivedAction, err error) {
+	recs, err := f.GetArchivedActions(ArchiveFilter{ActionID: actionID})
+	if err != nil {
+		return a, err
+	}
+	if len(recs) == 0 {
+		err = errors.New("could not find key")
+		f.Logger.WithField("actionID", actionID.String()).Error(err)
+		return a, err
+	}
+	return recs[0], nil
+}
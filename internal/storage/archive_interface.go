@@ -0,0 +1,148 @@
+/*
+ * MIT License
+ *
+ * (C) Copyright [2026] Hewlett Packard Enterprise Development LP
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a
+ * copy of this software and associated documentation files (the "Software"),
+ * to deal in the Software without restriction, including without limitation
+ * the rights to use, copy, modify, merge, publish, distribute, sublicense,
+ * and/or sell copies of the Software, and to permit persons to whom the
+ * Software is furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included
+ * in all copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL
+ * THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+ * OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+ * ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+ * OTHER DEALINGS IN THE SOFTWARE.
+ */
+
+package storage
+
+import (
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+)
+
+// archiveFormatVersion is written into every archive record so that older
+// records can still be read if the layout of the Storable types changes.
+const archiveFormatVersion = 1
+
+// ArchiveProvider is a write-mostly sink for actions and snapshots that have
+// expired out of the StorageProvider.
+type ArchiveProvider interface {
+	Init(Logger *logrus.Logger) (err error)
+
+	ArchiveAction(a ArchivedAction) (err error)
+	ArchiveSnapshot(s ArchivedSnapshot) (err error)
+
+	GetArchivedActions(f ArchiveFilter) (a []ArchivedAction, err error)
+	GetArchivedAction(actionID uuid.UUID) (a ArchivedAction, err error)
+}
+
+type ArchivedAction struct {
+	FormatVersion int                 `json:"formatVersion"`
+	ArchiveTime   time.Time           `json:"archiveTime"`
+	Action        ActionStorable      `json:"action"`
+	Operations    []OperationStorable `json:"operations"`
+}
+
+type ArchivedSnapshot struct {
+	FormatVersion int              `json:"formatVersion"`
+	ArchiveTime   time.Time        `json:"archiveTime"`
+	Snapshot      SnapshotStorable `json:"snapshot"`
+}
+
+func ToArchivedAction(a Action, o []Operation) (to ArchivedAction) {
+	to = ArchivedAction{
+		FormatVersion: archiveFormatVersion,
+		ArchiveTime:   time.Now(),
+		Action:        ToActionStorable(a),
+		Operations:    []OperationStorable{},
+	}
+	for _, op := range o {
+		to.Operations = append(to.Operations, ToOperationStorable(op))
+	}
+	return
+}
+
+func ToActionFromArchived(from ArchivedAction) (a Action, o []Operation) {
+	a = ToActionFromStorable(from.Action, from.Action.ActionID)
+	for _, op := range from.Operations {
+		o = append(o, ToOperationFromStorable(op))
+	}
+	return
+}
+
+func ToArchivedSnapshot(s Snapshot) (to ArchivedSnapshot) {
+	to = ArchivedSnapshot{
+		FormatVersion: archiveFormatVersion,
+		ArchiveTime:   time.Now(),
+		Snapshot:      ToSnapshotStorableWithDevices(s),
+	}
+	return
+}
+
+// ArchiveFilter - empty fields match everything
+type ArchiveFilter struct {
+	ActionID    uuid.UUID
+	Xname       string
+	Target      string
+	Description string    //substring match on the command description
+	EndedAfter  time.Time //the action ended at or after this time
+	EndedBefore time.Time //the action ended before this time
+}
+
+func (f *ArchiveFilter) Matches(a ArchivedAction) bool {
+	if f.ActionID != uuid.Nil && f.ActionID != a.Action.ActionID {
+		return false
+	}
+	if f.Description != "" &&
+		!strings.Contains(strings.ToLower(a.Action.Command.Description), strings.ToLower(f.Description)) {
+		return false
+	}
+	if !f.EndedAfter.IsZero() && (!a.Action.EndTime.Valid || a.Action.EndTime.Time.Before(f.EndedAfter)) {
+		return false
+	}
+	if !f.EndedBefore.IsZero() && (!a.Action.EndTime.Valid || !a.Action.EndTime.Time.Before(f.EndedBefore)) {
+		return false
+	}
+	if f.Xname == "" && f.Target == "" {
+		return true
+	}
+	for _, op := range a.Operations {
+		if f.Xname != "" && !strings.EqualFold(f.Xname, op.Xname) {
+			continue
+		}
+		if f.Target != "" && !strings.EqualFold(f.Target, op.Target) {
+			continue
+		}
+		return true
+	}
+	return false
+}
+
+// latestArchivedActions - an action can be archived more than once (e.g. after
+// it was restored); only the most recent copy of each is kept.
+func latestArchivedActions(all []ArchivedAction) (a []ArchivedAction) {
+	latest := make(map[uuid.UUID]int)
+	for _, rec := range all {
+		if idx, ok := latest[rec.Action.ActionID]; ok {
+			if rec.ArchiveTime.After(a[idx].ArchiveTime) {
+				a[idx] = rec
+			}
+			continue
+		}
+		latest[rec.Action.ActionID] = len(a)
+		a = append(a, rec)
+	}
+	return a
+}
@@ -0,0 +1,334 @@
+/*
+ * MIT License
+ *
+ * (C) Copyright [2026] Hewlett Packard Enterprise Development LP
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a
+ * copy of this software and associated documentation files (the "Software"),
+ * to deal in the Software without restriction, including without limitation
+ * the rights to use, copy, modify, merge, publish, distribute, sublicense,
+ * and/or sell copies of the Software, and to permit persons to whom the
+ * Software is furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included
+ * in all copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL
+ * THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+ * OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+ * ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+ * OTHER DEALINGS IN THE SOFTWARE.
+ */
+
+package storage
+
+import (
+	"bytes"
+	"compress/gzip"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	s3ArchivePrefix    = "fas-archive/"
+	s3DefaultRegion    = "us-east-1"
+	s3SignAlgorithm    = "AWS4-HMAC-SHA256"
+	s3SignedHeaders    = "host;x-amz-content-sha256;x-amz-date"
+	s3RequestTimeout   = 60 * time.Second
+	s3ObjectNameSuffix = ".json.gz"
+)
+
+var s3UnsafeKeyChars = regexp.MustCompile(`[^A-Za-z0-9._-]`)
+
+// S3Archive stores one gzip compressed JSON object per archived record in an
+// S3 compatible object store (path style addressing, SigV4 signed).
+type S3Archive struct {
+	Logger    *logrus.Logger
+	Endpoint  string
+	Bucket    string
+	Region    string
+	AccessKey string
+	SecretKey string
+	Client    *http.Client
+}
+
+type s3ListBucketResult struct {
+	Contents []struct {
+		Key string `xml:"Key"`
+	} `xml:"Contents"`
+	IsTruncated           bool   `xml:"IsTruncated"`
+	NextContinuationToken string `xml:"NextContinuationToken"`
+}
+
+func s3LookupEnv(val *string, key string) {
+	if *val == "" {
+		*val = os.Getenv(key)
+	}
+}
+
+func (s *S3Archive) Init(Logger *logrus.Logger) (err error) {
+	s.Logger = Logger
+	s3LookupEnv(&s.Endpoint, "ARCHIVE_S3_ENDPOINT")
+	s3LookupEnv(&s.Bucket, "ARCHIVE_S3_BUCKET")
+	s3LookupEnv(&s.Region, "ARCHIVE_S3_REGION")
+	s3LookupEnv(&s.AccessKey, "ARCHIVE_S3_ACCESS_KEY")
+	s3LookupEnv(&s.SecretKey, "ARCHIVE_S3_SECRET_KEY")
+	if s.Endpoint == "" || s.Bucket == "" {
+		err = fmt.Errorf("No ARCHIVE_S3_ENDPOINT or ARCHIVE_S3_BUCKET specified, can't open archive.")
+		return
+	}
+	if _, err = url.Parse(s.Endpoint); err != nil {
+		return
+	}
+	if s.Region == "" {
+		s.Region = s3DefaultRegion
+	}
+	if s.Client == nil {
+		s.Client = &http.Client{Timeout: s3RequestTimeout}
+	}
+	return
+}
+
+// s3URIEncode - the SigV4 flavor of URI encoding; only unreserved characters
+// are left alone.
+func s3URIEncode(s string, encodeSlash bool) string {
+	var buf strings.Builder
+	for _, b := range []byte(s) {
+		if (b >= 'A' && b <= 'Z') || (b >= 'a' && b <= 'z') || (b >= '0' && b <= '9') ||
+			b == '-' || b == '_' || b == '.' || b == '~' || (b == '/' && !encodeSlash) {
+			buf.WriteByte(b)
+		} else {
+			fmt.Fprintf(&buf, "%%%02X", b)
+		}
+	}
+	return buf.String()
+}
+
+func s3HMAC(key []byte, data string) []byte {
+	h := hmac.New(sha256.New, key)
+	h.Write([]byte(data))
+	return h.Sum(nil)
+}
+
+func s3SHA256Hex(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+func (s *S3Archive) sign(req *http.Request, payload []byte, now time.Time) {
+	amzDate := now.UTC().Format("20060102T150405Z")
+	dateStamp := now.UTC().Format("20060102")
+	payloadHash := s3SHA256Hex(payload)
+	req.Header.Set("x-amz-date", amzDate)
+	req.Header.Set("x-amz-content-sha256", payloadHash)
+	if s.AccessKey == "" {
+		return
+	}
+
+	canonicalRequest := strings.Join([]string{
+		req.Method,
+		req.URL.EscapedPath(),
+		req.URL.RawQuery,
+		"host:" + req.URL.Host + "\n" +
+			"x-amz-content-sha256:" + payloadHash + "\n" +
+			"x-amz-date:" + amzDate + "\n",
+		s3SignedHeaders,
+		payloadHash,
+	}, "\n")
+	scope := dateStamp + "/" + s.Region + "/s3/aws4_request"
+	stringToSign := strings.Join([]string{
+		s3SignAlgorithm,
+		amzDate,
+		scope,
+		s3SHA256Hex([]byte(canonicalRequest)),
+	}, "\n")
+
+	key := s3HMAC([]byte("AWS4"+s.SecretKey), dateStamp)
+	key = s3HMAC(key, s.Region)
+	key = s3HMAC(key, "s3")
+	key = s3HMAC(key, "aws4_request")
+	signature := hex.EncodeToString(s3HMAC(key, stringToSign))
+
+	req.Header.Set("Authorization", fmt.Sprintf("%s Credential=%s/%s, SignedHeaders=%s, Signature=%s",
+		s3SignAlgorithm, s.AccessKey, scope, s3SignedHeaders, signature))
+}
+
+// do - an empty key addresses the bucket itself; query must already be in canonical (sorted, SigV4 encoded) form
+// a 404 is returned with a nil error; callers decide what a missing key means
+func (s *S3Archive) do(method string, key string, query string, payload []byte) (body []byte, statusCode int, err error) {
+	u, err := url.Parse(s.Endpoint)
+	if err != nil {
+		return
+	}
+	u.Path = "/" + s.Bucket
+	u.RawPath = "/" + s3URIEncode(s.Bucket, true)
+	if key != "" {
+		u.Path += "/" + key
+		u.RawPath += "/" + s3URIEncode(key, false)
+	}
+	u.RawQuery = query
+
+	req, err := http.NewRequest(method, u.String(), bytes.NewReader(payload))
+	if err != nil {
+		return
+	}
+	if payload != nil {
+		req.Header.Set("Content-Type", "application/gzip")
+	}
+	s.sign(req, payload, time.Now())
+
+	rsp, err := s.Client.Do(req)
+	if err != nil {
+		return
+	}
+	defer rsp.Body.Close()
+	statusCode = rsp.StatusCode
+	body, err = io.ReadAll(rsp.Body)
+	if err == nil && (statusCode < 200 || statusCode > 299) && statusCode != http.StatusNotFound {
+		err = fmt.Errorf("%s %s returned status code: %d", method, u.Path, statusCode)
+	}
+	return
+}
+
+func (s *S3Archive) putObject(key string, val interface{}) (err error) {
+	data, err := json.Marshal(val)
+	if err != nil {
+		return err
+	}
+	var buf bytes.Buffer
+	zw := gzip.NewWriter(&buf)
+	if _, err = zw.Write(data); err != nil {
+		return err
+	}
+	if err = zw.Close(); err != nil {
+		return err
+	}
+	// do only lets a 404 through for the readers; a write to a missing bucket must fail
+	_, statusCode, err := s.do(http.MethodPut, key, "", buf.Bytes())
+	if err == nil && statusCode == http.StatusNotFound {
+		err = fmt.Errorf("PUT %s returned status code: %d", key, statusCode)
+	}
+	return err
+}
+
+func (s *S3Archive) getObject(key string, val interface{}) (err error) {
+	body, statusCode, err := s.do(http.MethodGet, key, "", nil)
+	if err != nil {
+		return err
+	}
+	if statusCode == http.StatusNotFound {
+		return errors.New("could not find key")
+	}
+	zr, err := gzip.NewReader(bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	defer zr.Close()
+	return json.NewDecoder(zr).Decode(val)
+}
+
+func (s *S3Archive) listObjects(prefix string) (keys []string, err error) {
+	token := ""
+	for {
+		params := map[string]string{"list-type": "2", "prefix": prefix}
+		if token != "" {
+			params["continuation-token"] = token
+		}
+		var names []string
+		for name := range params {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		var query []string
+		for _, name := range names {
+			query = append(query, s3URIEncode(name, true)+"="+s3URIEncode(params[name], true))
+		}
+
+		body, statusCode, err := s.do(http.MethodGet, "", strings.Join(query, "&"), nil)
+		if err != nil {
+			return keys, err
+		}
+		if statusCode == http.StatusNotFound {
+			return keys, fmt.Errorf("bucket %s does not exist", s.Bucket)
+		}
+		var result s3ListBucketResult
+		if err = xml.Unmarshal(body, &result); err != nil {
+			return keys, err
+		}
+		for _, c := range result.Contents {
+			keys = append(keys, c.Key)
+		}
+		if !result.IsTruncated || result.NextContinuationToken == "" {
+			return keys, nil
+		}
+		token = result.NextContinuationToken
+	}
+}
+
+func (s *S3Archive) actionKey(actionID uuid.UUID) string {
+	return s3ArchivePrefix + "actions/" + actionID.String() + s3ObjectNameSuffix
+}
+
+func (s *S3Archive) ArchiveAction(a ArchivedAction) (err error) {
+	return s.putObject(s.actionKey(a.Action.ActionID), a)
+}
+
+// ArchiveSnapshot - snapshot names are user supplied, so they are made key safe
+// and suffixed with the archive time to keep same named snapshots apart.
+func (s *S3Archive) ArchiveSnapshot(a ArchivedSnapshot) (err error) {
+	name := s3UnsafeKeyChars.ReplaceAllString(a.Snapshot.Name, "_")
+	key := s3ArchivePrefix + "snapshots/" + name + "-" + a.ArchiveTime.UTC().Format("20060102T150405Z") + s3ObjectNameSuffix
+	return s.putObject(key, a)
+}
+
+func (s *S3Archive) GetArchivedActions(filter ArchiveFilter) (a []ArchivedAction, err error) {
+	if filter.ActionID != uuid.Nil {
+		rec, err := s.GetArchivedAction(filter.ActionID)
+		if err == nil && filter.Matches(rec) {
+			a = append(a, rec)
+		}
+		return a, nil
+	}
+
+	keys, err := s.listObjects(s3ArchivePrefix + "actions/")
+	if err != nil {
+		return a, err
+	}
+	for _, key := range keys {
+		var rec ArchivedAction
+		if err := s.getObject(key, &rec); err != nil {
+			s.Logger.WithFields(logrus.Fields{"ERROR": err, "key": key}).Error("Could not read archive object")
+			continue
+		}
+		if filter.Matches(rec) {
+			a = append(a, rec)
+		}
+	}
+	return a, nil
+}
+
+func (s *S3Archive) GetArchivedAction(actionID uuid.UUID) (a ArchivedAction, err error) {
+	err = s.getObject(s.actionKey(actionID), &a)
+	if err != nil {
+		s.Logger.WithFields(logrus.Fields{"ERROR": err, "actionID": actionID.String()}).Error("Could not get archived action")
+	}
+	return a, err
+}
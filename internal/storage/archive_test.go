@@ -0,0 +1,188 @@
+/*
+ * MIT License
+ *
+ * (C) Copyright [2026] Hewlett Packard Enterprise Development LP
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a
+ * copy of this software and associated documentation files (the "Software"),
+ * to deal in the Software without restriction, including without limitation
+ * the rights to use, copy, modify, merge, publish, distribute, sublicense,
+ * and/or sell copies of the Software, and to permit persons to whom the
+ * Software is furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included
+ * in all copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL
+ * THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+ * OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+ * ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+ * OTHER DEALINGS IN THE SOFTWARE.
+ */
+
+package storage
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/suite"
+)
+
+type Archive_TS struct {
+	suite.Suite
+}
+
+func helperGetArchivableAction(xname string, description string) (a Action, o []Operation) {
+	a = HelperGetStockAction()
+	a.Command.Description = description
+	a.State.SetState("completed")
+	a.EndTime.Scan(time.Now().AddDate(0, 0, -10))
+	op := HelperGetStockOperation()
+	op.ActionID = a.ActionID
+	op.Xname = xname
+	op.Target = "BMC"
+	op.State.SetState("succeeded")
+	a.OperationIDs = append(a.OperationIDs, op.OperationID)
+	o = append(o, op)
+	return
+}
+
+func (suite *Archive_TS) runArchiveProvider(ap ArchiveProvider) {
+	a1, o1 := helperGetArchivableAction("x0c0s1b0", "first update")
+	a2, o2 := helperGetArchivableAction("x0c0s2b0", "second update")
+	suite.True(ap.ArchiveAction(ToArchivedAction(a1, o1)) == nil)
+	suite.True(ap.ArchiveAction(ToArchivedAction(a2, o2)) == nil)
+	suite.True(ap.ArchiveSnapshot(ToArchivedSnapshot(HelperGetFilledSnapshot(2))) == nil)
+
+	all, err := ap.GetArchivedActions(ArchiveFilter{})
+	suite.True(err == nil)
+	suite.Equal(2, len(all))
+
+	found, err := ap.GetArchivedActions(ArchiveFilter{Xname: "x0c0s2b0"})
+	suite.True(err == nil)
+	suite.Equal(1, len(found))
+	suite.Equal(a2.ActionID, found[0].Action.ActionID)
+
+	found, err = ap.GetArchivedActions(ArchiveFilter{Description: "FIRST"})
+	suite.True(err == nil)
+	suite.Equal(1, len(found))
+	suite.Equal(a1.ActionID, found[0].Action.ActionID)
+
+	found, err = ap.GetArchivedActions(ArchiveFilter{EndedAfter: time.Now().AddDate(0, 0, -1)})
+	suite.True(err == nil)
+	suite.Equal(0, len(found))
+
+	rec, err := ap.GetArchivedAction(a1.ActionID)
+	suite.True(err == nil)
+	action, operations := ToActionFromArchived(rec)
+	suite.True(action.Equals(a1))
+	suite.Equal(1, len(operations))
+	suite.True(operations[0].Equals(o1[0]))
+
+	_, err = ap.GetArchivedAction(uuid.New())
+	suite.False(err == nil)
+}
+
+func (suite *Archive_TS) Test_ToArchivedSnapshot() {
+	//the record is all that is left of the snapshot, so it carries the devices the provider keeps apart
+	rec := ToArchivedSnapshot(HelperGetFilledSnapshot(2))
+	suite.Equal(2, len(rec.Snapshot.Devices))
+}
+
+func (suite *Archive_TS) Test_FileArchive() {
+	ap := &FileArchive{Path: suite.T().TempDir()}
+	suite.True(ap.Init(logrus.New()) == nil)
+	suite.runArchiveProvider(ap)
+}
+
+func (suite *Archive_TS) Test_FileArchive_Rearchived() {
+	ap := &FileArchive{Path: suite.T().TempDir()}
+	suite.True(ap.Init(logrus.New()) == nil)
+	a, o := helperGetArchivableAction("x0c0s1b0", "first")
+	suite.True(ap.ArchiveAction(ToArchivedAction(a, o)) == nil)
+	a.Command.Description = "second"
+	suite.True(ap.ArchiveAction(ToArchivedAction(a, o)) == nil)
+
+	rec, err := ap.GetArchivedAction(a.ActionID)
+	suite.True(err == nil)
+	suite.Equal("second", rec.Action.Command.Description)
+}
+
+// fakeS3 - just enough of the S3 API to exercise S3Archive
+func fakeS3() *httptest.Server {
+	var mutex sync.Mutex
+	objects := make(map[string][]byte)
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mutex.Lock()
+		defer mutex.Unlock()
+		if !strings.HasPrefix(r.Header.Get("Authorization"), s3SignAlgorithm) {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		key := strings.TrimPrefix(r.URL.Path, "/bucket/")
+		switch {
+		case r.Method == http.MethodPut:
+			objects[key], _ = io.ReadAll(r.Body)
+		case r.Method == http.MethodGet && r.URL.Path == "/bucket":
+			prefix := r.URL.Query().Get("prefix")
+			io.WriteString(w, "<ListBucketResult>")
+			for k := range objects {
+				if strings.HasPrefix(k, prefix) {
+					io.WriteString(w, "<Contents><Key>"+k+"</Key></Contents>")
+				}
+			}
+			io.WriteString(w, "<IsTruncated>false</IsTruncated></ListBucketResult>")
+		case r.Method == http.MethodGet:
+			if obj, ok := objects[key]; ok {
+				w.Write(obj)
+			} else {
+				w.WriteHeader(http.StatusNotFound)
+			}
+		}
+	}))
+}
+
+func (suite *Archive_TS) Test_S3Archive() {
+	srv := fakeS3()
+	defer srv.Close()
+	ap := &S3Archive{Endpoint: srv.URL, Bucket: "bucket", AccessKey: "access", SecretKey: "secret"}
+	suite.True(ap.Init(logrus.New()) == nil)
+	suite.runArchiveProvider(ap)
+}
+
+func (suite *Archive_TS) Test_S3Archive_MissingBucket() {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer srv.Close()
+	ap := &S3Archive{Endpoint: srv.URL, Bucket: "bucket", AccessKey: "access", SecretKey: "secret"}
+	suite.True(ap.Init(logrus.New()) == nil)
+	a, o := helperGetArchivableAction("x0c0s1b0", "lost")
+	suite.False(ap.ArchiveAction(ToArchivedAction(a, o)) == nil)
+	_, err := ap.GetArchivedAction(a.ActionID)
+	suite.False(err == nil)
+}
+
+func (suite *Archive_TS) Test_S3Archive_Sign() {
+	ap := &S3Archive{Endpoint: "http://rgw:8080", Bucket: "bucket", Region: "us-east-1", AccessKey: "AKIDEXAMPLE", SecretKey: "secret"}
+	req, _ := http.NewRequest(http.MethodGet, "http://rgw:8080/bucket?list-type=2&prefix=fas-archive%2F", nil)
+	ap.sign(req, nil, time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC))
+	suite.Equal("20260102T030405Z", req.Header.Get("x-amz-date"))
+	suite.Equal("e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855", req.Header.Get("x-amz-content-sha256"))
+	suite.True(strings.HasPrefix(req.Header.Get("Authorization"),
+		"AWS4-HMAC-SHA256 Credential=AKIDEXAMPLE/20260102/us-east-1/s3/aws4_request, SignedHeaders=host;x-amz-content-sha256;x-amz-date, Signature="))
+}
+
+func Test_Storage_Archive(t *testing.T) {
+	suite.Run(t, new(Archive_TS))
+}
@@ -0,0 +1,63 @@
+/*
+ * MIT License
+ *
+ * (C) Copyright [2026] Hewlett Packard Enterprise Development LP
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a
+ * copy of this software and associated documentation files (the "Software"),
+ * to deal in the Software without restriction, including without limitation
+ * the rights to use, copy, modify, merge, publish, distribute, sublicense,
+ * and/or sell copies of the Software, and to permit persons to whom the
+ * Software is furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included
+ * in all copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL
+ * THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+ * OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+ * ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+ * OTHER DEALINGS IN THE SOFTWARE.
+ */
+
+package storage
+
+import (
+	"time"
+)
+
+// BackupFormatVersion is bumped whenever the layout of a BackupRecord, or of
+// one of the Storable types it carries, changes incompatibly.
+// Version 2 added compatibility rules, version 3 exclusion policies and quarantine entries.
+const BackupFormatVersion = 3
+
+const (
+	BackupKindHeader     = "header"
+	BackupKindImage      = "image"
+	BackupKindSnapshot   = "snapshot"
+	BackupKindAction     = "action"
+	BackupKindOperation  = "operation"
+	BackupKindRule       = "compatibilityRule"
+	BackupKindExclusion  = "exclusionPolicy"
+	BackupKindQuarantine = "quarantineEntry"
+	BackupKindTrailer    = "trailer"
+)
+
+// BackupRecord is one line of a backup stream. A stream starts with a header,
+// ends with a trailer, and has one entity record per line in between.
+type BackupRecord struct {
+	Kind          string             `json:"kind"`
+	FormatVersion int                `json:"formatVersion,omitempty"`
+	CreateTime    *time.Time         `json:"createTime,omitempty"`
+	RecordCount   int                `json:"recordCount,omitempty"` //trailer only; the number of entity records
+	Image         *Image             `json:"image,omitempty"`
+	Snapshot      *SnapshotStorable  `json:"snapshot,omitempty"`
+	Action        *ActionStorable    `json:"action,omitempty"`
+	Operation     *OperationStorable `json:"operation,omitempty"`
+	History       []OperationEvent   `json:"history,omitempty"` //operation only
+	Rule          *CompatibilityRule `json:"compatibilityRule,omitempty"`
+	Exclusion     *ExclusionPolicy   `json:"exclusionPolicy,omitempty"`
+	Quarantine    *QuarantineEntry   `json:"quarantineEntry,omitempty"`
+}
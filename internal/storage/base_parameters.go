@@ -86,3 +86,40 @@ func (obj *TargetFilter) Equals(other TargetFilter) bool {
 	}
 	return true
 }
+
+//XnameTargetFilter -> restricts an action to exact xname/target pairs; a retry action uses it so that it only touches
+// what failed in the parent action, and not every combination of those xnames and targets.
+type XnameTargetFilter struct {
+	XnameTargets []XnameTarget `json:"xnameTargets,omitempty"`
+}
+
+type XnameTarget struct {
+	Xname  string `json:"xname"`
+	Target string `json:"target"`
+}
+
+func (obj *XnameTargetFilter) Equals(other XnameTargetFilter) bool {
+	if len(obj.XnameTargets) != len(other.XnameTargets) {
+		return false
+	}
+	for _, v := range obj.XnameTargets {
+		if !other.Contains(v.Xname, v.Target) {
+			return false
+		}
+	}
+	return true
+}
+
+func (obj *XnameTargetFilter) Empty() bool {
+	return len(obj.XnameTargets) == 0
+}
+
+// Contains -> true if the exact xname/target pair is in the filter
+func (obj *XnameTargetFilter) Contains(xname string, target string) bool {
+	for _, v := range obj.XnameTargets {
+		if v.Xname == xname && v.Target == target {
+			return true
+		}
+	}
+	return false
+}
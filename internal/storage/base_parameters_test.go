@@ -105,6 +105,21 @@ func (suite *Base_Parameters_TS) Test_TargetFilter_Equals() {
 	suite.True(t2.Equals(t1))
 }
 
+func (suite *Base_Parameters_TS) Test_XnameTargetFilter() {
+	f1 := XnameTargetFilter{}
+	f2 := XnameTargetFilter{}
+	suite.True(f1.Empty())
+	suite.True(f1.Equals(f2))
+	f1.XnameTargets = []XnameTarget{{Xname: "x0c0s1b0", Target: "BMC"}, {Xname: "x0c0s2b0", Target: "BIOS"}}
+	suite.False(f1.Empty())
+	suite.False(f1.Equals(f2))
+	suite.True(f1.Contains("x0c0s1b0", "BMC"))
+	suite.False(f1.Contains("x0c0s1b0", "BIOS"))
+	f2.XnameTargets = []XnameTarget{f1.XnameTargets[1], f1.XnameTargets[0]}
+	suite.True(f1.Equals(f2))
+	suite.True(f2.Equals(f1))
+}
+
 func Test_Storage_Base_Parameters(t *testing.T) {
 	//This setups the production routs and handler
 	suite.Run(t, new(Base_Parameters_TS))
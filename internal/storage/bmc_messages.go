@@ -0,0 +1,53 @@
+/*
+ * MIT License
+ *
+ * (C) Copyright [2026] Hewlett Packard Enterprise Development LP
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a
+ * copy of this software and associated documentation files (the "Software"),
+ * to deal in the Software without restriction, including without limitation
+ * the rights to use, copy, modify, merge, publish, distribute, sublicense,
+ * and/or sell copies of the Software, and to permit persons to whom the
+ * Software is furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included
+ * in all copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL
+ * THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+ * OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+ * ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+ * OTHER DEALINGS IN THE SOFTWARE.
+ */
+
+package storage
+
+import "time"
+
+const (
+	BMCMessageSourceUpdate = "update" //@Message.ExtendedInfo of the response to the update payload
+	BMCMessageSourceTask   = "task"   //Messages of the update task
+)
+
+// BMCMessage -> a Redfish message from the BMC, resolved against the message registries FAS bundles
+type BMCMessage struct {
+	Time       time.Time `json:"time"`
+	Source     string    `json:"source"`
+	MessageID  string    `json:"messageID"`
+	Message    string    `json:"message"`
+	Severity   string    `json:"severity,omitempty"` //OK, Warning or Critical
+	Resolution string    `json:"resolution,omitempty"`
+}
+
+// MaxBMCMessages -> a BMC that rejects every retry should not fill the operation with the same messages
+const MaxBMCMessages = 50
+
+// AddBMCMessages -> keeps the newest MaxBMCMessages
+func (obj *Operation) AddBMCMessages(messages ...BMCMessage) {
+	obj.BMCMessages = append(obj.BMCMessages, messages...)
+	if len(obj.BMCMessages) > MaxBMCMessages {
+		obj.BMCMessages = obj.BMCMessages[len(obj.BMCMessages)-MaxBMCMessages:]
+	}
+}
@@ -0,0 +1,100 @@
+/*
+ * MIT License
+ *
+ * (C) Copyright [2026] Hewlett Packard Enterprise Development LP
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a
+ * copy of this software and associated documentation files (the "Software"),
+ * to deal in the Software without restriction, including without limitation
+ * the rights to use, copy, modify, merge, publish, distribute, sublicense,
+ * and/or sell copies of the Software, and to permit persons to whom the
+ * Software is furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included
+ * in all copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL
+ * THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+ * OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+ * ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+ * OTHER DEALINGS IN THE SOFTWARE.
+ */
+
+package storage
+
+import (
+	"database/sql"
+	"strings"
+
+	"github.com/Cray-HPE/hms-firmware-action/internal/model"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	CompatibilityRuleRequires = "requires" //a version of a target needs a version of another target on the same xname
+	CompatibilityRuleUniform  = "uniform"  //every xname in the same chassis (or other scope) runs the same version of a target
+
+	CompatibilityEnforcementNoSolution = "noSolution" //the operations that would break the rule are not performed
+	CompatibilityEnforcementReject     = "reject"     //the whole plan is not performed
+)
+
+type CompatibilityRuleID struct {
+	RuleID uuid.UUID `json:"ruleID"`
+}
+
+// CompatibilityRule -> a combination of firmware versions that is not supported.  Unlike image prerequisites, which
+// order the operations, a rule only says what must hold once the action is done; plans that break it are cut back or
+// rejected.
+type CompatibilityRule struct {
+	RuleID           uuid.UUID    `json:"ruleID"`
+	CreateTime       sql.NullTime `json:"createTime"`
+	Description      string       `json:"description,omitempty"`
+	Type             string       `json:"type"`
+	Enforcement      string       `json:"enforcement,omitempty"`
+	Manufacturer     string       `json:"manufacturer,omitempty"` //empty matches any
+	Models           []string     `json:"models,omitempty"`       //empty matches any
+	Target           string       `json:"target"`
+	Versions         string       `json:"versions,omitempty"`         //requires: semver constraint on Target that triggers the rule, empty for any version
+	RequiredTarget   string       `json:"requiredTarget,omitempty"`   //requires
+	RequiredVersions string       `json:"requiredVersions,omitempty"` //requires: semver constraint RequiredTarget must meet
+	Scope            string       `json:"scope,omitempty"`            //uniform: the HMS type xnames are grouped by, Chassis by default
+}
+
+func (obj *CompatibilityRule) Equals(other CompatibilityRule) bool {
+	if obj.RuleID != other.RuleID {
+		logrus.Warn("ruleID is not equal")
+		return false
+	} else if obj.CreateTime.Time.Round(0).Equal(other.CreateTime.Time.Round(0)) == false {
+		logrus.Warn("CreateTime is not equal")
+		return false
+	} else if obj.Description != other.Description ||
+		obj.Type != other.Type ||
+		obj.Enforcement != other.Enforcement ||
+		obj.Manufacturer != other.Manufacturer ||
+		model.StringSliceEquals(obj.Models, other.Models) == false ||
+		obj.Target != other.Target ||
+		obj.Versions != other.Versions ||
+		obj.RequiredTarget != other.RequiredTarget ||
+		obj.RequiredVersions != other.RequiredVersions ||
+		obj.Scope != other.Scope {
+		logrus.Warn("rule is not equal")
+		return false
+	}
+	return true
+}
+
+// AppliesTo -> true if the rule covers hardware from this manufacturer and of this model
+func (obj *CompatibilityRule) AppliesTo(manufacturer string, deviceModel string) bool {
+	if obj.Manufacturer != "" && !strings.EqualFold(obj.Manufacturer, manufacturer) {
+		return false
+	}
+	if len(obj.Models) > 0 {
+		if _, found := model.Find(obj.Models, deviceModel); !found {
+			return false
+		}
+	}
+	return true
+}
@@ -27,6 +27,7 @@ package storage
 import (
 	"encoding/json"
 	"fmt"
+	"net/url"
 	"os"
 	"strings"
 	"sync"
@@ -150,6 +151,13 @@ func (e *ETCDStorage) Ping() (err error) {
 	return
 }
 
+func (e *ETCDStorage) Close() (err error) {
+	if e.kvHandle != nil {
+		err = e.kvHandle.Close()
+	}
+	return err
+}
+
 func (e *ETCDStorage) StoreAction(a Action) (err error) {
 	key := fmt.Sprintf("/actions/%s", a.ActionID.String())
 	storable := ToActionStorable(a)
@@ -237,7 +245,9 @@ func (e *ETCDStorage) DeleteOperation(operationID uuid.UUID) (err error) {
 	err = e.kvDelete(key)
 	if err != nil {
 		e.Logger.Error(err)
+		return
 	}
+	_ = e.DeleteOperationHistory(operationID)
 	return
 }
 
@@ -253,6 +263,49 @@ func (e *ETCDStorage) GetOperation(operationID uuid.UUID) (o Operation, err erro
 	return
 }
 
+func operationHistoryEtcdKey(operationID uuid.UUID) string {
+	return fmt.Sprintf("/operationhistory/%s", operationID.String())
+}
+
+// GetOperationHistory -> an operation without history has an empty one
+func (e *ETCDStorage) GetOperationHistory(operationID uuid.UUID) (h []OperationEvent, err error) {
+	e.mutex.Lock()
+	v, exists, err := e.kvHandle.Get(e.fixUpKey(operationHistoryEtcdKey(operationID)))
+	e.mutex.Unlock()
+	if err == nil && exists {
+		err = json.Unmarshal([]byte(v), &h)
+	}
+	if err != nil {
+		e.Logger.Error(err)
+	}
+	return
+}
+
+func (e *ETCDStorage) AppendOperationHistory(operationID uuid.UUID, events []OperationEvent) (err error) {
+	history, err := e.GetOperationHistory(operationID)
+	if err != nil {
+		return
+	}
+	err = e.kvStore(operationHistoryEtcdKey(operationID), CapOperationHistory(append(history, events...)))
+	if err != nil {
+		e.Logger.Error(err)
+	}
+	return
+}
+
+func (e *ETCDStorage) DeleteOperationHistory(operationID uuid.UUID) (err error) {
+	key := operationHistoryEtcdKey(operationID)
+	_, exists, err := e.kvHandle.Get(e.fixUpKey(key))
+	if err != nil || !exists {
+		return
+	}
+	err = e.kvDelete(key)
+	if err != nil {
+		e.Logger.Error(err)
+	}
+	return
+}
+
 func (e *ETCDStorage) GetOperations(actionID uuid.UUID) (o []Operation, err error) {
 	action, err := e.GetAction(actionID)
 	if err != nil {
@@ -454,3 +507,155 @@ func (e *ETCDStorage) DeleteImage(imageID uuid.UUID) (err error) {
 	}
 	return
 }
+
+func (e *ETCDStorage) GetCompatibilityRules() (r []CompatibilityRule, err error) {
+	k := e.fixUpKey("/compatibilityrules/")
+	kvl, err := e.kvHandle.GetRange(k+keyMin, k+keyMax)
+	if err == nil {
+		for _, kv := range kvl {
+			var rule CompatibilityRule
+			err = json.Unmarshal([]byte(kv.Value), &rule)
+			if err != nil {
+				e.Logger.Error(err)
+			} else {
+				r = append(r, rule)
+			}
+		}
+	} else {
+		e.Logger.Error(err)
+	}
+	return
+}
+
+func (e *ETCDStorage) GetCompatibilityRule(ruleID uuid.UUID) (r CompatibilityRule, err error) {
+	key := fmt.Sprintf("/compatibilityrules/%s", ruleID.String())
+	err = e.kvGet(key, &r)
+	if err != nil {
+		e.Logger.Error(err)
+	}
+	return
+}
+
+func (e *ETCDStorage) StoreCompatibilityRule(r CompatibilityRule) (err error) {
+	key := fmt.Sprintf("/compatibilityrules/%s", r.RuleID.String())
+	err = e.kvStore(key, r)
+	if err != nil {
+		e.Logger.Error(err)
+	}
+	return
+}
+
+func (e *ETCDStorage) DeleteCompatibilityRule(ruleID uuid.UUID) (err error) {
+	_, err = e.GetCompatibilityRule(ruleID)
+	if err != nil {
+		return err
+	}
+
+	key := fmt.Sprintf("/compatibilityrules/%s", ruleID.String())
+	err = e.kvDelete(key)
+	if err != nil {
+		e.Logger.Error(err)
+	}
+	return
+}
+
+func (e *ETCDStorage) GetExclusionPolicies() (p []ExclusionPolicy, err error) {
+	k := e.fixUpKey("/exclusionpolicies/")
+	kvl, err := e.kvHandle.GetRange(k+keyMin, k+keyMax)
+	if err == nil {
+		for _, kv := range kvl {
+			var policy ExclusionPolicy
+			err = json.Unmarshal([]byte(kv.Value), &policy)
+			if err != nil {
+				e.Logger.Error(err)
+			} else {
+				p = append(p, policy)
+			}
+		}
+	} else {
+		e.Logger.Error(err)
+	}
+	return
+}
+
+func (e *ETCDStorage) GetExclusionPolicy(policyID uuid.UUID) (p ExclusionPolicy, err error) {
+	key := fmt.Sprintf("/exclusionpolicies/%s", policyID.String())
+	err = e.kvGet(key, &p)
+	if err != nil {
+		e.Logger.Error(err)
+	}
+	return
+}
+
+func (e *ETCDStorage) StoreExclusionPolicy(p ExclusionPolicy) (err error) {
+	key := fmt.Sprintf("/exclusionpolicies/%s", p.PolicyID.String())
+	err = e.kvStore(key, p)
+	if err != nil {
+		e.Logger.Error(err)
+	}
+	return
+}
+
+func (e *ETCDStorage) DeleteExclusionPolicy(policyID uuid.UUID) (err error) {
+	_, err = e.GetExclusionPolicy(policyID)
+	if err != nil {
+		return err
+	}
+
+	key := fmt.Sprintf("/exclusionpolicies/%s", policyID.String())
+	err = e.kvDelete(key)
+	if err != nil {
+		e.Logger.Error(err)
+	}
+	return
+}
+
+// the target goes into the key escaped, some target names carry a slash
+func quarantineEtcdKey(xname string, target string) string {
+	return fmt.Sprintf("/quarantine/%s/%s", xname, url.PathEscape(target))
+}
+
+func (e *ETCDStorage) GetQuarantineEntries() (q []QuarantineEntry, err error) {
+	k := e.fixUpKey("/quarantine/")
+	kvl, err := e.kvHandle.GetRange(k+keyMin, k+keyMax)
+	if err == nil {
+		for _, kv := range kvl {
+			var entry QuarantineEntry
+			err = json.Unmarshal([]byte(kv.Value), &entry)
+			if err != nil {
+				e.Logger.Error(err)
+			} else {
+				q = append(q, entry)
+			}
+		}
+	} else {
+		e.Logger.Error(err)
+	}
+	return
+}
+
+func (e *ETCDStorage) GetQuarantineEntry(xname string, target string) (q QuarantineEntry, err error) {
+	err = e.kvGet(quarantineEtcdKey(xname, target), &q)
+	return
+}
+
+func (e *ETCDStorage) StoreQuarantineEntry(q QuarantineEntry) (err error) {
+	err = e.kvStore(quarantineEtcdKey(q.Xname, q.Target), q)
+	if err != nil {
+		e.Logger.Error(err)
+	}
+	return
+}
+
+func (e *ETCDStorage) DeleteQuarantineEntry(xname string, target string) (err error) {
+	_, err = e.GetQuarantineEntry(xname, target)
+	if err != nil {
+		return err
+	}
+
+	err = e.kvDelete(quarantineEtcdKey(xname, target))
+	if err != nil {
+		e.Logger.Error(err)
+	}
+	return
+}
@@ -0,0 +1,124 @@
+/*
+ * MIT License
+ *
+ * (C) Copyright [2026] Hewlett Packard Enterprise Development LP
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a
+ * copy of this software and associated documentation files (the "Software"),
+ * to deal in the Software without restriction, including without limitation
+ * the rights to use, copy, modify, merge, publish, distribute, sublicense,
+ * and/or sell copies of the Software, and to permit persons to whom the
+ * Software is furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included
+ * in all copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL
+ * THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+ * OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+ * ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+ * OTHER DEALINGS IN THE SOFTWARE.
+ */
+
+package storage
+
+import (
+	"database/sql"
+	"path"
+	"strings"
+
+	"github.com/Cray-HPE/hms-firmware-action/internal/model"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+)
+
+type ExclusionPolicyID struct {
+	PolicyID uuid.UUID `json:"policyID"`
+}
+
+// ExclusionPolicy -> devices FAS must never update.  Every criterion that is set has to match for a device to be
+// excluded, a criterion with several values matches if any of them does.  Roles, subroles, groups and partitions are
+// those of the device in HSM or of the nodes it manages, so a node role excludes the BMC of that node.
+type ExclusionPolicy struct {
+	PolicyID     uuid.UUID    `json:"policyID"`
+	CreateTime   sql.NullTime `json:"createTime"`
+	Description  string       `json:"description,omitempty"`
+	Roles        []string     `json:"roles,omitempty"`
+	SubRoles     []string     `json:"subRoles,omitempty"`
+	Groups       []string     `json:"groups,omitempty"`
+	Partitions   []string     `json:"partitions,omitempty"`
+	Xnames       []string     `json:"xnames,omitempty"` //shell patterns, e.g. x3000c0s*b0
+	Manufacturer string       `json:"manufacturer,omitempty"`
+	Models       []string     `json:"models,omitempty"`
+	Targets      []string     `json:"targets,omitempty"`
+}
+
+func (obj *ExclusionPolicy) Equals(other ExclusionPolicy) bool {
+	if obj.PolicyID != other.PolicyID {
+		logrus.Warn("policyID is not equal")
+		return false
+	} else if obj.CreateTime.Time.Round(0).Equal(other.CreateTime.Time.Round(0)) == false {
+		logrus.Warn("CreateTime is not equal")
+		return false
+	} else if obj.Description != other.Description ||
+		model.StringSliceEquals(obj.Roles, other.Roles) == false ||
+		model.StringSliceEquals(obj.SubRoles, other.SubRoles) == false ||
+		model.StringSliceEquals(obj.Groups, other.Groups) == false ||
+		model.StringSliceEquals(obj.Partitions, other.Partitions) == false ||
+		model.StringSliceEquals(obj.Xnames, other.Xnames) == false ||
+		obj.Manufacturer != other.Manufacturer ||
+		model.StringSliceEquals(obj.Models, other.Models) == false ||
+		model.StringSliceEquals(obj.Targets, other.Targets) == false {
+		logrus.Warn("policy is not equal")
+		return false
+	}
+	return true
+}
+
+// HasCriteria -> a policy without any criterion would exclude every device
+func (obj *ExclusionPolicy) HasCriteria() bool {
+	return len(obj.Roles) > 0 || len(obj.SubRoles) > 0 || len(obj.Groups) > 0 || len(obj.Partitions) > 0 ||
+		len(obj.Xnames) > 0 || obj.Manufacturer != "" || len(obj.Models) > 0 || len(obj.Targets) > 0
+}
+
+// NeedsComponents -> true if the policy can only be decided with the HSM components of the nodes under a device
+func (obj *ExclusionPolicy) NeedsComponents() bool {
+	return len(obj.Roles) > 0 || len(obj.SubRoles) > 0 || len(obj.Groups) > 0 || len(obj.Partitions) > 0
+}
+
+// MatchesTarget -> the criteria that only need the operation itself; empty ones match anything
+func (obj *ExclusionPolicy) MatchesTarget(xname string, manufacturer string, deviceModel string, target string,
+	targetName string) bool {
+	if len(obj.Xnames) > 0 {
+		found := false
+		for _, pattern := range obj.Xnames {
+			if ok, _ := path.Match(strings.ToLower(pattern), strings.ToLower(xname)); ok {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	if obj.Manufacturer != "" && !strings.EqualFold(obj.Manufacturer, manufacturer) {
+		return false
+	}
+	if len(obj.Models) > 0 {
+		if _, found := model.Find(obj.Models, deviceModel); !found {
+			return false
+		}
+	}
+	if len(obj.Targets) > 0 {
+		_, found := model.Find(obj.Targets, target)
+		if !found && targetName != "" {
+			_, found = model.Find(obj.Targets, targetName)
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
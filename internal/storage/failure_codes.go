@@ -0,0 +1,60 @@
+/*
+ * MIT License
+ *
+ * (C) Copyright [2026] Hewlett Packard Enterprise Development LP
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a
+ * copy of this software and associated documentation files (the "Software"),
+ * to deal in the Software without restriction, including without limitation
+ * the rights to use, copy, modify, merge, publish, distribute, sublicense,
+ * and/or sell copies of the Software, and to permit persons to whom the
+ * Software is furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included
+ * in all copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL
+ * THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+ * OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+ * ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+ * OTHER DEALINGS IN THE SOFTWARE.
+ */
+
+package storage
+
+// Failure codes -> why an operation ended failed, noSolution or noOperation, for automation that has to decide
+// between retrying and escalating.  The StateHelper keeps the human readable detail.
+const (
+	// noOperation
+	FailureSameVersion = "SAME_VERSION" //the device already runs the image
+	FailureSkipped     = "SKIPPED"      //an admin skipped the operation
+
+	// noSolution, decided before anything is sent to the device
+	FailureImageNotFound       = "IMAGE_NOT_FOUND"
+	FailureDowngradeNotAllowed = "DOWNGRADE_NOT_ALLOWED"
+	FailureNoUpgradePath       = "NO_UPGRADE_PATH"
+	FailurePrerequisiteNotMet  = "PREREQUISITE_NOT_MET"
+	FailureIncompatible        = "INCOMPATIBLE"
+	FailureNoRestoreImage      = "NO_RESTORE_IMAGE" //no image to go back to, and restoreNotPossibleOverride is not set
+	FailureExcluded            = "EXCLUDED"         //an exclusion policy covers the device
+	FailureStagingNotSupported = "STAGING_NOT_SUPPORTED"
+	FailureUnsupportedDevice   = "UNSUPPORTED_DEVICE"
+	FailureInvalidOperation    = "INVALID_OPERATION" //the operation could not be built
+	FailureHookFailed          = "HOOK_FAILED"
+	FailureQuarantined         = "QUARANTINED" //failed too often in earlier actions, see /quarantine
+
+	// failed while launching
+	FailureFileUnreachable      = "FILE_UNREACHABLE"
+	FailureLockFailed           = "LOCK_FAILED"
+	FailurePowerStateNotAllowed = "POWER_STATE_NOT_ALLOWED"
+	FailureLaunchTimeout        = "LAUNCH_TIMEOUT"
+	FailureBMCUnreachable       = "BMC_UNREACHABLE" //the update payload got no http response
+	FailureBMCRejected          = "BMC_REJECTED"    //the BMC answered the update payload with an error status
+
+	// failed while verifying
+	FailureBMCTaskFailed     = "BMC_TASK_FAILED" //the update task or update information on the BMC reports a failure
+	FailureVerifyTimeout     = "VERIFY_TIMEOUT"
+	FailureUnexpectedVersion = "UNEXPECTED_VERSION"
+)
@@ -0,0 +1,70 @@
+/*
+ * MIT License
+ *
+ * (C) Copyright [2026] Hewlett Packard Enterprise Development LP
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a
+ * copy of this software and associated documentation files (the "Software"),
+ * to deal in the Software without restriction, including without limitation
+ * the rights to use, copy, modify, merge, publish, distribute, sublicense,
+ * and/or sell copies of the Software, and to permit persons to whom the
+ * Software is furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included
+ * in all copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL
+ * THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+ * OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+ * ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+ * OTHER DEALINGS IN THE SOFTWARE.
+ */
+
+package storage
+
+import (
+	"errors"
+
+	"github.com/Masterminds/semver/v3"
+)
+
+// ImagePrerequisite -> another target on the same device that has to be at MinimumVersion or later before the image
+// that declares it can be applied, e.g. a BIOS that only works with a newer BMC.  If FAS is updating that target in
+// the same action the operation for it runs first; otherwise the version already on the device has to satisfy it.
+type ImagePrerequisite struct {
+	Target         string `json:"target"`
+	MinimumVersion string `json:"minimumVersion"` //semantic version
+}
+
+func (obj *ImagePrerequisite) Validate() error {
+	if obj.Target == "" {
+		return errors.New("prerequisite target is required")
+	}
+	if _, err := semver.NewVersion(obj.MinimumVersion); err != nil {
+		return errors.New("prerequisite " + obj.Target + " minimumVersion is not a semantic version: " + err.Error())
+	}
+	return nil
+}
+
+// SatisfiedBy -> true if version is at or above the minimum; an unknown version never satisfies a prerequisite
+func (obj *ImagePrerequisite) SatisfiedBy(version *semver.Version) bool {
+	minimum, err := semver.NewVersion(obj.MinimumVersion)
+	if err != nil || version == nil {
+		return false
+	}
+	return !version.LessThan(minimum)
+}
+
+func ImagePrerequisitesEquals(a []ImagePrerequisite, b []ImagePrerequisite) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
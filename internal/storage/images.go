@@ -39,27 +39,32 @@ type ImageID struct {
 }
 
 //TODO flush this out new rule in documentation!: the firmware version must be unique for the devicetype/manf/model;
-//  b.c I cannot figure out WHAT tag they are running just by looking at the firmware on the device!!!
+//
+//	b.c I cannot figure out WHAT tag they are running just by looking at the firmware on the device!!!
 type Image struct {
-	ImageID                           uuid.UUID       `json:"imageID"`
-	CreateTime                        sql.NullTime    `json:"createTime"`
-	DeviceType                        string          `json:"deviceType"`
-	Manufacturer                      string          `json:"manufacturer,omitempty"`
-	Models                            []string        `json:"models,omitempty"`
-	SoftwareIds                       []string        `json:"softwareIds,omitempty"`
-	Target                            string          `json:"target,omitempty"`
-	Tags                              []string        `json:"tags,omitempty"`
-	FirmwareVersion                   string          `json:"firmwareVersion"`
-	SemanticFirmwareVersion           *semver.Version `json:"semanticFirmwareVersion,omitempty"`
-	UpdateURI                         string          `json:"updateURI"`
-	NeedManualReboot                  bool            `json:"needManualReboot"`
-	WaitTimeBeforeManualRebootSeconds int             `json:"waitTimeBeforeManualRebootSeconds"`
-	WaitTimeAfterRebootSeconds        int             `json:"waitTimeAfterRebootSeconds"`
-	PollingSpeedSeconds               int             `json:"pollingSpeedSeconds"`
-	ForceResetType                    string          `json:"forceResetType"`
-	S3URL                             string          `json:"s3URL"`
-	TftpURL                           string          `json:"tftpURL"`
-	AllowableDeviceStates             []string        `json:"allowableDeviceStates,omitempty"`
+	ImageID                           uuid.UUID           `json:"imageID"`
+	CreateTime                        sql.NullTime        `json:"createTime"`
+	DeviceType                        string              `json:"deviceType"`
+	Manufacturer                      string              `json:"manufacturer,omitempty"`
+	Models                            []string            `json:"models,omitempty"`
+	SoftwareIds                       []string            `json:"softwareIds,omitempty"`
+	Target                            string              `json:"target,omitempty"`
+	Tags                              []string            `json:"tags,omitempty"`
+	FirmwareVersion                   string              `json:"firmwareVersion"`
+	SemanticFirmwareVersion           *semver.Version     `json:"semanticFirmwareVersion,omitempty"`
+	UpdateURI                         string              `json:"updateURI"`
+	NeedManualReboot                  bool                `json:"needManualReboot"`
+	WaitTimeBeforeManualRebootSeconds int                 `json:"waitTimeBeforeManualRebootSeconds"`
+	WaitTimeAfterRebootSeconds        int                 `json:"waitTimeAfterRebootSeconds"`
+	PollingSpeedSeconds               int                 `json:"pollingSpeedSeconds"`
+	ForceResetType                    string              `json:"forceResetType"`
+	S3URL                             string              `json:"s3URL"`
+	TftpURL                           string              `json:"tftpURL"`
+	AllowableDeviceStates             []string            `json:"allowableDeviceStates,omitempty"`
+	RetryPolicy                       *RetryPolicy        `json:"retryPolicy,omitempty"`
+	Prerequisites                     []ImagePrerequisite `json:"prerequisites,omitempty"`
+	RequiresFromVersion               string              `json:"requiresFromVersion,omitempty"` //semver constraint the installed version must meet, e.g. ">=2.0.0 <3.0.0"
+	NoDowngradeBelow                  string              `json:"noDowngradeBelow,omitempty"`    //semver; once this image is installed nothing older than this may replace it
 }
 
 func (obj *Image) Equals(other Image) bool {
@@ -121,6 +126,31 @@ func (obj *Image) Equals(other Image) bool {
 	} else if model.StringSliceEquals(obj.AllowableDeviceStates, other.AllowableDeviceStates) == false {
 		logrus.Warn("AllowableDeviceStates is not equal")
 		return false
+	} else if obj.RetryPolicy.Equals(other.RetryPolicy) == false {
+		logrus.Warn("RetryPolicy is not equal")
+		return false
+	} else if ImagePrerequisitesEquals(obj.Prerequisites, other.Prerequisites) == false {
+		logrus.Warn("Prerequisites is not equal")
+		return false
+	} else if obj.RequiresFromVersion != other.RequiresFromVersion {
+		logrus.Warn("RequiresFromVersion is not equal")
+		return false
+	} else if obj.NoDowngradeBelow != other.NoDowngradeBelow {
+		logrus.Warn("NoDowngradeBelow is not equal")
+		return false
 	}
 	return true
 }
+
+// AcceptsFromVersion -> true if the image can be applied on top of the given version.  Images without a
+// RequiresFromVersion can be applied on top of anything, even a version FAS does not know.
+func (obj *Image) AcceptsFromVersion(version *semver.Version) bool {
+	if obj.RequiresFromVersion == "" {
+		return true
+	}
+	constraint, err := semver.NewConstraint(obj.RequiresFromVersion)
+	if err != nil || version == nil {
+		return false
+	}
+	return constraint.Check(version)
+}
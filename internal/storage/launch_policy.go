@@ -0,0 +1,59 @@
+/*
+ * MIT License
+ *
+ * (C) Copyright [2026] Hewlett Packard Enterprise Development LP
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a
+ * copy of this software and associated documentation files (the "Software"),
+ * to deal in the Software without restriction, including without limitation
+ * the rights to use, copy, modify, merge, publish, distribute, sublicense,
+ * and/or sell copies of the Software, and to permit persons to whom the
+ * Software is furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included
+ * in all copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL
+ * THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+ * OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+ * ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+ * OTHER DEALINGS IN THE SOFTWARE.
+ */
+
+package storage
+
+// LaunchPolicy -> limits how many units (BMCs, slots, ...) of one topology group (chassis, cabinet, ...) have an
+// update in flight at the same time, so a group is never taken down as a whole.  Group and Unit are HMS types; the
+// group and unit of an operation are the ancestors of its xname of those types.
+type LaunchPolicy struct {
+	Group      string `json:"group,omitempty"`      //e.g. Chassis; empty is the whole action
+	Unit       string `json:"unit,omitempty"`       //e.g. ComputeModule; empty is the xname of the operation
+	MaxUnits   int    `json:"maxUnits,omitempty"`   //at most this many units of a group at a time
+	MaxPercent int    `json:"maxPercent,omitempty"` //or at most this share of the units of a group the action updates
+}
+
+// Limit -> how many units of a group may be busy, given how many units of it the action updates; never less than 1
+func (obj *LaunchPolicy) Limit(units int) int {
+	if obj.MaxUnits > 0 {
+		return obj.MaxUnits
+	}
+	limit := units * obj.MaxPercent / 100
+	if limit < 1 {
+		limit = 1
+	}
+	return limit
+}
+
+func LaunchPoliciesEqual(a []LaunchPolicy, b []LaunchPolicy) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
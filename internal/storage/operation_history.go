@@ -0,0 +1,139 @@
+/*
+ * MIT License
+ *
+ * (C) Copyright [2026] Hewlett Packard Enterprise Development LP
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a
+ * copy of this software and associated documentation files (the "Software"),
+ * to deal in the Software without restriction, including without limitation
+ * the rights to use, copy, modify, merge, publish, distribute, sublicense,
+ * and/or sell copies of the Software, and to permit persons to whom the
+ * Software is furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included
+ * in all copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL
+ * THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+ * OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+ * ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+ * OTHER DEALINGS IN THE SOFTWARE.
+ */
+
+package storage
+
+import (
+	"time"
+
+	"github.com/looplab/fsm"
+)
+
+// OperationEvent -> one entry of the history of an operation: a transition of its state machine, or a change of
+// its StateHelper or of what the BMC reports about the update
+type OperationEvent struct {
+	Time            time.Time `json:"time"`
+	Event           string    `json:"event,omitempty"` //the fsm event; empty when the state did not change
+	State           string    `json:"state"`
+	StateHelper     string    `json:"stateHelper,omitempty"`
+	RedfishStatus   string    `json:"redfishStatus,omitempty"`   //update or task status reported by the BMC
+	PercentComplete string    `json:"percentComplete,omitempty"` //as reported by the BMC
+}
+
+// MaxOperationHistory -> the oldest entries are dropped past this, so an operation that polls a BMC for days
+// does not grow without bound
+const MaxOperationHistory = 500
+
+// the transitions are parked on the state machine, which every copy of an operation shares, until the operation is
+// stored; an enter_state callback only ever sees the copy it was created with
+const pendingHistoryKey = "pendingHistory"
+
+func notePending(f *fsm.FSM, event OperationEvent) {
+	var pending []OperationEvent
+	if v, ok := f.Metadata(pendingHistoryKey); ok {
+		pending = v.([]OperationEvent)
+	}
+	f.SetMetadata(pendingHistoryKey, append(pending, event))
+}
+
+// repeats -> true when the event adds nothing to the one before it
+func repeats(last *OperationEvent, event OperationEvent) bool {
+	return last != nil && event.Event == "" && last.State == event.State && last.StateHelper == event.StateHelper &&
+		last.RedfishStatus == event.RedfishStatus && last.PercentComplete == event.PercentComplete
+}
+
+// CapOperationHistory -> drops the oldest entries past MaxOperationHistory
+func CapOperationHistory(history []OperationEvent) []OperationEvent {
+	if len(history) > MaxOperationHistory {
+		history = history[len(history)-MaxOperationHistory:]
+	}
+	return history
+}
+
+// PendingHistory -> what happened since the operation was last stored, to be appended to its stored history, with
+// the StateHelper noted when it changed.  last is the newest entry stored so far, nil if there is none; the caller
+// may hold an older copy of the operation.
+func (op *Operation) PendingHistory(last *OperationEvent) (events []OperationEvent) {
+	if op.State == nil {
+		return nil
+	}
+	if v, ok := op.State.Metadata(pendingHistoryKey); ok {
+		for _, event := range v.([]OperationEvent) {
+			if !repeats(last, event) {
+				events = append(events, event)
+				last = &events[len(events)-1]
+			}
+		}
+		op.State.DeleteMetadata(pendingHistoryKey)
+	}
+	if last != nil && last.State == op.State.Current() {
+		//the StateHelper is set right after the transition or report it explains
+		if len(events) > 0 {
+			last.StateHelper = op.StateHelper
+			return events
+		}
+		if last.StateHelper == op.StateHelper {
+			return nil
+		}
+	}
+	return append(events, OperationEvent{Time: time.Now(), State: op.State.Current(), StateHelper: op.StateHelper})
+}
+
+// RecordRedfishStatus -> notes what the BMC reports about the update; it lands in the history with the next store
+func (op *Operation) RecordRedfishStatus(status string, percent string) {
+	notePending(op.State, OperationEvent{
+		Time:            time.Now(),
+		State:           op.State.Current(),
+		StateHelper:     op.StateHelper,
+		RedfishStatus:   status,
+		PercentComplete: percent,
+	})
+}
+
+// StateDurations -> how long an operation spent in each state of its history on the way to a final one; the
+// current state counts until until
+func StateDurations(history []OperationEvent, until time.Time) map[string]time.Duration {
+	durations := make(map[string]time.Duration)
+	for i, event := range history {
+		if IsFinalOperationState(event.State) {
+			continue
+		}
+		end := until
+		if i+1 < len(history) {
+			end = history[i+1].Time
+		}
+		if end.After(event.Time) {
+			durations[event.State] += end.Sub(event.Time)
+		}
+	}
+	return durations
+}
+
+func IsFinalOperationState(state string) bool {
+	switch state {
+	case "succeeded", "failed", "aborted", "noSolution", "noOperation":
+		return true
+	}
+	return false
+}
@@ -0,0 +1,105 @@
+/*
+ * MIT License
+ *
+ * (C) Copyright [2026] Hewlett Packard Enterprise Development LP
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a
+ * copy of this software and associated documentation files (the "Software"),
+ * to deal in the Software without restriction, including without limitation
+ * the rights to use, copy, modify, merge, publish, distribute, sublicense,
+ * and/or sell copies of the Software, and to permit persons to whom the
+ * Software is furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included
+ * in all copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL
+ * THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+ * OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+ * ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+ * OTHER DEALINGS IN THE SOFTWARE.
+ */
+
+package storage
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type Operation_History_TS struct {
+	suite.Suite
+}
+
+func (suite *Operation_History_TS) Test_PendingHistory() {
+	operation := NewOperation()
+	_ = operation.State.Event(context.Background(), "configure")
+	history := operation.PendingHistory(nil)
+	suite.Equal(1, len(history))
+	suite.Equal("configure", history[0].Event)
+	suite.Equal("configured", history[0].State)
+
+	//a copy made before the transition still hands it over, and the StateHelper set after it explains it
+	stale := *operation
+	_ = operation.State.Event(context.Background(), "start")
+	stale.StateHelper = "preparing to launch"
+	events := stale.PendingHistory(&history[len(history)-1])
+	suite.Equal(1, len(events))
+	suite.Equal("start", events[0].Event)
+	suite.Equal("preparing to launch", events[0].StateHelper)
+	history = append(history, events...)
+
+	//storing again without a change adds nothing; a new StateHelper does
+	suite.Equal(0, len(stale.PendingHistory(&history[len(history)-1])))
+	stale.StateHelper = "sending cray payload"
+	events = stale.PendingHistory(&history[len(history)-1])
+	suite.Equal(1, len(events))
+	suite.Equal("", events[0].Event)
+	history = append(history, events...)
+
+	stale.RecordRedfishStatus("Running OK", "40%")
+	stale.StateHelper = "Firmware Task Returned Running"
+	events = stale.PendingHistory(&history[len(history)-1])
+	suite.Equal(1, len(events))
+	suite.Equal("40%", events[0].PercentComplete)
+	suite.Equal("Firmware Task Returned Running", events[0].StateHelper)
+	history = append(history, events...)
+	stale.RecordRedfishStatus("Running OK", "40%")
+	suite.Equal(0, len(stale.PendingHistory(&history[len(history)-1])))
+}
+
+func (suite *Operation_History_TS) Test_HistoryIsCapped() {
+	var history []OperationEvent
+	for i := 0; i < MaxOperationHistory+10; i++ {
+		history = CapOperationHistory(append(history, OperationEvent{StateHelper: time.Duration(i).String()}))
+	}
+	suite.Equal(MaxOperationHistory, len(history))
+	suite.Equal(time.Duration(MaxOperationHistory+9).String(), history[MaxOperationHistory-1].StateHelper)
+}
+
+func (suite *Operation_History_TS) Test_StateDurations() {
+	start := time.Now().Add(-time.Hour)
+	history := []OperationEvent{
+		{Time: start, Event: "configure", State: "configured"},
+		{Time: start.Add(time.Minute), Event: "start", State: "inProgress"},
+		{Time: start.Add(3 * time.Minute), State: "inProgress", StateHelper: "sending cray payload"},
+		{Time: start.Add(5 * time.Minute), Event: "needsVerify", State: "needsVerified"},
+		{Time: start.Add(6 * time.Minute), Event: "verifying", State: "verifying"},
+		{Time: start.Add(16 * time.Minute), Event: "success", State: "succeeded"},
+	}
+	durations := StateDurations(history, time.Now())
+	suite.Equal(time.Minute, durations["configured"])
+	suite.Equal(4*time.Minute, durations["inProgress"])
+	suite.Equal(10*time.Minute, durations["verifying"])
+	_, ok := durations["succeeded"]
+	suite.False(ok)
+}
+
+func Test_Storage_Operation_History(t *testing.T) {
+	suite.Run(t, new(Operation_History_TS))
+}
@@ -0,0 +1,56 @@
+/*
+ * MIT License
+ *
+ * (C) Copyright [2026] Hewlett Packard Enterprise Development LP
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a
+ * copy of this software and associated documentation files (the "Software"),
+ * to deal in the Software without restriction, including without limitation
+ * the rights to use, copy, modify, merge, publish, distribute, sublicense,
+ * and/or sell copies of the Software, and to permit persons to whom the
+ * Software is furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included
+ * in all copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL
+ * THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+ * OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+ * ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+ * OTHER DEALINGS IN THE SOFTWARE.
+ */
+
+package storage
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// QuarantineEntry -> the failure streak of one xname/target across actions.  Once the streak reaches the quarantine
+// threshold the device is quarantined, and new actions skip it until an admin clears the entry.
+type QuarantineEntry struct {
+	Xname               string    `json:"xname"`
+	Target              string    `json:"target"`
+	ConsecutiveFailures int       `json:"consecutiveFailures"`
+	LastFailureTime     time.Time `json:"lastFailureTime"`
+	LastFailureCode     string    `json:"lastFailureCode,omitempty"`
+	LastStateHelper     string    `json:"lastStateHelper,omitempty"`
+	LastOperationID     uuid.UUID `json:"lastOperationID"`
+	Quarantined         bool      `json:"quarantined"`
+	QuarantineTime      time.Time `json:"quarantineTime,omitempty"`
+}
+
+// FailureCountsTowardsQuarantine -> only failures the device itself is to blame for; an s3 outage or a lock held
+// by another service should not quarantine a healthy BMC
+func FailureCountsTowardsQuarantine(failureCode string) bool {
+	switch failureCode {
+	case FailureBMCUnreachable, FailureBMCRejected, FailureBMCTaskFailed, FailureVerifyTimeout,
+		FailureUnexpectedVersion, FailureLaunchTimeout:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,177 @@
+/*
+ * MIT License
+ *
+ * (C) Copyright [2026] Hewlett Packard Enterprise Development LP
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a
+ * copy of this software and associated documentation files (the "Software"),
+ * to deal in the Software without restriction, including without limitation
+ * the rights to use, copy, modify, merge, publish, distribute, sublicense,
+ * and/or sell copies of the Software, and to permit persons to whom the
+ * Software is furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included
+ * in all copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL
+ * THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+ * OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+ * ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+ * OTHER DEALINGS IN THE SOFTWARE.
+ */
+
+package storage
+
+import (
+	"errors"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// RetryPolicy -> how many times FAS will resend an update payload to a BMC that failed with a transient error, and
+// how long it waits between tries.  A policy can be set on an image or on an action; the action wins.  Without either
+// FAS sends the payload exactly once, which is how it has always behaved.
+type RetryPolicy struct {
+	MaxAttempts           int      `json:"maxAttempts"`                     //total sends, including the first one
+	InitialBackoffSeconds int      `json:"initialBackoffSeconds,omitempty"` //wait before the second send
+	MaxBackoffSeconds     int      `json:"maxBackoffSeconds,omitempty"`     //upper bound on any single wait
+	BackoffMultiplier     float64  `json:"backoffMultiplier,omitempty"`     //growth factor applied after each failed send
+	RetryableStatusCodes  []int    `json:"retryableStatusCodes,omitempty"`  //http status codes returned by the BMC
+	RetryableErrors       []string `json:"retryableErrors,omitempty"`       //substrings of a connection level error
+}
+
+var DefaultRetryableStatusCodes = []int{500, 502, 503, 504}
+
+var DefaultRetryableErrors = []string{"connection refused", "connection reset", "EOF", "timeout", "deadline exceeded", "no route to host"}
+
+const (
+	DefaultRetryInitialBackoffSeconds = 30
+	DefaultRetryBackoffMultiplier     = 2
+	// the control loop relaunches an inProgress operation that has not been refreshed in 10 minutes, so a single
+	// wait has to stay well below that
+	MaxRetryBackoffSeconds = 300
+)
+
+func (obj *RetryPolicy) Equals(other *RetryPolicy) bool {
+	if obj == nil || other == nil {
+		return obj == other
+	}
+	if obj.MaxAttempts != other.MaxAttempts ||
+		obj.InitialBackoffSeconds != other.InitialBackoffSeconds ||
+		obj.MaxBackoffSeconds != other.MaxBackoffSeconds ||
+		obj.BackoffMultiplier != other.BackoffMultiplier ||
+		len(obj.RetryableStatusCodes) != len(other.RetryableStatusCodes) ||
+		len(obj.RetryableErrors) != len(other.RetryableErrors) {
+		return false
+	}
+	for i := range obj.RetryableStatusCodes {
+		if obj.RetryableStatusCodes[i] != other.RetryableStatusCodes[i] {
+			return false
+		}
+	}
+	for i := range obj.RetryableErrors {
+		if obj.RetryableErrors[i] != other.RetryableErrors[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func (obj *RetryPolicy) Validate() (err error) {
+	if obj == nil {
+		return nil
+	}
+	if obj.MaxAttempts < 1 {
+		return errors.New("retryPolicy.maxAttempts must be at least 1")
+	}
+	if obj.InitialBackoffSeconds < 0 || obj.MaxBackoffSeconds < 0 {
+		return errors.New("retryPolicy backoff seconds cannot be negative")
+	}
+	if obj.InitialBackoffSeconds > MaxRetryBackoffSeconds || obj.MaxBackoffSeconds > MaxRetryBackoffSeconds {
+		return errors.New("retryPolicy backoff seconds cannot exceed " + strconv.Itoa(MaxRetryBackoffSeconds))
+	}
+	if obj.MaxBackoffSeconds > 0 && obj.MaxBackoffSeconds < obj.InitialBackoffSeconds {
+		return errors.New("retryPolicy.maxBackoffSeconds cannot be less than initialBackoffSeconds")
+	}
+	if obj.BackoffMultiplier != 0 && obj.BackoffMultiplier < 1 {
+		return errors.New("retryPolicy.backoffMultiplier must be at least 1")
+	}
+	for _, code := range obj.RetryableStatusCodes {
+		if code < 100 || code > 599 {
+			return errors.New("retryPolicy.retryableStatusCodes must be valid http status codes")
+		}
+	}
+	return nil
+}
+
+// ResolveRetryPolicy -> picks the policy that applies to an operation and fills in the defaults.
+func ResolveRetryPolicy(action *RetryPolicy, image *RetryPolicy) (policy RetryPolicy) {
+	if action != nil {
+		policy = *action
+	} else if image != nil {
+		policy = *image
+	}
+	if policy.MaxAttempts < 1 {
+		policy.MaxAttempts = 1
+	}
+	if policy.InitialBackoffSeconds == 0 {
+		policy.InitialBackoffSeconds = DefaultRetryInitialBackoffSeconds
+	}
+	if policy.MaxBackoffSeconds == 0 || policy.MaxBackoffSeconds > MaxRetryBackoffSeconds {
+		policy.MaxBackoffSeconds = MaxRetryBackoffSeconds
+	}
+	if policy.BackoffMultiplier == 0 {
+		policy.BackoffMultiplier = DefaultRetryBackoffMultiplier
+	}
+	if len(policy.RetryableStatusCodes) == 0 {
+		policy.RetryableStatusCodes = DefaultRetryableStatusCodes
+	}
+	if len(policy.RetryableErrors) == 0 {
+		policy.RetryableErrors = DefaultRetryableErrors
+	}
+	return
+}
+
+// Backoff -> how long to wait after the given (1 based) attempt failed.
+func (obj *RetryPolicy) Backoff(attempt int) time.Duration {
+	if attempt < 1 {
+		attempt = 1
+	}
+	seconds := float64(obj.InitialBackoffSeconds) * math.Pow(obj.BackoffMultiplier, float64(attempt-1))
+	if obj.MaxBackoffSeconds > 0 && seconds > float64(obj.MaxBackoffSeconds) {
+		seconds = float64(obj.MaxBackoffSeconds)
+	}
+	return time.Duration(seconds * float64(time.Second))
+}
+
+// Retryable -> isError is true when the request never got an http response (connection dropped, timed out, etc), in
+// which case the error text is matched; otherwise the status code the BMC returned is.
+func (obj *RetryPolicy) Retryable(isError bool, statusCode int, detail string) bool {
+	if isError {
+		for _, v := range obj.RetryableErrors {
+			if strings.Contains(strings.ToLower(detail), strings.ToLower(v)) {
+				return true
+			}
+		}
+		return false
+	}
+	for _, v := range obj.RetryableStatusCodes {
+		if v == statusCode {
+			return true
+		}
+	}
+	return false
+}
+
+// OperationAttempt -> one send of the update payload
+type OperationAttempt struct {
+	Attempt    int       `json:"attempt"`
+	Time       time.Time `json:"time"`
+	StatusCode int       `json:"statusCode"`
+	Error      string    `json:"error,omitempty"`
+	Retryable  bool      `json:"retryable"`
+}
@@ -0,0 +1,102 @@
+/*
+ * MIT License
+ *
+ * (C) Copyright [2026] Hewlett Packard Enterprise Development LP
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a
+ * copy of this software and associated documentation files (the "Software"),
+ * to deal in the Software without restriction, including without limitation
+ * the rights to use, copy, modify, merge, publish, distribute, sublicense,
+ * and/or sell copies of the Software, and to permit persons to whom the
+ * Software is furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included
+ * in all copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL
+ * THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+ * OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+ * ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+ * OTHER DEALINGS IN THE SOFTWARE.
+ */
+
+package storage
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type Retry_Policy_TS struct {
+	suite.Suite
+}
+
+func (suite *Retry_Policy_TS) Test_ResolveRetryPolicy_Default() {
+	policy := ResolveRetryPolicy(nil, nil)
+	suite.Equal(1, policy.MaxAttempts)
+	suite.Equal(DefaultRetryableStatusCodes, policy.RetryableStatusCodes)
+	suite.Equal(DefaultRetryableErrors, policy.RetryableErrors)
+}
+
+func (suite *Retry_Policy_TS) Test_ResolveRetryPolicy_ActionOverridesImage() {
+	image := &RetryPolicy{MaxAttempts: 2}
+	action := &RetryPolicy{MaxAttempts: 5, RetryableStatusCodes: []int{503}}
+	suite.Equal(2, ResolveRetryPolicy(nil, image).MaxAttempts)
+	policy := ResolveRetryPolicy(action, image)
+	suite.Equal(5, policy.MaxAttempts)
+	suite.Equal([]int{503}, policy.RetryableStatusCodes)
+}
+
+func (suite *Retry_Policy_TS) Test_Backoff() {
+	policy := ResolveRetryPolicy(&RetryPolicy{MaxAttempts: 5, InitialBackoffSeconds: 10, MaxBackoffSeconds: 60}, nil)
+	suite.Equal(10*time.Second, policy.Backoff(1))
+	suite.Equal(20*time.Second, policy.Backoff(2))
+	suite.Equal(40*time.Second, policy.Backoff(3))
+	suite.Equal(60*time.Second, policy.Backoff(4))
+
+	policy = ResolveRetryPolicy(&RetryPolicy{MaxAttempts: 20, InitialBackoffSeconds: 10}, nil)
+	suite.Equal(MaxRetryBackoffSeconds*time.Second, policy.Backoff(15))
+}
+
+func (suite *Retry_Policy_TS) Test_Retryable() {
+	policy := ResolveRetryPolicy(&RetryPolicy{MaxAttempts: 3}, nil)
+	suite.True(policy.Retryable(false, 503, ""))
+	suite.False(policy.Retryable(false, 400, ""))
+	suite.True(policy.Retryable(true, 500, "Post \"https://x0c0s0b0/redfish\": dial tcp: connect: connection refused"))
+	suite.False(policy.Retryable(true, 400, "open images/file: no such file or directory"))
+
+	policy = ResolveRetryPolicy(&RetryPolicy{MaxAttempts: 3, RetryableErrors: []string{"TLS handshake"}}, nil)
+	suite.True(policy.Retryable(true, 500, "net/http: tls handshake timeout"))
+	suite.False(policy.Retryable(true, 500, "connection refused"))
+}
+
+func (suite *Retry_Policy_TS) Test_Validate() {
+	var policy *RetryPolicy
+	suite.Nil(policy.Validate())
+	suite.NotNil((&RetryPolicy{MaxAttempts: 0}).Validate())
+	suite.NotNil((&RetryPolicy{MaxAttempts: 2, InitialBackoffSeconds: 60, MaxBackoffSeconds: 30}).Validate())
+	suite.NotNil((&RetryPolicy{MaxAttempts: 2, BackoffMultiplier: 0.5}).Validate())
+	suite.NotNil((&RetryPolicy{MaxAttempts: 2, MaxBackoffSeconds: MaxRetryBackoffSeconds + 1}).Validate())
+	suite.NotNil((&RetryPolicy{MaxAttempts: 2, RetryableStatusCodes: []int{42}}).Validate())
+	suite.Nil((&RetryPolicy{MaxAttempts: 2, InitialBackoffSeconds: 5, MaxBackoffSeconds: 30, BackoffMultiplier: 1.5}).Validate())
+}
+
+func (suite *Retry_Policy_TS) Test_Equals() {
+	var nilPolicy *RetryPolicy
+	p1 := &RetryPolicy{MaxAttempts: 3, RetryableStatusCodes: []int{503}}
+	p2 := &RetryPolicy{MaxAttempts: 3, RetryableStatusCodes: []int{503}}
+	suite.True(nilPolicy.Equals(nil))
+	suite.False(nilPolicy.Equals(p1))
+	suite.False(p1.Equals(nil))
+	suite.True(p1.Equals(p2))
+	p2.RetryableStatusCodes = []int{502}
+	suite.False(p1.Equals(p2))
+}
+
+func Test_Storage_Retry_Policy(t *testing.T) {
+	suite.Run(t, new(Retry_Policy_TS))
+}
@@ -79,6 +79,16 @@ func ToSnapshotStorable(from Snapshot) (to SnapshotStorable) {
 	return to
 }
 
+// ToSnapshotStorableWithDevices - for when the snapshot is written as a single
+// record, instead of the per device keys the StorageProvider uses.
+func ToSnapshotStorableWithDevices(from Snapshot) (to SnapshotStorable) {
+	to = ToSnapshotStorable(from)
+	for _, dev := range from.Devices {
+		to.Devices = append(to.Devices, ToDeviceStorable(dev))
+	}
+	return to
+}
+
 func ToSnapshotFromStorable(from SnapshotStorable) (to Snapshot) {
 	to = Snapshot{
 		Name:              from.Name,
@@ -32,6 +32,7 @@ import (
 type StorageProvider interface {
 	Init(Logger *logrus.Logger) (err error)
 	Ping() (err error)
+	Close() (err error)
 
 	StoreAction(a Action) (err error)
 	DeleteAction(actionID uuid.UUID) (err error)
@@ -44,6 +45,11 @@ type StorageProvider interface {
 	GetOperations(actionID uuid.UUID) (o []Operation, err error)
 	GetAllOperations() (o []Operation, err error)
 
+	// the history of an operation is kept apart from it, so loading operations does not drag it along
+	GetOperationHistory(operationID uuid.UUID) (h []OperationEvent, err error)
+	AppendOperationHistory(operationID uuid.UUID, events []OperationEvent) (err error)
+	DeleteOperationHistory(operationID uuid.UUID) (err error)
+
 	StoreSnapshot(s Snapshot) (err error)
 	GetSnapshot(name string) (ss Snapshot, err error)
 	GetSnapshots() (s []Snapshot, err error)
@@ -53,4 +59,19 @@ type StorageProvider interface {
 	GetImage(imageID uuid.UUID) (i Image, err error)
 	StoreImage(i Image) (err error)
 	DeleteImage(imageID uuid.UUID) (err error)
+
+	GetCompatibilityRules() (r []CompatibilityRule, err error)
+	GetCompatibilityRule(ruleID uuid.UUID) (r CompatibilityRule, err error)
+	StoreCompatibilityRule(r CompatibilityRule) (err error)
+	DeleteCompatibilityRule(ruleID uuid.UUID) (err error)
+
+	GetExclusionPolicies() (p []ExclusionPolicy, err error)
+	GetExclusionPolicy(policyID uuid.UUID) (p ExclusionPolicy, err error)
+	StoreExclusionPolicy(p ExclusionPolicy) (err error)
+	DeleteExclusionPolicy(policyID uuid.UUID) (err error)
+
+	GetQuarantineEntries() (q []QuarantineEntry, err error)
+	GetQuarantineEntry(xname string, target string) (q QuarantineEntry, err error)
+	StoreQuarantineEntry(q QuarantineEntry) (err error)
+	DeleteQuarantineEntry(xname string, target string) (err error)
 }
@@ -25,6 +25,9 @@
 package storage
 
 import (
+	"encoding/json"
+	"strings"
+
 	"github.com/google/uuid"
 	"github.com/sirupsen/logrus"
 )
@@ -146,6 +149,36 @@ func (suite *Storage_Provider_TS) Test_Storage_Provider_DeleteOperation_Happy()
 	suite.False(err == nil)
 }
 
+func (suite *Storage_Provider_TS) Test_Storage_Provider_OperationHistory() {
+	o := HelperGetStockOperation()
+	err := MS.StoreOperation(o)
+	suite.True(err == nil)
+	h, err := MS.GetOperationHistory(o.OperationID)
+	suite.True(err == nil)
+	suite.Equal(0, len(h))
+
+	err = MS.AppendOperationHistory(o.OperationID, []OperationEvent{{State: "configured"}})
+	suite.True(err == nil)
+	err = MS.AppendOperationHistory(o.OperationID, []OperationEvent{{State: "inProgress"}, {State: "verifying"}})
+	suite.True(err == nil)
+	h, err = MS.GetOperationHistory(o.OperationID)
+	suite.True(err == nil)
+	suite.Equal(3, len(h))
+	suite.Equal("verifying", h[2].State)
+
+	//the history is not part of the operation
+	op, err := MS.GetOperation(o.OperationID)
+	suite.True(err == nil)
+	data, _ := json.Marshal(ToOperationStorable(op))
+	suite.False(strings.Contains(string(data), "verifying"))
+
+	err = MS.DeleteOperation(o.OperationID)
+	suite.True(err == nil)
+	h, err = MS.GetOperationHistory(o.OperationID)
+	suite.True(err == nil)
+	suite.Equal(0, len(h))
+}
+
 func (suite *Storage_Provider_TS) Test_Storage_Provider_GetOperation_Error() {
 	_, err := MS.GetOperation(uuid.New())
 	suite.True(err != nil)
@@ -0,0 +1,63 @@
+/*
+ * MIT License
+ *
+ * (C) Copyright [2026] Hewlett Packard Enterprise Development LP
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a
+ * copy of this software and associated documentation files (the "Software"),
+ * to deal in the Software without restriction, including without limitation
+ * the rights to use, copy, modify, merge, publish, distribute, sublicense,
+ * and/or sell copies of the Software, and to permit persons to whom the
+ * Software is furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included
+ * in all copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL
+ * THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+ * OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+ * ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+ * OTHER DEALINGS IN THE SOFTWARE.
+ */
+
+package storage
+
+import (
+	"github.com/google/uuid"
+)
+
+func (suite *Storage_Provider_TS) Test_Storage_Provider_StoreExclusionPolicy_HappyPath() {
+	policy := HelperGetStockExclusionPolicy()
+	err := MS.StoreExclusionPolicy(policy)
+	suite.True(err == nil)
+
+	returnPolicy, err := MS.GetExclusionPolicy(policy.PolicyID)
+	suite.True(err == nil)
+	suite.True(returnPolicy.Equals(policy))
+
+	policies, err := MS.GetExclusionPolicies()
+	suite.True(err == nil)
+	count := 0
+	for _, p := range policies {
+		if p.PolicyID == policy.PolicyID {
+			count++
+		}
+	}
+	suite.Equal(1, count)
+
+	err = MS.DeleteExclusionPolicy(policy.PolicyID)
+	suite.True(err == nil)
+
+	// Make sure deleted
+	_, err = MS.GetExclusionPolicy(policy.PolicyID)
+	suite.False(err == nil)
+}
+
+func (suite *Storage_Provider_TS) Test_Storage_Provider_ExclusionPolicy_NotFound() {
+	_, err := MS.GetExclusionPolicy(uuid.New())
+	suite.False(err == nil)
+	err = MS.DeleteExclusionPolicy(uuid.New())
+	suite.False(err == nil)
+}
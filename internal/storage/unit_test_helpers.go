@@ -54,6 +54,33 @@ func HelperGetStockImage() (i Image) {
 	return i
 }
 
+func HelperGetStockCompatibilityRule() (r CompatibilityRule) {
+	r = CompatibilityRule{
+		RuleID:           uuid.New(),
+		Description:      "BIOS 2.x needs BMC 1.5 or later",
+		Type:             CompatibilityRuleRequires,
+		Enforcement:      CompatibilityEnforcementNoSolution,
+		Manufacturer:     "cray",
+		Target:           "BIOS",
+		Versions:         ">=2.0.0",
+		RequiredTarget:   "BMC",
+		RequiredVersions: ">=1.5.0",
+	}
+	r.CreateTime.Scan(time.Now())
+	return r
+}
+
+func HelperGetStockExclusionPolicy() (p ExclusionPolicy) {
+	p = ExclusionPolicy{
+		PolicyID:    uuid.New(),
+		Description: "never touch the management nodes",
+		Roles:       []string{"Management"},
+		Xnames:      []string{"x3000c0s*b0"},
+	}
+	p.CreateTime.Scan(time.Now())
+	return p
+}
+
 func HelperGetStockAction() (a Action) {
 	parameters := ActionParameters{
 		Command: Command{